@@ -0,0 +1,159 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
+	"sigs.k8s.io/descheduler/pkg/descheduler"
+	"sigs.k8s.io/descheduler/pkg/descheduler/client"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
+)
+
+// NewCompareCommand creates a *cobra.Command object for comparing two policies against the same cluster state
+func NewCompareCommand(out io.Writer) *cobra.Command {
+	s, err := options.NewDeschedulerServer()
+	if err != nil {
+		klog.ErrorS(err, "unable to initialize server")
+	}
+	var policyA, policyB, snapshotFile string
+
+	cmd := &cobra.Command{
+		Use:   "compare",
+		Short: "Compare two policies against the same cluster state",
+		Long: `Runs a single descheduling loop of two policies, A and B, against the same cluster state in the dry run mode and prints them side by side: the pods either policy would evict, the utilization of every node and the skew of every topology spread constraint after the loop of either policy.
+The cluster state is read from a snapshot file or, when none is given, captured from the cluster once for both policies.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			var objects []runtime.Object
+			var err error
+			if snapshotFile != "" {
+				if objects, err = descheduler.LoadSnapshot(snapshotFile); err != nil {
+					return err
+				}
+			} else {
+				kubeClient, err := client.CreateClient(s.ClientConnection, "descheduler")
+				if err != nil {
+					return err
+				}
+				if objects, err = descheduler.TakeSnapshot(cmd.Context(), kubeClient); err != nil {
+					return err
+				}
+			}
+
+			policyClient := fakeclientset.NewSimpleClientset(objects...)
+			a, err := descheduler.LoadPolicyConfig(policyA, policyClient, pluginregistry.PluginRegistry)
+			if err != nil {
+				return fmt.Errorf("%s: %v", policyA, err)
+			}
+			b, err := descheduler.LoadPolicyConfig(policyB, policyClient, pluginregistry.PluginRegistry)
+			if err != nil {
+				return fmt.Errorf("%s: %v", policyB, err)
+			}
+			comparison, err := descheduler.ComparePolicies(cmd.Context(), objects, a, b)
+			if err != nil {
+				return err
+			}
+			return printComparison(out, comparison)
+		},
+	}
+	cmd.Flags().StringVar(&policyA, "policy-a", "", "File with the configuration of the descheduler policy A.")
+	cmd.Flags().StringVar(&policyB, "policy-b", "", "File with the configuration of the descheduler policy B.")
+	cmd.Flags().StringVar(&snapshotFile, "snapshot", "", "File with the cluster snapshot to compare the policies against. The cluster state is captured from the cluster when not set.")
+	cmd.Flags().StringVar(&s.ClientConnection.Kubeconfig, "kubeconfig", s.ClientConnection.Kubeconfig, "File with kube configuration. Deprecated, use client-connection-kubeconfig instead.")
+	cmd.Flags().StringVar(&s.ClientConnection.Kubeconfig, "client-connection-kubeconfig", s.ClientConnection.Kubeconfig, "File path to kube configuration for interacting with kubernetes apiserver.")
+	cmd.Flags().Float32Var(&s.ClientConnection.QPS, "client-connection-qps", s.ClientConnection.QPS, "QPS to use for interacting with kubernetes apiserver.")
+	cmd.Flags().Int32Var(&s.ClientConnection.Burst, "client-connection-burst", s.ClientConnection.Burst, "Burst to use for interacting with kubernetes apiserver.")
+	if err := cmd.MarkFlagRequired("policy-a"); err != nil {
+		klog.ErrorS(err, "unable to mark the policy-a flag required")
+	}
+	if err := cmd.MarkFlagRequired("policy-b"); err != nil {
+		klog.ErrorS(err, "unable to mark the policy-b flag required")
+	}
+	return cmd
+}
+
+func printComparison(out io.Writer, comparison *descheduler.PolicyComparison) error {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	var onlyA, onlyB, both int
+	fmt.Fprintln(w, "POD\tNODE\tPOLICY A\tPOLICY B")
+	for _, eviction := range comparison.Evictions {
+		switch {
+		case eviction.A != nil && eviction.B != nil:
+			both++
+		case eviction.A != nil:
+			onlyA++
+		default:
+			onlyB++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", eviction.Pod, eviction.Node, evictingPlugin(eviction.A), evictingPlugin(eviction.B))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%d pod(s) would be evicted by both policies, %d by policy A only, %d by policy B only\n\n", both, onlyA, onlyB)
+
+	fmt.Fprintln(w, "NODE\tCPU BEFORE/A/B\tMEMORY BEFORE/A/B\tPODS BEFORE/A/B")
+	for _, node := range comparison.NodeUtilization {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", node.Node,
+			utilizations(node, v1.ResourceCPU),
+			utilizations(node, v1.ResourceMemory),
+			utilizations(node, v1.ResourcePods),
+		)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if len(comparison.TopologySkew) == 0 {
+		return nil
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintln(w, "NAMESPACE\tTOPOLOGY KEY\tSELECTOR\tWHEN UNSATISFIABLE\tMAX SKEW\tSKEW BEFORE/A/B")
+	for _, skew := range comparison.TopologySkew {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%d/%d/%d\n", skew.Namespace, skew.TopologyKey, skew.Selector, skew.WhenUnsatisfiable, skew.MaxSkew, skew.Before, skew.A, skew.B)
+	}
+	return w.Flush()
+}
+
+// evictingPlugin names the profile, plugin and extension point evicting a pod, or "-" when the pod is not evicted
+func evictingPlugin(opts *evictions.EvictOptions) string {
+	if opts == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%s/%s/%s", opts.ProfileName, opts.PluginName, opts.ExtensionPoint)
+}
+
+// utilizations formats the utilization of a resource of a node before and after the loop of either policy
+func utilizations(node descheduler.NodeUtilizationComparison, resourceName v1.ResourceName) string {
+	before, ok := node.Before[resourceName]
+	if !ok {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f/%.2f/%.2f%%", before, node.A[resourceName], node.B[resourceName])
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/types"
+	clientset "k8s.io/client-go/kubernetes"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
+	"sigs.k8s.io/descheduler/pkg/descheduler"
+	"sigs.k8s.io/descheduler/pkg/descheduler/client"
+	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
+)
+
+// NewExplainCommand creates a *cobra.Command object for explaining why a pod would or would not be evicted
+func NewExplainCommand(out io.Writer) *cobra.Command {
+	s, err := options.NewDeschedulerServer()
+	if err != nil {
+		klog.ErrorS(err, "unable to initialize server")
+	}
+	var pod, policyConfigFile, snapshotFile string
+
+	cmd := &cobra.Command{
+		Use:   "explain",
+		Short: "Explain why a pod would or would not be evicted",
+		Long: `Evaluates a pod against every profile of the policy and prints the verdict of every Filter and PreEvictionFilter plugin and of every Deschedule and Balance plugin able to explain its decision about a single pod, with its reason.
+It then runs a single descheduling loop of the policy in the dry run mode and prints the decisions taken about the pod.
+The pod is read from a snapshot file or, when none is given, from the cluster.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			namespace, name, err := cache.SplitMetaNamespaceKey(pod)
+			if err != nil || namespace == "" || name == "" {
+				return fmt.Errorf("invalid pod %q, expected namespace/name", pod)
+			}
+
+			var kubeClient clientset.Interface
+			if snapshotFile != "" {
+				objects, err := descheduler.LoadSnapshot(snapshotFile)
+				if err != nil {
+					return err
+				}
+				kubeClient = fakeclientset.NewSimpleClientset(objects...)
+			} else {
+				if kubeClient, err = client.CreateClient(s.ClientConnection, "descheduler"); err != nil {
+					return err
+				}
+			}
+
+			policy, err := descheduler.LoadPolicyConfig(policyConfigFile, kubeClient, pluginregistry.PluginRegistry)
+			if err != nil {
+				return err
+			}
+			explanation, err := descheduler.ExplainPod(cmd.Context(), kubeClient, policy, types.NamespacedName{Namespace: namespace, Name: name})
+			if err != nil {
+				return err
+			}
+			return printExplanation(out, explanation)
+		},
+	}
+	cmd.Flags().StringVar(&pod, "pod", "", "Pod to explain, in the namespace/name format.")
+	cmd.Flags().StringVar(&policyConfigFile, "policy-config-file", "", "File with descheduler policy configuration.")
+	cmd.Flags().StringVar(&snapshotFile, "snapshot", "", "File with the cluster snapshot to explain the pod against. The pod is read from the cluster when not set.")
+	cmd.Flags().StringVar(&s.ClientConnection.Kubeconfig, "kubeconfig", s.ClientConnection.Kubeconfig, "File with kube configuration. Deprecated, use client-connection-kubeconfig instead.")
+	cmd.Flags().StringVar(&s.ClientConnection.Kubeconfig, "client-connection-kubeconfig", s.ClientConnection.Kubeconfig, "File path to kube configuration for interacting with kubernetes apiserver.")
+	cmd.Flags().Float32Var(&s.ClientConnection.QPS, "client-connection-qps", s.ClientConnection.QPS, "QPS to use for interacting with kubernetes apiserver.")
+	cmd.Flags().Int32Var(&s.ClientConnection.Burst, "client-connection-burst", s.ClientConnection.Burst, "Burst to use for interacting with kubernetes apiserver.")
+	if err := cmd.MarkFlagRequired("pod"); err != nil {
+		klog.ErrorS(err, "unable to mark the pod flag required")
+	}
+	if err := cmd.MarkFlagRequired("policy-config-file"); err != nil {
+		klog.ErrorS(err, "unable to mark the policy-config-file flag required")
+	}
+	return cmd
+}
+
+func printExplanation(out io.Writer, explanation *descheduler.PodExplanation) error {
+	fmt.Fprintf(out, "Pod %s on node %s\n", explanation.Pod, explanation.Node)
+	if !explanation.NodeProcessed {
+		fmt.Fprintf(out, "The node %s is not ready or does not match the node selector of the policy, so no plugin processes the pod\n", explanation.Node)
+	}
+	fmt.Fprintln(out)
+
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "PROFILE\tPLUGIN\tEXTENSION POINT\tVERDICT\tREASON")
+	for _, profile := range explanation.Profiles {
+		if profile.Error != "" {
+			fmt.Fprintf(w, "%s\t-\t-\t-\t%s\n", profile.Profile, profile.Error)
+			continue
+		}
+		for _, verdict := range profile.Verdicts {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", profile.Profile, verdict.Plugin, verdict.ExtensionPoint, verdict.Result, orDash(verdict.Reason))
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out)
+	if len(explanation.Limits) == 0 {
+		_, err := fmt.Fprintln(out, "The policy sets no limit across the descheduling loops")
+		return err
+	}
+	fmt.Fprintln(w, "LIMIT\tALLOWED\tREASON")
+	for _, limit := range explanation.Limits {
+		fmt.Fprintf(w, "%s\t%t\t%s\n", limit.Limit, limit.Allowed, orDash(limit.Reason))
+	}
+	return w.Flush()
+}
+
+// orDash returns the string, or "-" when it is empty
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
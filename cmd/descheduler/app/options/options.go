@@ -23,6 +23,7 @@ import (
 	"github.com/spf13/pflag"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apiserveroptions "k8s.io/apiserver/pkg/server/options"
+	"k8s.io/client-go/dynamic"
 	clientset "k8s.io/client-go/kubernetes"
 	componentbaseconfig "k8s.io/component-base/config"
 	componentbaseoptions "k8s.io/component-base/config/options"
@@ -33,7 +34,19 @@ import (
 )
 
 const (
-	DefaultDeschedulerPort = 10258
+	DefaultDeschedulerPort    = 10258
+	DefaultPolicyConfigMapKey = "policy.yaml"
+	DefaultReportFormat       = "json"
+	// DryRunModeClient evicts the pods from an in-memory copy of the cluster in the dry run mode
+	DryRunModeClient = "client"
+	// DryRunModeServer sends the evictions to the apiserver as dry run requests in the dry run mode
+	DryRunModeServer = "server"
+	// DefaultDryRunScoringStrategy is the scoring strategy the pods evicted in the dry run mode are rescheduled with
+	DefaultDryRunScoringStrategy = "LeastAllocated"
+	// DefaultEvictionRetryBackoff is the initial backoff of the retries of the evictions blocked by a pod disruption budget
+	DefaultEvictionRetryBackoff = time.Second
+	// DefaultPlanExpiration is how long an eviction plan waits for its approval before it gets replaced
+	DefaultPlanExpiration = time.Hour
 )
 
 // DeschedulerServer configuration
@@ -45,6 +58,44 @@ type DeschedulerServer struct {
 	SecureServing  *apiserveroptions.SecureServingOptionsWithLoopback
 	DisableMetrics bool
 	EnableHTTP2    bool
+
+	// PolicyConfigMap references the ConfigMap holding the policy as namespace/name
+	PolicyConfigMap string
+	// PolicyConfigMapKey is the PolicyConfigMap key holding the policy
+	PolicyConfigMapKey string
+	// ReloadPolicy reloads the policy in between descheduling loops
+	ReloadPolicy bool
+	// PolicyResource is the name of the DeschedulerPolicy custom resource holding the policy
+	PolicyResource string
+	// DynamicClient accesses the DeschedulerPolicy custom resources
+	DynamicClient dynamic.Interface
+	// ReportFile is the file the report of every descheduling loop is appended to
+	ReportFile string
+	// ReportFormat is the format of the reports, json or yaml
+	ReportFormat string
+	// DryRunMode is the dry run mode, client or server
+	DryRunMode string
+	// DryRunReschedule binds the replacement of every pod evicted in the dry run mode to the best fitting node
+	DryRunReschedule bool
+	// DryRunScoringStrategy is the strategy the nodes are scored with when rescheduling, LeastAllocated or MostAllocated
+	DryRunScoringStrategy string
+	// PlanConfigMap references the ConfigMap the eviction plan is written to as namespace/name,
+	// making the descheduling loops plan the evictions instead of performing them
+	PlanConfigMap string
+	// ApplyPlan applies a pending eviction plan without waiting for its approval
+	ApplyPlan bool
+	// PlanExpiration is how long a pending eviction plan can get applied for, zero meaning it never expires
+	PlanExpiration time.Duration
+	// EvictionRetryDeadline is how long the evictions blocked by a pod disruption budget are retried for
+	// within a descheduling loop, zero disabling the retries
+	EvictionRetryDeadline time.Duration
+	// EvictionRetryBackoff is the initial backoff of the eviction retries, doubled after every attempt
+	EvictionRetryBackoff time.Duration
+	// EvictionHistoryConfigMap references the ConfigMap the eviction history is persisted to as namespace/name,
+	// so the workload eviction budget and the eviction cooldown hold across restarts
+	EvictionHistoryConfigMap string
+	// EnableExplainEndpoint serves the explanation of why a pod would or would not be evicted
+	EnableExplainEndpoint bool
 }
 
 // NewDeschedulerServer creates a new DeschedulerServer with default parameters
@@ -60,6 +111,12 @@ func NewDeschedulerServer() (*DeschedulerServer, error) {
 	return &DeschedulerServer{
 		DeschedulerConfiguration: *cfg,
 		SecureServing:            secureServing,
+		PolicyConfigMapKey:       DefaultPolicyConfigMapKey,
+		ReportFormat:             DefaultReportFormat,
+		DryRunMode:               DryRunModeClient,
+		DryRunScoringStrategy:    DefaultDryRunScoringStrategy,
+		EvictionRetryBackoff:     DefaultEvictionRetryBackoff,
+		PlanExpiration:           DefaultPlanExpiration,
 	}, nil
 }
 
@@ -94,7 +151,23 @@ func (rs *DeschedulerServer) AddFlags(fs *pflag.FlagSet) {
 	fs.Float32Var(&rs.ClientConnection.QPS, "client-connection-qps", rs.ClientConnection.QPS, "QPS to use for interacting with kubernetes apiserver.")
 	fs.Int32Var(&rs.ClientConnection.Burst, "client-connection-burst", rs.ClientConnection.Burst, "Burst to use for interacting with kubernetes apiserver.")
 	fs.StringVar(&rs.PolicyConfigFile, "policy-config-file", rs.PolicyConfigFile, "File with descheduler policy configuration.")
+	fs.StringVar(&rs.PolicyConfigMap, "policy-config-map", rs.PolicyConfigMap, "ConfigMap with descheduler policy configuration, in the namespace/name format. Mutually exclusive with --policy-config-file.")
+	fs.StringVar(&rs.PolicyConfigMapKey, "policy-config-map-key", rs.PolicyConfigMapKey, "Key of the --policy-config-map ConfigMap holding the descheduler policy configuration.")
+	fs.StringVar(&rs.PolicyResource, "policy-resource", rs.PolicyResource, "Name of the cluster-scoped DeschedulerPolicy custom resource with descheduler policy configuration. The policy is reloaded before every descheduling loop and its status is reported on the resource. Mutually exclusive with --policy-config-file and --policy-config-map.")
+	fs.BoolVar(&rs.ReloadPolicy, "reload-policy", rs.ReloadPolicy, "Reload the descheduler policy from its file or ConfigMap before every descheduling loop. An invalid policy is reported and the last valid one is kept.")
 	fs.BoolVar(&rs.DryRun, "dry-run", rs.DryRun, "Execute descheduler in dry run mode.")
+	fs.StringVar(&rs.DryRunMode, "dry-run-mode", rs.DryRunMode, `Mode of the dry run. Permitted modes: "client", evicting the pods from an in-memory copy of the cluster, and "server", sending the evictions to the apiserver as dry run requests, validated against the RBAC, pod disruption budgets and admission webhooks without deleting the pods.`)
+	fs.BoolVar(&rs.DryRunReschedule, "dry-run-reschedule", rs.DryRunReschedule, "Simulate the rescheduling of the pods evicted in the dry run mode, binding the replacement their owner would create to the best fitting node, so the later plugins see the replacements instead of the capacity vanishing.")
+	fs.StringVar(&rs.DryRunScoringStrategy, "dry-run-scoring-strategy", rs.DryRunScoringStrategy, `Strategy the nodes the replacement pods fit on are scored with by --dry-run-reschedule. Permitted strategies: "LeastAllocated", spreading the pods, and "MostAllocated", bin-packing them.`)
+	fs.StringVar(&rs.ReportFile, "report-file", rs.ReportFile, "File to write a machine-readable report of every descheduling loop to, listing the pods rejected by a plugin and the pods whose eviction got requested. The file is truncated at start and every loop appends its report.")
+	fs.StringVar(&rs.ReportFormat, "report-format", rs.ReportFormat, `Format of the --report-file reports. Permitted formats: "json", "yaml".`)
+	fs.StringVar(&rs.PlanConfigMap, "plan-config-map", rs.PlanConfigMap, "ConfigMap to write the eviction plan to, in the namespace/name format. The descheduling loops plan the evictions instead of performing them, and apply the plan once the ConfigMap is annotated with descheduler.alpha.kubernetes.io/plan-approved=true. Mutually exclusive with --dry-run.")
+	fs.BoolVar(&rs.ApplyPlan, "apply-plan", rs.ApplyPlan, "Apply the pending eviction plan of --plan-config-map without waiting for its approval.")
+	fs.DurationVar(&rs.PlanExpiration, "plan-expiration", rs.PlanExpiration, "How long a pending eviction plan of --plan-config-map can get applied for since it got produced. An expired plan is replaced by a new one, as the cluster drifted from the state it got reviewed against. Zero keeps the plans until they get applied.")
+	fs.DurationVar(&rs.EvictionRetryDeadline, "eviction-retry-deadline", rs.EvictionRetryDeadline, "How long to retry the evictions blocked by a pod disruption budget at the end of a descheduling loop. The blocked evictions are queued while the plugins run and retried with an exponential backoff once they ran. Zero disables the retries, as does --dry-run.")
+	fs.DurationVar(&rs.EvictionRetryBackoff, "eviction-retry-backoff", rs.EvictionRetryBackoff, "Initial backoff of the retries of the evictions blocked by a pod disruption budget, doubled after every attempt.")
+	fs.StringVar(&rs.EvictionHistoryConfigMap, "eviction-history-config-map", rs.EvictionHistoryConfigMap, "ConfigMap to persist the history of the evictions the workload eviction budget and the eviction cooldown of the policy are enforced against, in the namespace/name format. Unset, the history is kept in memory and lost on restart.")
+	fs.BoolVar(&rs.EnableExplainEndpoint, "enable-explain-endpoint", rs.EnableExplainEndpoint, "Serve the explanation of why a pod would or would not be evicted by the policy of the last descheduling loop through https://localhost:10258/debug/explain?pod=namespace/name. A single explanation runs at a time.")
 	fs.BoolVar(&rs.DisableMetrics, "disable-metrics", rs.DisableMetrics, "Disables metrics. The metrics are by default served through https://localhost:10258/metrics. Secure address, resp. port can be changed through --bind-address, resp. --secure-port flags.")
 	fs.StringVar(&rs.Tracing.CollectorEndpoint, "otel-collector-endpoint", "", "Set this flag to the OpenTelemetry Collector Service Address")
 	fs.StringVar(&rs.Tracing.TransportCert, "otel-transport-ca-cert", "", "Path of the CA Cert that can be used to generate the client Certificate for establishing secure connection to the OTEL in gRPC mode")
@@ -0,0 +1,115 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler"
+	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
+)
+
+// NewPolicyCommand creates a *cobra.Command object for working with policy files offline
+func NewPolicyCommand(out io.Writer) *cobra.Command {
+	policyCmd := &cobra.Command{
+		Use:   "policy",
+		Short: "Work with descheduler policy files",
+		Long:  `Validates, converts and defaults descheduler policy files. None of the subcommands needs a cluster.`,
+	}
+	policyCmd.AddCommand(
+		newPolicyValidateCommand(out),
+		newPolicyConvertCommand(out),
+		newPolicyPrintDefaultsCommand(out),
+	)
+	return policyCmd
+}
+
+func newPolicyValidateCommand(out io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate FILE...",
+		Short: "Validate policy files",
+		Long:  `Decodes the policy files and validates them against the registered plugins. Exits with a non-zero code when any of the files is invalid.`,
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			var errs []error
+			for _, file := range args {
+				if _, err := loadPolicyOffline(file); err != nil {
+					errs = append(errs, fmt.Errorf("%s: %v", file, err))
+					continue
+				}
+				fmt.Fprintf(out, "%s: valid\n", file)
+			}
+			return utilerrors.NewAggregate(errs)
+		},
+	}
+}
+
+func newPolicyConvertCommand(out io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "convert FILE",
+		Short: "Convert a v1alpha1 policy file to v1alpha2",
+		Long:  `Converts the strategies of a v1alpha1 policy file into the profiles of a v1alpha2 policy and prints it as YAML. No defaults are set.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			policy, err := descheduler.ConvertPolicyConfig(args[0])
+			if err != nil {
+				return err
+			}
+			return printPolicy(out, policy)
+		},
+	}
+}
+
+func newPolicyPrintDefaultsCommand(out io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "print-defaults FILE",
+		Short: "Print a policy file with all the defaults set",
+		Long:  `Validates the policy file and prints it as a v1alpha2 YAML policy with all the defaults set, including the DefaultEvictor plugin and the default plugin arguments.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			policy, err := loadPolicyOffline(args[0])
+			if err != nil {
+				return err
+			}
+			return printPolicy(out, policy)
+		},
+	}
+}
+
+// loadPolicyOffline loads the policy without any cluster.
+// The priority thresholds referencing a priority class by its name can not be resolved.
+func loadPolicyOffline(file string) (*api.DeschedulerPolicy, error) {
+	return descheduler.LoadPolicyConfig(file, fakeclientset.NewSimpleClientset(), pluginregistry.PluginRegistry)
+}
+
+func printPolicy(out io.Writer, policy *api.DeschedulerPolicy) error {
+	data, err := descheduler.EncodePolicy(policy)
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(data)
+	return err
+}
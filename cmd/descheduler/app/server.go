@@ -91,6 +91,12 @@ func NewDeschedulerCommand(out io.Writer) *cobra.Command {
 			}
 
 			healthz.InstallHandler(pathRecorderMux, healthz.NamedCheck("Descheduler", healthz.PingHealthz.Check))
+			var runOpts []descheduler.RunOption
+			if s.EnableExplainEndpoint {
+				explainHandler := descheduler.NewExplainHandler()
+				pathRecorderMux.Handle(descheduler.ExplainPath, explainHandler)
+				runOpts = append(runOpts, descheduler.WithExplainHandler(explainHandler))
+			}
 
 			stoppedCh, _, err := SecureServing.Serve(pathRecorderMux, 0, ctx.Done())
 			if err != nil {
@@ -98,7 +104,7 @@ func NewDeschedulerCommand(out io.Writer) *cobra.Command {
 				return
 			}
 
-			err = Run(ctx, s)
+			err = Run(ctx, s, runOpts...)
 			if err != nil {
 				klog.ErrorS(err, "descheduler server")
 			}
@@ -114,7 +120,7 @@ func NewDeschedulerCommand(out io.Writer) *cobra.Command {
 	return cmd
 }
 
-func Run(ctx context.Context, rs *options.DeschedulerServer) error {
+func Run(ctx context.Context, rs *options.DeschedulerServer, opts ...descheduler.RunOption) error {
 	err := tracing.NewTracerProvider(ctx, rs.Tracing.CollectorEndpoint, rs.Tracing.TransportCert, rs.Tracing.ServiceName, rs.Tracing.ServiceNamespace, rs.Tracing.SampleRate, rs.Tracing.FallbackToNoOpProviderOnError)
 	if err != nil {
 		return err
@@ -123,7 +129,7 @@ func Run(ctx context.Context, rs *options.DeschedulerServer) error {
 	// increase the fake watch channel so the dry-run mode can be run
 	// over a cluster with thousands of pods
 	watch.DefaultChanSize = 100000
-	return descheduler.Run(ctx, rs)
+	return descheduler.Run(ctx, rs, opts...)
 }
 
 func SetupLogs() {
@@ -0,0 +1,116 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/nodeutilization"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/podlifetime"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removeduplicates"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removefailedpods"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodshavingtoomanyrestarts"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatinginterpodantiaffinity"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatingnodeaffinity"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatingnodetaints"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatingtopologyspreadconstraint"
+)
+
+// pluginReasons explains why the built-in plugins evict pods, for the evictions requested without a reason
+var pluginReasons = map[string]string{
+	nodeutilization.LowNodeUtilizationPluginName:           "node over the target utilization",
+	nodeutilization.HighNodeUtilizationPluginName:          "node under the utilization threshold",
+	podlifetime.PluginName:                                 "pod older than the maximum lifetime",
+	removeduplicates.PluginName:                            "duplicate pod of the same owner on the node",
+	removefailedpods.PluginName:                            "failed pod",
+	removepodshavingtoomanyrestarts.PluginName:             "pod restarted too many times",
+	removepodsviolatinginterpodantiaffinity.PluginName:     "pod violating an inter-pod anti-affinity",
+	removepodsviolatingnodeaffinity.PluginName:             "pod violating its node affinity",
+	removepodsviolatingnodetaints.PluginName:               "pod not tolerating a node taint",
+	removepodsviolatingtopologyspreadconstraint.PluginName: "pod violating a topology spread constraint",
+}
+
+// NewSimulateCommand creates a *cobra.Command object for running a policy against a cluster snapshot
+func NewSimulateCommand(out io.Writer) *cobra.Command {
+	var policyConfigFile, snapshotFile string
+	cmd := &cobra.Command{
+		Use:   "simulate",
+		Short: "Run a policy against a cluster snapshot",
+		Long: `Runs a single descheduling loop of the policy against the nodes, pods, namespaces, priority classes and pod disruption budgets of a snapshot file, with no cluster at all, and prints the pods each plugin would evict.
+The snapshot file holds YAML or JSON objects, either one per document or as the items of a v1 List.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			objects, err := descheduler.LoadSnapshot(snapshotFile)
+			if err != nil {
+				return err
+			}
+			client := fakeclientset.NewSimpleClientset(objects...)
+			policy, err := descheduler.LoadPolicyConfig(policyConfigFile, client, pluginregistry.PluginRegistry)
+			if err != nil {
+				return err
+			}
+			evicted, err := descheduler.Simulate(cmd.Context(), client, policy)
+			if err != nil {
+				return err
+			}
+			return printEvictions(out, evicted)
+		},
+	}
+	cmd.Flags().StringVar(&policyConfigFile, "policy-config-file", "", "File with descheduler policy configuration.")
+	cmd.Flags().StringVar(&snapshotFile, "snapshot", "", "File with the cluster snapshot to run the policy against.")
+	if err := cmd.MarkFlagRequired("policy-config-file"); err != nil {
+		klog.ErrorS(err, "unable to mark the policy-config-file flag required")
+	}
+	if err := cmd.MarkFlagRequired("snapshot"); err != nil {
+		klog.ErrorS(err, "unable to mark the snapshot flag required")
+	}
+	return cmd
+}
+
+func printEvictions(out io.Writer, evicted []evictions.Eviction) error {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "PROFILE\tPLUGIN\tEXTENSION POINT\tPOD\tNODE\tREASON")
+	for _, eviction := range evicted {
+		reason := eviction.Opts.Reason
+		if reason == "" {
+			reason = orDash(pluginReasons[eviction.Opts.PluginName])
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s/%s\t%s\t%s\n",
+			eviction.Opts.ProfileName,
+			eviction.Opts.PluginName,
+			eviction.Opts.ExtensionPoint,
+			eviction.Pod.Namespace, eviction.Pod.Name,
+			eviction.Pod.Spec.NodeName,
+			reason,
+		)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(out, "%d pod(s) would be evicted\n", len(evicted))
+	return err
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"crypto/rand"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
+	"sigs.k8s.io/descheduler/pkg/descheduler"
+	"sigs.k8s.io/descheduler/pkg/descheduler/client"
+)
+
+// NewSnapshotCommand creates a *cobra.Command object for capturing the cluster state the descheduler sees
+func NewSnapshotCommand(out io.Writer) *cobra.Command {
+	s, err := options.NewDeschedulerServer()
+	if err != nil {
+		klog.ErrorS(err, "unable to initialize server")
+	}
+	var outputFile string
+	var anonymize bool
+
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Capture the cluster state the descheduler sees",
+		Long: `Writes the nodes, pods, namespaces, priority classes and pod disruption budgets of the cluster, along with the owners of the pods, as a snapshot file to be run against by the simulate command.
+The names, labels and other identifying values can be anonymized, e.g. to attach the snapshot to a bug report.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			kubeClient, err := client.CreateClient(s.ClientConnection, "descheduler")
+			if err != nil {
+				return err
+			}
+			objects, err := descheduler.TakeSnapshot(cmd.Context(), kubeClient)
+			if err != nil {
+				return err
+			}
+			if anonymize {
+				// a random salt keeps the anonymized values from being matched against guessed ones
+				salt := make([]byte, 32)
+				if _, err := rand.Read(salt); err != nil {
+					return err
+				}
+				objects, err = descheduler.AnonymizeSnapshot(objects, salt)
+				if err != nil {
+					return err
+				}
+			}
+
+			w := out
+			if outputFile != "" {
+				f, err := os.Create(outputFile)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				w = f
+			}
+			return descheduler.WriteSnapshot(w, objects)
+		},
+	}
+	cmd.Flags().StringVar(&s.ClientConnection.Kubeconfig, "kubeconfig", s.ClientConnection.Kubeconfig, "File with kube configuration. Deprecated, use client-connection-kubeconfig instead.")
+	cmd.Flags().StringVar(&s.ClientConnection.Kubeconfig, "client-connection-kubeconfig", s.ClientConnection.Kubeconfig, "File path to kube configuration for interacting with kubernetes apiserver.")
+	cmd.Flags().Float32Var(&s.ClientConnection.QPS, "client-connection-qps", s.ClientConnection.QPS, "QPS to use for interacting with kubernetes apiserver.")
+	cmd.Flags().Int32Var(&s.ClientConnection.Burst, "client-connection-burst", s.ClientConnection.Burst, "Burst to use for interacting with kubernetes apiserver.")
+	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "File to write the snapshot to. The snapshot is written to the standard output when not set.")
+	cmd.Flags().BoolVar(&anonymize, "anonymize", false, "Replace the names, labels and other identifying values with salted hashes, consistently across all the objects.")
+	return cmd
+}
@@ -33,6 +33,11 @@ func main() {
 	out := os.Stdout
 	cmd := app.NewDeschedulerCommand(out)
 	cmd.AddCommand(app.NewVersionCommand())
+	cmd.AddCommand(app.NewPolicyCommand(out))
+	cmd.AddCommand(app.NewSnapshotCommand(out))
+	cmd.AddCommand(app.NewSimulateCommand(out))
+	cmd.AddCommand(app.NewCompareCommand(out))
+	cmd.AddCommand(app.NewExplainCommand(out))
 
 	code := cli.Run(cmd)
 	os.Exit(code)
@@ -13,6 +13,11 @@ var docGenPath = "docs/cli"
 func main() {
 	cmd := app.NewDeschedulerCommand(os.Stdout)
 	cmd.AddCommand(app.NewVersionCommand())
+	cmd.AddCommand(app.NewPolicyCommand(os.Stdout))
+	cmd.AddCommand(app.NewSnapshotCommand(os.Stdout))
+	cmd.AddCommand(app.NewSimulateCommand(os.Stdout))
+	cmd.AddCommand(app.NewCompareCommand(os.Stdout))
+	cmd.AddCommand(app.NewExplainCommand(os.Stdout))
 	cmd.DisableAutoGenTag = true // Disable this so that the diff wont track it
 	if err := doc.GenMarkdownTree(cmd, docGenPath); err != nil {
 		log.Fatal(err)
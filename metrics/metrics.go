@@ -38,6 +38,14 @@ var (
 			StabilityLevel: metrics.ALPHA,
 		}, []string{"result", "strategy", "namespace", "node"})
 
+	PodsEvictedTotal = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "pods_evicted_total",
+			Help:           "Number of evicted pods, by the result, by the error reason, by the strategy, by the profile, by the namespace, by the node name. 'error' result means a pod could not be evicted, the error reason telling why",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"result", "error_reason", "strategy", "profile", "namespace", "node"})
+
 	buildInfo = metrics.NewGauge(
 		&metrics.GaugeOpts{
 			Subsystem:      DeschedulerSubsystem,
@@ -66,11 +74,102 @@ var (
 			Buckets:        []float64{0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 25, 50, 100},
 		}, []string{"strategy", "profile"})
 
+	PluginStatus = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "plugin_status_total",
+			Help:           "Number of plugin runs, by the strategy, by the profile, by the extension point, by the status code. 'Skip' code means the plugin had nothing to do, 'NoAction' code means none of the evaluated pods could be evicted",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"strategy", "profile", "extension_point", "code"})
+
+	PluginPods = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "plugin_pods_total",
+			Help:           "Number of pods processed by plugins, by the strategy, by the profile, by the result. 'evaluated' result counts the pods a plugin tried to evict, 'evicted', 'skipped' and 'queued' results split them by outcome, 'queued' counting the evictions blocked by a pod disruption budget and queued for a retry at the end of the loop",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"strategy", "profile", "result"})
+
+	PodsFiltered = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "pods_filtered",
+			Help:           "Number of pods rejected by the Filter and PreEvictionFilter plugins, counted once per run of the strategy proposing the pod, by the reason with one per failed check, by the plugin rejecting the pod, by the extension point, by the strategy proposing the pod, by the profile. 'unknown' reason means the plugin does not tell",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"reason", "plugin", "extension_point", "strategy", "profile"})
+
+	PodFinalizersRemoved = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "pod_finalizers_removed_total",
+			Help:           "Number of pods whose finalizers got removed by the delete fallback once evicted or deleted, by the result, by the strategy, by the profile, by the namespace, by the node name",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"result", "strategy", "profile", "namespace", "node"})
+
+	EvictionsRateLimited = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "evictions_rate_limited_total",
+			Help:           "Number of evictions delayed by the eviction rate limit, by the strategy, by the profile",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"strategy", "profile"})
+
+	PodsDeleted = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "pods_deleted_total",
+			Help:           "Number of pods deleted directly because their eviction was disallowed, by the result, by the strategy, by the profile, by the namespace, by the node name. Such deletions bypass the pod disruption budgets",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"result", "strategy", "profile", "namespace", "node"})
+
+	EvictionsFlapping = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "evictions_flapping_total",
+			Help:           "Number of evictions skipped by the eviction cooldown because the pod got created on a node after pods of the same owner were evicted from it within the cooldown window, by the strategy, by the profile, by the namespace, by the node name",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"strategy", "profile", "namespace", "node"})
+
+	PolicyReloads = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "policy_reloads_total",
+			Help:           "Number of descheduler policy reloads, by the result. 'error' result means the policy could not be read, decoded or validated and the last valid policy is kept",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"result"})
+
+	PolicyLastReloadSuccessful = metrics.NewGauge(
+		&metrics.GaugeOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "policy_last_reload_successful",
+			Help:           "Whether the last descheduler policy reload succeeded (1) or failed (0)",
+			StabilityLevel: metrics.ALPHA,
+		})
+
+	EvictionHistoryErrors = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "eviction_history_errors_total",
+			Help:           "Number of failures to load or save the eviction history ConfigMap, by the operation. The history is kept in memory and the operation is retried on the next loop",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"operation"})
+
 	metricsList = []metrics.Registerable{
 		PodsEvicted,
+		PodsEvictedTotal,
 		buildInfo,
 		DeschedulerLoopDuration,
 		DeschedulerStrategyDuration,
+		PluginStatus,
+		PluginPods,
+		PodsFiltered,
+		EvictionsRateLimited,
+		PodsDeleted,
+		PodFinalizersRemoved,
+		EvictionsFlapping,
+		PolicyReloads,
+		PolicyLastReloadSuccessful,
+		EvictionHistoryErrors,
 	}
 )
 
@@ -20,6 +20,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -38,6 +39,100 @@ type DeschedulerPolicy struct {
 
 	// MaxNoOfPodsToEvictPerNamespace restricts maximum of pods to be evicted per namespace.
 	MaxNoOfPodsToEvictPerNamespace *uint
+
+	// MaxNoOfPodsToEvictTotal restricts maximum of pods to be evicted per descheduling loop.
+	MaxNoOfPodsToEvictTotal *uint
+
+	// EvictionRateLimit paces the evictions. Unset, the evictions are requested as fast as the plugins ask for them.
+	EvictionRateLimit *EvictionRateLimit
+
+	// GracePeriodSeconds overrides the termination grace period of the evicted pods.
+	// Unset, the pods terminate within their own grace period.
+	GracePeriodSeconds *int64
+
+	// DeleteFallback deletes the pods directly when their eviction is disallowed.
+	// Unset, such pods are not evicted.
+	DeleteFallback *DeleteFallback
+
+	// WorkloadEvictionBudget caps the evictions of the pods of every workload across the descheduling loops.
+	// Unset, the workloads are only protected by their pod disruption budgets.
+	WorkloadEvictionBudget *WorkloadEvictionBudget
+
+	// EvictionCooldown keeps the pods of an owner from being evicted again within a window since one of them got evicted.
+	// Unset, the pods are evicted regardless of the previous evictions.
+	EvictionCooldown *EvictionCooldown
+
+	// Parallelism is the maximum number of profiles running their Deschedule extension point
+	// concurrently, and of nodes processed concurrently by plugins supporting it.
+	// Defaults to 1, i.e. everything runs sequentially.
+	Parallelism *uint
+}
+
+// RatePeriod is the unit of time an eviction rate is expressed in
+type RatePeriod string
+
+const (
+	// Second expresses the rate in evictions per second
+	Second RatePeriod = "Second"
+	// Minute expresses the rate in evictions per minute
+	Minute RatePeriod = "Minute"
+)
+
+// EvictionRateLimit limits the rate of evictions through a token bucket
+type EvictionRateLimit struct {
+	// Evictions is the number of evictions allowed per period
+	Evictions uint
+	// Period is the unit of time of the rate, Second or Minute. Defaults to Second.
+	Period RatePeriod
+	// Burst is the number of evictions which can be requested at once, i.e. the size of the bucket. Defaults to 1.
+	Burst *uint
+}
+
+// DeleteFallback deletes the pods whose eviction is rejected as forbidden or not supported,
+// e.g. because the eviction subresource is disallowed by the RBAC or an admission webhook.
+// Deleting a pod bypasses its pod disruption budget, so the fallback is limited to the pods in the given phases,
+// which cannot be Running nor Pending. Neither an eviction nor a deletion removes the finalizers of a pod,
+// which RemoveFinalizers does for the pods in the given phases.
+type DeleteFallback struct {
+	// PodPhases are the phases of the pods deleted when their eviction is disallowed.
+	// Defaults to Failed and Succeeded, as the pod disruption budgets do not protect such pods.
+	// Running and Pending are rejected, as deleting such pods would bypass their pod disruption budgets.
+	PodPhases []v1.PodPhase
+	// RemoveFinalizers removes the finalizers of the pods in PodPhases once they got evicted or deleted,
+	// so they do not linger when the controller owning a finalizer is gone. Off by default.
+	RemoveFinalizers bool
+}
+
+// WorkloadEvictionBudget caps the number of pods of a workload, i.e. a Deployment, a StatefulSet or a ReplicaSet
+// not managed by a Deployment, evicted within a sliding window. The evictions are tracked across the descheduling loops.
+// The pods owned by other controllers, or by none, are not subject to the budget.
+type WorkloadEvictionBudget struct {
+	// MaxEvictions is the number of pods of a workload which can be evicted within the window,
+	// either absolute or a percentage of the replicas of the workload, rounded up
+	MaxEvictions intstr.IntOrString
+	// WindowSeconds is the length of the sliding window the evictions are counted over
+	WindowSeconds uint
+}
+
+// CooldownScope is what an eviction cooldown applies to
+type CooldownScope string
+
+const (
+	// NodeCooldownScope applies the cooldown to the pods of the owner on the node a pod got evicted from
+	NodeCooldownScope CooldownScope = "Node"
+	// OwnerCooldownScope applies the cooldown to all the pods of the owner
+	OwnerCooldownScope CooldownScope = "Owner"
+)
+
+// EvictionCooldown keeps the pods of an owner, i.e. the controller of a pod or the Deployment of its ReplicaSet,
+// from being evicted again within a window since one of them got evicted, so the replacement of an evicted pod
+// scheduled back onto the same node is not evicted again every descheduling loop. The evictions of a loop
+// start the cooldown for the next loops. The pods without owner are not subject to it.
+type EvictionCooldown struct {
+	// WindowSeconds is how long the cooldown lasts after an eviction
+	WindowSeconds uint
+	// Scope is what the cooldown applies to, Node or Owner. Defaults to Node.
+	Scope CooldownScope
 }
 
 // Namespaces carries a list of included/excluded namespaces
@@ -66,6 +161,9 @@ type DeschedulerProfile struct {
 type PluginConfig struct {
 	Name string
 	Args runtime.Object
+	// GracePeriodSeconds overrides the termination grace period of the pods evicted by the plugin,
+	// taking precedence over the one of the policy
+	GracePeriodSeconds *int64
 }
 
 type Plugins struct {
@@ -75,6 +173,8 @@ type Plugins struct {
 	Balance           PluginSet
 	Filter            PluginSet
 	PreEvictionFilter PluginSet
+	PreEvict          PluginSet
+	PostEvict         PluginSet
 }
 
 type PluginSet struct {
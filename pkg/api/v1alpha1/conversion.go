@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/conversion"
@@ -72,6 +73,12 @@ func (ei *evictorImpl) NodeLimitExceeded(node *v1.Node) bool {
 	return ei.podEvictor.NodeLimitExceeded(node)
 }
 
+// Sort is a no-op since v1alpha1 policies do not configure any sort plugins
+func (ei *evictorImpl) Sort(pods []*v1.Pod) {}
+
+// SortReversed is a no-op since v1alpha1 policies do not configure any sort plugins
+func (ei *evictorImpl) SortReversed(pods []*v1.Pod) {}
+
 // handleImpl implements the framework handle which gets passed to plugins
 type handleImpl struct {
 	clientSet                 clientset.Interface
@@ -144,8 +151,14 @@ func V1alpha1ToInternal(
 
 	var profiles []api.DeschedulerProfile
 
-	// Build profiles
-	for name, strategy := range deschedulerPolicy.Strategies {
+	// Build profiles, ordered by the strategy names so the conversion is deterministic
+	names := make([]StrategyName, 0, len(deschedulerPolicy.Strategies))
+	for name := range deschedulerPolicy.Strategies {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	for _, name := range names {
+		strategy := deschedulerPolicy.Strategies[name]
 		if _, ok := pluginregistry.PluginRegistry[string(name)]; ok {
 			if strategy.Enabled {
 				params := strategy.Params
@@ -87,6 +87,7 @@ func convertToInternalPluginConfigArgs(out *api.DeschedulerPolicy) error {
 
 func Convert_v1alpha2_PluginConfig_To_api_PluginConfig(in *PluginConfig, out *api.PluginConfig, s conversion.Scope) error {
 	out.Name = in.Name
+	out.GracePeriodSeconds = in.GracePeriodSeconds
 	if _, ok := pluginregistry.PluginRegistry[in.Name]; ok {
 		out.Args = pluginregistry.PluginRegistry[in.Name].PluginArgInstance.DeepCopyObject()
 		if in.Args.Raw != nil {
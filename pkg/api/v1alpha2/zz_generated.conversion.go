@@ -24,6 +24,7 @@ package v1alpha2
 import (
 	unsafe "unsafe"
 
+	v1 "k8s.io/api/core/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	api "sigs.k8s.io/descheduler/pkg/api"
@@ -36,6 +37,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*DeleteFallback)(nil), (*api.DeleteFallback)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_DeleteFallback_To_api_DeleteFallback(a.(*DeleteFallback), b.(*api.DeleteFallback), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.DeleteFallback)(nil), (*DeleteFallback)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_DeleteFallback_To_v1alpha2_DeleteFallback(a.(*api.DeleteFallback), b.(*DeleteFallback), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*DeschedulerProfile)(nil), (*api.DeschedulerProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_DeschedulerProfile_To_api_DeschedulerProfile(a.(*DeschedulerProfile), b.(*api.DeschedulerProfile), scope)
 	}); err != nil {
@@ -46,6 +57,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*EvictionCooldown)(nil), (*api.EvictionCooldown)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_EvictionCooldown_To_api_EvictionCooldown(a.(*EvictionCooldown), b.(*api.EvictionCooldown), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.EvictionCooldown)(nil), (*EvictionCooldown)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_EvictionCooldown_To_v1alpha2_EvictionCooldown(a.(*api.EvictionCooldown), b.(*EvictionCooldown), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EvictionRateLimit)(nil), (*api.EvictionRateLimit)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_EvictionRateLimit_To_api_EvictionRateLimit(a.(*EvictionRateLimit), b.(*api.EvictionRateLimit), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.EvictionRateLimit)(nil), (*EvictionRateLimit)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_EvictionRateLimit_To_v1alpha2_EvictionRateLimit(a.(*api.EvictionRateLimit), b.(*EvictionRateLimit), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*api.PluginConfig)(nil), (*PluginConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_api_PluginConfig_To_v1alpha2_PluginConfig(a.(*api.PluginConfig), b.(*PluginConfig), scope)
 	}); err != nil {
@@ -71,6 +102,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*WorkloadEvictionBudget)(nil), (*api.WorkloadEvictionBudget)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_WorkloadEvictionBudget_To_api_WorkloadEvictionBudget(a.(*WorkloadEvictionBudget), b.(*api.WorkloadEvictionBudget), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.WorkloadEvictionBudget)(nil), (*WorkloadEvictionBudget)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_WorkloadEvictionBudget_To_v1alpha2_WorkloadEvictionBudget(a.(*api.WorkloadEvictionBudget), b.(*WorkloadEvictionBudget), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*api.DeschedulerPolicy)(nil), (*DeschedulerPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_api_DeschedulerPolicy_To_v1alpha2_DeschedulerPolicy(a.(*api.DeschedulerPolicy), b.(*DeschedulerPolicy), scope)
 	}); err != nil {
@@ -89,6 +130,28 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1alpha2_DeleteFallback_To_api_DeleteFallback(in *DeleteFallback, out *api.DeleteFallback, s conversion.Scope) error {
+	out.PodPhases = *(*[]v1.PodPhase)(unsafe.Pointer(&in.PodPhases))
+	out.RemoveFinalizers = in.RemoveFinalizers
+	return nil
+}
+
+// Convert_v1alpha2_DeleteFallback_To_api_DeleteFallback is an autogenerated conversion function.
+func Convert_v1alpha2_DeleteFallback_To_api_DeleteFallback(in *DeleteFallback, out *api.DeleteFallback, s conversion.Scope) error {
+	return autoConvert_v1alpha2_DeleteFallback_To_api_DeleteFallback(in, out, s)
+}
+
+func autoConvert_api_DeleteFallback_To_v1alpha2_DeleteFallback(in *api.DeleteFallback, out *DeleteFallback, s conversion.Scope) error {
+	out.PodPhases = *(*[]v1.PodPhase)(unsafe.Pointer(&in.PodPhases))
+	out.RemoveFinalizers = in.RemoveFinalizers
+	return nil
+}
+
+// Convert_api_DeleteFallback_To_v1alpha2_DeleteFallback is an autogenerated conversion function.
+func Convert_api_DeleteFallback_To_v1alpha2_DeleteFallback(in *api.DeleteFallback, out *DeleteFallback, s conversion.Scope) error {
+	return autoConvert_api_DeleteFallback_To_v1alpha2_DeleteFallback(in, out, s)
+}
+
 func autoConvert_v1alpha2_DeschedulerPolicy_To_api_DeschedulerPolicy(in *DeschedulerPolicy, out *api.DeschedulerPolicy, s conversion.Scope) error {
 	if in.Profiles != nil {
 		in, out := &in.Profiles, &out.Profiles
@@ -104,6 +167,13 @@ func autoConvert_v1alpha2_DeschedulerPolicy_To_api_DeschedulerPolicy(in *Desched
 	out.NodeSelector = (*string)(unsafe.Pointer(in.NodeSelector))
 	out.MaxNoOfPodsToEvictPerNode = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNode))
 	out.MaxNoOfPodsToEvictPerNamespace = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNamespace))
+	out.MaxNoOfPodsToEvictTotal = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictTotal))
+	out.EvictionRateLimit = (*api.EvictionRateLimit)(unsafe.Pointer(in.EvictionRateLimit))
+	out.GracePeriodSeconds = (*int64)(unsafe.Pointer(in.GracePeriodSeconds))
+	out.DeleteFallback = (*api.DeleteFallback)(unsafe.Pointer(in.DeleteFallback))
+	out.WorkloadEvictionBudget = (*api.WorkloadEvictionBudget)(unsafe.Pointer(in.WorkloadEvictionBudget))
+	out.EvictionCooldown = (*api.EvictionCooldown)(unsafe.Pointer(in.EvictionCooldown))
+	out.Parallelism = (*uint)(unsafe.Pointer(in.Parallelism))
 	return nil
 }
 
@@ -122,6 +192,13 @@ func autoConvert_api_DeschedulerPolicy_To_v1alpha2_DeschedulerPolicy(in *api.Des
 	out.NodeSelector = (*string)(unsafe.Pointer(in.NodeSelector))
 	out.MaxNoOfPodsToEvictPerNode = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNode))
 	out.MaxNoOfPodsToEvictPerNamespace = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNamespace))
+	out.MaxNoOfPodsToEvictTotal = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictTotal))
+	out.EvictionRateLimit = (*EvictionRateLimit)(unsafe.Pointer(in.EvictionRateLimit))
+	out.GracePeriodSeconds = (*int64)(unsafe.Pointer(in.GracePeriodSeconds))
+	out.DeleteFallback = (*DeleteFallback)(unsafe.Pointer(in.DeleteFallback))
+	out.WorkloadEvictionBudget = (*WorkloadEvictionBudget)(unsafe.Pointer(in.WorkloadEvictionBudget))
+	out.EvictionCooldown = (*EvictionCooldown)(unsafe.Pointer(in.EvictionCooldown))
+	out.Parallelism = (*uint)(unsafe.Pointer(in.Parallelism))
 	return nil
 }
 
@@ -173,11 +250,58 @@ func Convert_api_DeschedulerProfile_To_v1alpha2_DeschedulerProfile(in *api.Desch
 	return autoConvert_api_DeschedulerProfile_To_v1alpha2_DeschedulerProfile(in, out, s)
 }
 
+func autoConvert_v1alpha2_EvictionCooldown_To_api_EvictionCooldown(in *EvictionCooldown, out *api.EvictionCooldown, s conversion.Scope) error {
+	out.WindowSeconds = in.WindowSeconds
+	out.Scope = api.CooldownScope(in.Scope)
+	return nil
+}
+
+// Convert_v1alpha2_EvictionCooldown_To_api_EvictionCooldown is an autogenerated conversion function.
+func Convert_v1alpha2_EvictionCooldown_To_api_EvictionCooldown(in *EvictionCooldown, out *api.EvictionCooldown, s conversion.Scope) error {
+	return autoConvert_v1alpha2_EvictionCooldown_To_api_EvictionCooldown(in, out, s)
+}
+
+func autoConvert_api_EvictionCooldown_To_v1alpha2_EvictionCooldown(in *api.EvictionCooldown, out *EvictionCooldown, s conversion.Scope) error {
+	out.WindowSeconds = in.WindowSeconds
+	out.Scope = CooldownScope(in.Scope)
+	return nil
+}
+
+// Convert_api_EvictionCooldown_To_v1alpha2_EvictionCooldown is an autogenerated conversion function.
+func Convert_api_EvictionCooldown_To_v1alpha2_EvictionCooldown(in *api.EvictionCooldown, out *EvictionCooldown, s conversion.Scope) error {
+	return autoConvert_api_EvictionCooldown_To_v1alpha2_EvictionCooldown(in, out, s)
+}
+
+func autoConvert_v1alpha2_EvictionRateLimit_To_api_EvictionRateLimit(in *EvictionRateLimit, out *api.EvictionRateLimit, s conversion.Scope) error {
+	out.Evictions = in.Evictions
+	out.Period = api.RatePeriod(in.Period)
+	out.Burst = (*uint)(unsafe.Pointer(in.Burst))
+	return nil
+}
+
+// Convert_v1alpha2_EvictionRateLimit_To_api_EvictionRateLimit is an autogenerated conversion function.
+func Convert_v1alpha2_EvictionRateLimit_To_api_EvictionRateLimit(in *EvictionRateLimit, out *api.EvictionRateLimit, s conversion.Scope) error {
+	return autoConvert_v1alpha2_EvictionRateLimit_To_api_EvictionRateLimit(in, out, s)
+}
+
+func autoConvert_api_EvictionRateLimit_To_v1alpha2_EvictionRateLimit(in *api.EvictionRateLimit, out *EvictionRateLimit, s conversion.Scope) error {
+	out.Evictions = in.Evictions
+	out.Period = RatePeriod(in.Period)
+	out.Burst = (*uint)(unsafe.Pointer(in.Burst))
+	return nil
+}
+
+// Convert_api_EvictionRateLimit_To_v1alpha2_EvictionRateLimit is an autogenerated conversion function.
+func Convert_api_EvictionRateLimit_To_v1alpha2_EvictionRateLimit(in *api.EvictionRateLimit, out *EvictionRateLimit, s conversion.Scope) error {
+	return autoConvert_api_EvictionRateLimit_To_v1alpha2_EvictionRateLimit(in, out, s)
+}
+
 func autoConvert_v1alpha2_PluginConfig_To_api_PluginConfig(in *PluginConfig, out *api.PluginConfig, s conversion.Scope) error {
 	out.Name = in.Name
 	if err := runtime.Convert_runtime_RawExtension_To_runtime_Object(&in.Args, &out.Args, s); err != nil {
 		return err
 	}
+	out.GracePeriodSeconds = (*int64)(unsafe.Pointer(in.GracePeriodSeconds))
 	return nil
 }
 
@@ -186,6 +310,7 @@ func autoConvert_api_PluginConfig_To_v1alpha2_PluginConfig(in *api.PluginConfig,
 	if err := runtime.Convert_runtime_Object_To_runtime_RawExtension(&in.Args, &out.Args, s); err != nil {
 		return err
 	}
+	out.GracePeriodSeconds = (*int64)(unsafe.Pointer(in.GracePeriodSeconds))
 	return nil
 }
 
@@ -235,6 +360,12 @@ func autoConvert_v1alpha2_Plugins_To_api_Plugins(in *Plugins, out *api.Plugins,
 	if err := Convert_v1alpha2_PluginSet_To_api_PluginSet(&in.PreEvictionFilter, &out.PreEvictionFilter, s); err != nil {
 		return err
 	}
+	if err := Convert_v1alpha2_PluginSet_To_api_PluginSet(&in.PreEvict, &out.PreEvict, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha2_PluginSet_To_api_PluginSet(&in.PostEvict, &out.PostEvict, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -262,6 +393,12 @@ func autoConvert_api_Plugins_To_v1alpha2_Plugins(in *api.Plugins, out *Plugins,
 	if err := Convert_api_PluginSet_To_v1alpha2_PluginSet(&in.PreEvictionFilter, &out.PreEvictionFilter, s); err != nil {
 		return err
 	}
+	if err := Convert_api_PluginSet_To_v1alpha2_PluginSet(&in.PreEvict, &out.PreEvict, s); err != nil {
+		return err
+	}
+	if err := Convert_api_PluginSet_To_v1alpha2_PluginSet(&in.PostEvict, &out.PostEvict, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -269,3 +406,25 @@ func autoConvert_api_Plugins_To_v1alpha2_Plugins(in *api.Plugins, out *Plugins,
 func Convert_api_Plugins_To_v1alpha2_Plugins(in *api.Plugins, out *Plugins, s conversion.Scope) error {
 	return autoConvert_api_Plugins_To_v1alpha2_Plugins(in, out, s)
 }
+
+func autoConvert_v1alpha2_WorkloadEvictionBudget_To_api_WorkloadEvictionBudget(in *WorkloadEvictionBudget, out *api.WorkloadEvictionBudget, s conversion.Scope) error {
+	out.MaxEvictions = in.MaxEvictions
+	out.WindowSeconds = in.WindowSeconds
+	return nil
+}
+
+// Convert_v1alpha2_WorkloadEvictionBudget_To_api_WorkloadEvictionBudget is an autogenerated conversion function.
+func Convert_v1alpha2_WorkloadEvictionBudget_To_api_WorkloadEvictionBudget(in *WorkloadEvictionBudget, out *api.WorkloadEvictionBudget, s conversion.Scope) error {
+	return autoConvert_v1alpha2_WorkloadEvictionBudget_To_api_WorkloadEvictionBudget(in, out, s)
+}
+
+func autoConvert_api_WorkloadEvictionBudget_To_v1alpha2_WorkloadEvictionBudget(in *api.WorkloadEvictionBudget, out *WorkloadEvictionBudget, s conversion.Scope) error {
+	out.MaxEvictions = in.MaxEvictions
+	out.WindowSeconds = in.WindowSeconds
+	return nil
+}
+
+// Convert_api_WorkloadEvictionBudget_To_v1alpha2_WorkloadEvictionBudget is an autogenerated conversion function.
+func Convert_api_WorkloadEvictionBudget_To_v1alpha2_WorkloadEvictionBudget(in *api.WorkloadEvictionBudget, out *WorkloadEvictionBudget, s conversion.Scope) error {
+	return autoConvert_api_WorkloadEvictionBudget_To_v1alpha2_WorkloadEvictionBudget(in, out, s)
+}
@@ -22,9 +22,31 @@ limitations under the License.
 package api
 
 import (
+	v1 "k8s.io/api/core/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeleteFallback) DeepCopyInto(out *DeleteFallback) {
+	*out = *in
+	if in.PodPhases != nil {
+		in, out := &in.PodPhases, &out.PodPhases
+		*out = make([]v1.PodPhase, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeleteFallback.
+func (in *DeleteFallback) DeepCopy() *DeleteFallback {
+	if in == nil {
+		return nil
+	}
+	out := new(DeleteFallback)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeschedulerPolicy) DeepCopyInto(out *DeschedulerPolicy) {
 	*out = *in
@@ -51,6 +73,41 @@ func (in *DeschedulerPolicy) DeepCopyInto(out *DeschedulerPolicy) {
 		*out = new(uint)
 		**out = **in
 	}
+	if in.MaxNoOfPodsToEvictTotal != nil {
+		in, out := &in.MaxNoOfPodsToEvictTotal, &out.MaxNoOfPodsToEvictTotal
+		*out = new(uint)
+		**out = **in
+	}
+	if in.EvictionRateLimit != nil {
+		in, out := &in.EvictionRateLimit, &out.EvictionRateLimit
+		*out = new(EvictionRateLimit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GracePeriodSeconds != nil {
+		in, out := &in.GracePeriodSeconds, &out.GracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DeleteFallback != nil {
+		in, out := &in.DeleteFallback, &out.DeleteFallback
+		*out = new(DeleteFallback)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WorkloadEvictionBudget != nil {
+		in, out := &in.WorkloadEvictionBudget, &out.WorkloadEvictionBudget
+		*out = new(WorkloadEvictionBudget)
+		**out = **in
+	}
+	if in.EvictionCooldown != nil {
+		in, out := &in.EvictionCooldown, &out.EvictionCooldown
+		*out = new(EvictionCooldown)
+		**out = **in
+	}
+	if in.Parallelism != nil {
+		in, out := &in.Parallelism, &out.Parallelism
+		*out = new(uint)
+		**out = **in
+	}
 	return
 }
 
@@ -96,6 +153,43 @@ func (in *DeschedulerProfile) DeepCopy() *DeschedulerProfile {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvictionCooldown) DeepCopyInto(out *EvictionCooldown) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvictionCooldown.
+func (in *EvictionCooldown) DeepCopy() *EvictionCooldown {
+	if in == nil {
+		return nil
+	}
+	out := new(EvictionCooldown)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvictionRateLimit) DeepCopyInto(out *EvictionRateLimit) {
+	*out = *in
+	if in.Burst != nil {
+		in, out := &in.Burst, &out.Burst
+		*out = new(uint)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvictionRateLimit.
+func (in *EvictionRateLimit) DeepCopy() *EvictionRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(EvictionRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Namespaces) DeepCopyInto(out *Namespaces) {
 	*out = *in
@@ -128,6 +222,11 @@ func (in *PluginConfig) DeepCopyInto(out *PluginConfig) {
 	if in.Args != nil {
 		out.Args = in.Args.DeepCopyObject()
 	}
+	if in.GracePeriodSeconds != nil {
+		in, out := &in.GracePeriodSeconds, &out.GracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
 	return
 }
 
@@ -176,6 +275,8 @@ func (in *Plugins) DeepCopyInto(out *Plugins) {
 	in.Balance.DeepCopyInto(&out.Balance)
 	in.Filter.DeepCopyInto(&out.Filter)
 	in.PreEvictionFilter.DeepCopyInto(&out.PreEvictionFilter)
+	in.PreEvict.DeepCopyInto(&out.PreEvict)
+	in.PostEvict.DeepCopyInto(&out.PostEvict)
 	return
 }
 
@@ -231,3 +332,20 @@ func (in ResourceThresholds) DeepCopy() ResourceThresholds {
 	in.DeepCopyInto(out)
 	return *out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadEvictionBudget) DeepCopyInto(out *WorkloadEvictionBudget) {
+	*out = *in
+	out.MaxEvictions = in.MaxEvictions
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadEvictionBudget.
+func (in *WorkloadEvictionBudget) DeepCopy() *WorkloadEvictionBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadEvictionBudget)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/descheduler/pkg/api/v1alpha2"
+)
+
+const (
+	// PolicyValidCondition reports whether the policy could be decoded and validated.
+	// Its message holds the validation error when the policy is invalid.
+	PolicyValidCondition = "Valid"
+
+	// PolicyValidReason is the reason of the Valid condition of a valid policy
+	PolicyValidReason = "PolicyValid"
+	// PolicyInvalidReason is the reason of the Valid condition of an invalid policy
+	PolicyInvalidReason = "PolicyInvalid"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DeschedulerPolicy is a cluster-scoped descheduler policy.
+// Its spec has the same schema as the descheduler/v1alpha2 DeschedulerPolicy.
+type DeschedulerPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DeschedulerPolicySpec   `json:"spec,omitempty"`
+	Status DeschedulerPolicyStatus `json:"status,omitempty"`
+}
+
+type DeschedulerPolicySpec struct {
+	// Profiles
+	Profiles []v1alpha2.DeschedulerProfile `json:"profiles,omitempty"`
+
+	// NodeSelector for a set of nodes to operate over
+	NodeSelector *string `json:"nodeSelector,omitempty"`
+
+	// MaxNoOfPodsToEvictPerNode restricts maximum of pods to be evicted per node.
+	MaxNoOfPodsToEvictPerNode *uint `json:"maxNoOfPodsToEvictPerNode,omitempty"`
+
+	// MaxNoOfPodsToEvictPerNamespace restricts maximum of pods to be evicted per namespace.
+	MaxNoOfPodsToEvictPerNamespace *uint `json:"maxNoOfPodsToEvictPerNamespace,omitempty"`
+
+	// MaxNoOfPodsToEvictTotal restricts maximum of pods to be evicted per descheduling loop.
+	MaxNoOfPodsToEvictTotal *uint `json:"maxNoOfPodsToEvictTotal,omitempty"`
+
+	// EvictionRateLimit paces the evictions.
+	EvictionRateLimit *v1alpha2.EvictionRateLimit `json:"evictionRateLimit,omitempty"`
+
+	// GracePeriodSeconds overrides the termination grace period of the evicted pods.
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
+
+	// DeleteFallback deletes the pods directly when their eviction is disallowed.
+	DeleteFallback *v1alpha2.DeleteFallback `json:"deleteFallback,omitempty"`
+
+	// WorkloadEvictionBudget caps the evictions of the pods of every workload across the descheduling loops.
+	WorkloadEvictionBudget *v1alpha2.WorkloadEvictionBudget `json:"workloadEvictionBudget,omitempty"`
+
+	// EvictionCooldown keeps the pods of an owner from being evicted again within a window since one of them got evicted.
+	EvictionCooldown *v1alpha2.EvictionCooldown `json:"evictionCooldown,omitempty"`
+
+	// Parallelism is the maximum number of profiles running their Deschedule extension point
+	// concurrently, and of nodes processed concurrently by plugins supporting it.
+	Parallelism *uint `json:"parallelism,omitempty"`
+}
+
+// DeschedulerPolicyStatus is the status of the policy as observed by the descheduler
+type DeschedulerPolicyStatus struct {
+	// ObservedGeneration is the most recent generation of the policy read by the descheduler
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastLoopTime is the time the last descheduling loop finished
+	LastLoopTime *metav1.Time `json:"lastLoopTime,omitempty"`
+
+	// Profiles holds the number of pods evicted by each profile in the last descheduling loop
+	Profiles []ProfileStatus `json:"profiles,omitempty"`
+
+	// Conditions of the policy, e.g. whether it is valid
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ProfileStatus holds the number of pods evicted by a profile and its plugins
+type ProfileStatus struct {
+	Name        string         `json:"name"`
+	PodsEvicted int64          `json:"podsEvicted"`
+	Plugins     []PluginStatus `json:"plugins,omitempty"`
+}
+
+// PluginStatus holds the number of pods evicted by a plugin
+type PluginStatus struct {
+	Name        string `json:"name"`
+	PodsEvicted int64  `json:"podsEvicted"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DeschedulerPolicyList is a list of DeschedulerPolicy objects
+type DeschedulerPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []DeschedulerPolicy `json:"items"`
+}
@@ -19,6 +19,7 @@ package client
 import (
 	"fmt"
 
+	"k8s.io/client-go/dynamic"
 	clientset "k8s.io/client-go/kubernetes"
 	componentbaseconfig "k8s.io/component-base/config"
 
@@ -29,6 +30,25 @@ import (
 )
 
 func CreateClient(clientConnection componentbaseconfig.ClientConnectionConfiguration, userAgt string) (clientset.Interface, error) {
+	cfg, err := createConfig(clientConnection, userAgt)
+	if err != nil {
+		return nil, err
+	}
+
+	return clientset.NewForConfig(cfg)
+}
+
+// CreateDynamicClient creates a dynamic client, e.g. for accessing the DeschedulerPolicy custom resources
+func CreateDynamicClient(clientConnection componentbaseconfig.ClientConnectionConfiguration, userAgt string) (dynamic.Interface, error) {
+	cfg, err := createConfig(clientConnection, userAgt)
+	if err != nil {
+		return nil, err
+	}
+
+	return dynamic.NewForConfig(cfg)
+}
+
+func createConfig(clientConnection componentbaseconfig.ClientConnectionConfiguration, userAgt string) (*rest.Config, error) {
 	var cfg *rest.Config
 	if len(clientConnection.Kubeconfig) != 0 {
 		master, err := GetMasterFromKubeconfig(clientConnection.Kubeconfig)
@@ -56,7 +76,7 @@ func CreateClient(clientConnection componentbaseconfig.ClientConnectionConfigura
 		cfg = rest.AddUserAgent(cfg, userAgt)
 	}
 
-	return clientset.NewForConfig(cfg)
+	return cfg, nil
 }
 
 func GetMasterFromKubeconfig(filename string) (string, error) {
@@ -0,0 +1,247 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+)
+
+// PolicyComparison compares the outcome of a descheduling loop of two policies, A and B,
+// run against the same cluster state
+type PolicyComparison struct {
+	// Evictions lists the pods either policy evicts, sorted by namespace and name
+	Evictions []EvictionComparison
+	// NodeUtilization lists the utilization of every node, sorted by name
+	NodeUtilization []NodeUtilizationComparison
+	// TopologySkew lists the skew of every topology spread constraint of the pods
+	TopologySkew []TopologySkewComparison
+}
+
+// EvictionComparison tells which policies evict a pod, A or B being nil when the policy does not evict it
+type EvictionComparison struct {
+	Pod  types.NamespacedName
+	Node string
+	A, B *evictions.EvictOptions
+}
+
+// NodeUtilizationComparison compares the utilization of a node after the loop of either policy,
+// in percents of its allocatable resources
+type NodeUtilizationComparison struct {
+	Node   string
+	Before map[v1.ResourceName]float64
+	A, B   map[v1.ResourceName]float64
+}
+
+// TopologySkewComparison compares the skew of a topology spread constraint after the loop of either policy,
+// i.e. the difference between the most and the least pods matching it in any domain of the topology
+type TopologySkewComparison struct {
+	Namespace         string
+	TopologyKey       string
+	Selector          string
+	WhenUnsatisfiable v1.UnsatisfiableConstraintAction
+	MaxSkew           int32
+	Before            int
+	A, B              int
+}
+
+// ComparePolicies runs a single descheduling loop of both policies in the dry run mode against
+// copies of the same objects, e.g. those of a snapshot, and compares their outcome.
+// The cluster state after a loop is the one before it without the evicted pods.
+func ComparePolicies(ctx context.Context, objects []runtime.Object, policyA, policyB *api.DeschedulerPolicy) (*PolicyComparison, error) {
+	evictedA, err := Simulate(ctx, fakeclientset.NewSimpleClientset(objects...), policyA)
+	if err != nil {
+		return nil, err
+	}
+	evictedB, err := Simulate(ctx, fakeclientset.NewSimpleClientset(objects...), policyB)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []*v1.Node
+	var pods []*v1.Pod
+	for _, obj := range objects {
+		switch o := obj.(type) {
+		case *v1.Node:
+			nodes = append(nodes, o)
+		case *v1.Pod:
+			pods = append(pods, o)
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	podsBefore := nodePods(nodes, func(nodeName string, filter podutil.FilterFunc) ([]*v1.Pod, error) {
+		var nodePods []*v1.Pod
+		for _, pod := range pods {
+			if pod.Spec.NodeName == nodeName && (filter == nil || filter(pod)) {
+				nodePods = append(nodePods, pod)
+			}
+		}
+		return nodePods, nil
+	})
+
+	return &PolicyComparison{
+		Evictions:       compareEvictions(evictedA, evictedB),
+		NodeUtilization: compareNodeUtilization(nodes, podsBefore, evictedA, evictedB),
+		TopologySkew:    compareTopologySkew(nodes, podsBefore, evictedA, evictedB),
+	}, nil
+}
+
+// compareEvictions matches the pods evicted by either policy by their namespace and name
+func compareEvictions(evictedA, evictedB []evictions.Eviction) []EvictionComparison {
+	byPod := map[types.NamespacedName]*EvictionComparison{}
+	comparison := func(eviction evictions.Eviction) *EvictionComparison {
+		key := types.NamespacedName{Namespace: eviction.Pod.Namespace, Name: eviction.Pod.Name}
+		if _, ok := byPod[key]; !ok {
+			byPod[key] = &EvictionComparison{Pod: key, Node: eviction.Pod.Spec.NodeName}
+		}
+		return byPod[key]
+	}
+	for i := range evictedA {
+		comparison(evictedA[i]).A = &evictedA[i].Opts
+	}
+	for i := range evictedB {
+		comparison(evictedB[i]).B = &evictedB[i].Opts
+	}
+
+	compared := make([]EvictionComparison, 0, len(byPod))
+	for _, c := range byPod {
+		compared = append(compared, *c)
+	}
+	sort.Slice(compared, func(i, j int) bool {
+		if compared[i].Pod.Namespace != compared[j].Pod.Namespace {
+			return compared[i].Pod.Namespace < compared[j].Pod.Namespace
+		}
+		return compared[i].Pod.Name < compared[j].Pod.Name
+	})
+	return compared
+}
+
+// compareNodeUtilization reports the utilization of every node before the loops and after the loop of either policy
+func compareNodeUtilization(nodes []*v1.Node, podsBefore map[string][]*v1.Pod, evictedA, evictedB []evictions.Eviction) []NodeUtilizationComparison {
+	utilizationA := nodeUtilizationReport(nodes, podsBefore, evictedA)
+	utilizationB := nodeUtilizationReport(nodes, podsBefore, evictedB)
+
+	compared := make([]NodeUtilizationComparison, 0, len(nodes))
+	for i, node := range nodes {
+		compared = append(compared, NodeUtilizationComparison{
+			Node:   node.Name,
+			Before: utilizationA[i].Before,
+			A:      utilizationA[i].After,
+			B:      utilizationB[i].After,
+		})
+	}
+	return compared
+}
+
+// compareTopologySkew reports the skew of every distinct topology spread constraint of the pods
+// before the loops and after the loop of either policy
+func compareTopologySkew(nodes []*v1.Node, podsBefore map[string][]*v1.Pod, evictedA, evictedB []evictions.Eviction) []TopologySkewComparison {
+	afterA := podsAfter(podsBefore, evictedA)
+	afterB := podsAfter(podsBefore, evictedB)
+
+	type constraintKey struct {
+		namespace, topologyKey, selector string
+		whenUnsatisfiable                v1.UnsatisfiableConstraintAction
+	}
+	seen := map[constraintKey]bool{}
+	var compared []TopologySkewComparison
+	for _, node := range nodes {
+		for _, pod := range podsBefore[node.Name] {
+			for _, constraint := range pod.Spec.TopologySpreadConstraints {
+				selector, err := metav1.LabelSelectorAsSelector(constraint.LabelSelector)
+				if err != nil {
+					continue
+				}
+				key := constraintKey{pod.Namespace, constraint.TopologyKey, selector.String(), constraint.WhenUnsatisfiable}
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				compared = append(compared, TopologySkewComparison{
+					Namespace:         pod.Namespace,
+					TopologyKey:       constraint.TopologyKey,
+					Selector:          selector.String(),
+					WhenUnsatisfiable: constraint.WhenUnsatisfiable,
+					MaxSkew:           constraint.MaxSkew,
+					Before:            topologySkew(nodes, podsBefore, pod.Namespace, constraint.TopologyKey, selector),
+					A:                 topologySkew(nodes, afterA, pod.Namespace, constraint.TopologyKey, selector),
+					B:                 topologySkew(nodes, afterB, pod.Namespace, constraint.TopologyKey, selector),
+				})
+			}
+		}
+	}
+	sort.Slice(compared, func(i, j int) bool {
+		a, b := compared[i], compared[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.TopologyKey != b.TopologyKey {
+			return a.TopologyKey < b.TopologyKey
+		}
+		if a.Selector != b.Selector {
+			return a.Selector < b.Selector
+		}
+		return a.WhenUnsatisfiable < b.WhenUnsatisfiable
+	})
+	return compared
+}
+
+// topologySkew counts the pods of the namespace matching the selector in every domain of the topology,
+// i.e. every value of the topology key among the nodes, and returns the difference between the most and the least
+func topologySkew(nodes []*v1.Node, nodePods map[string][]*v1.Pod, namespace, topologyKey string, selector labels.Selector) int {
+	domains := map[string]int{}
+	for _, node := range nodes {
+		domain, ok := node.Labels[topologyKey]
+		if !ok {
+			continue
+		}
+		if _, ok := domains[domain]; !ok {
+			domains[domain] = 0
+		}
+		for _, pod := range nodePods[node.Name] {
+			if pod.Namespace == namespace && selector.Matches(labels.Set(pod.Labels)) {
+				domains[domain]++
+			}
+		}
+	}
+	if len(domains) == 0 {
+		return 0
+	}
+	first := true
+	var min, max int
+	for _, count := range domains {
+		if first || count < min {
+			min = count
+		}
+		if first || count > max {
+			max = count
+		}
+		first = false
+	}
+	return max - min
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removefailedpods"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatingnodetaints"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestComparePolicies(t *testing.T) {
+	SetupPlugins()
+
+	zone := func(name string) func(*v1.Node) {
+		return func(node *v1.Node) {
+			node.Labels = map[string]string{"zone": name}
+		}
+	}
+	tainted := test.BuildTestNode("n1", 1000, 2000, 10, func(node *v1.Node) {
+		zone("a")(node)
+		node.Spec.Taints = []v1.Taint{{Key: "dedicated", Value: "infra", Effect: v1.TaintEffectNoSchedule}}
+	})
+	untainted := test.BuildTestNode("n2", 1000, 2000, 10, zone("b"))
+	spread := func(pod *v1.Pod) {
+		test.SetRSOwnerRef(pod)
+		pod.Labels = map[string]string{"app": "web"}
+		pod.Spec.TopologySpreadConstraints = []v1.TopologySpreadConstraint{{
+			MaxSkew:           1,
+			TopologyKey:       "zone",
+			WhenUnsatisfiable: v1.ScheduleAnyway,
+			LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+		}}
+	}
+	objects := []runtime.Object{tainted, untainted, &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}}
+	for i := 0; i < 3; i++ {
+		objects = append(objects, test.BuildTestPod(fmt.Sprintf("p%d", i), 100, 0, tainted.Name, spread))
+	}
+	objects = append(objects, test.BuildTestPod("p3", 100, 0, untainted.Name, spread))
+
+	loadPolicy := func(pluginName string) *api.DeschedulerPolicy {
+		policyFile := filepath.Join(t.TempDir(), "policy.yaml")
+		if err := os.WriteFile(policyFile, []byte(reloaderTestPolicyFor(pluginName)), 0o600); err != nil {
+			t.Fatalf("Unable to write the policy: %v", err)
+		}
+		policy, err := LoadPolicyConfig(policyFile, fakeclientset.NewSimpleClientset(), pluginregistry.PluginRegistry)
+		if err != nil {
+			t.Fatalf("Unable to load the policy: %v", err)
+		}
+		return policy
+	}
+
+	comparison, err := ComparePolicies(context.Background(), objects, loadPolicy(removepodsviolatingnodetaints.PluginName), loadPolicy(removefailedpods.PluginName))
+	if err != nil {
+		t.Fatalf("Unable to compare the policies: %v", err)
+	}
+
+	var evicted []types.NamespacedName
+	for _, eviction := range comparison.Evictions {
+		if eviction.A == nil || eviction.A.PluginName != removepodsviolatingnodetaints.PluginName || eviction.B != nil {
+			t.Errorf("Expected pod %v to be evicted by policy A only, got %+v", eviction.Pod, eviction)
+		}
+		evicted = append(evicted, eviction.Pod)
+	}
+	expectedEvicted := []types.NamespacedName{{Namespace: "default", Name: "p0"}, {Namespace: "default", Name: "p1"}, {Namespace: "default", Name: "p2"}}
+	if diff := cmp.Diff(expectedEvicted, evicted); diff != "" {
+		t.Errorf("Unexpected evicted pods (-want +got):\n%s", diff)
+	}
+
+	expectedUtilization := []NodeUtilizationComparison{
+		{
+			Node:   "n1",
+			Before: map[v1.ResourceName]float64{v1.ResourceCPU: 30, v1.ResourceMemory: 0, v1.ResourcePods: 30},
+			A:      map[v1.ResourceName]float64{v1.ResourceCPU: 0, v1.ResourceMemory: 0, v1.ResourcePods: 0},
+			B:      map[v1.ResourceName]float64{v1.ResourceCPU: 30, v1.ResourceMemory: 0, v1.ResourcePods: 30},
+		},
+		{
+			Node:   "n2",
+			Before: map[v1.ResourceName]float64{v1.ResourceCPU: 10, v1.ResourceMemory: 0, v1.ResourcePods: 10},
+			A:      map[v1.ResourceName]float64{v1.ResourceCPU: 10, v1.ResourceMemory: 0, v1.ResourcePods: 10},
+			B:      map[v1.ResourceName]float64{v1.ResourceCPU: 10, v1.ResourceMemory: 0, v1.ResourcePods: 10},
+		},
+	}
+	if diff := cmp.Diff(expectedUtilization, comparison.NodeUtilization); diff != "" {
+		t.Errorf("Unexpected node utilization (-want +got):\n%s", diff)
+	}
+
+	expectedSkew := []TopologySkewComparison{{
+		Namespace:         "default",
+		TopologyKey:       "zone",
+		Selector:          "app=web",
+		WhenUnsatisfiable: v1.ScheduleAnyway,
+		MaxSkew:           1,
+		Before:            2,
+		A:                 1,
+		B:                 2,
+	}}
+	if diff := cmp.Diff(expectedSkew, comparison.TopologySkew); diff != "" {
+		t.Errorf("Unexpected topology skew (-want +got):\n%s", diff)
+	}
+}
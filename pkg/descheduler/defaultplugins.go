@@ -0,0 +1,91 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+)
+
+// allPluginsWildcard disables all default plugins of an extension point when listed in PluginSet.Disabled
+const allPluginsWildcard = "*"
+
+// getDefaultPlugins returns the default set of plugins enabled in every profile
+func getDefaultPlugins() *api.Plugins {
+	return &api.Plugins{
+		Filter: api.PluginSet{
+			Enabled: []string{defaultevictor.PluginName},
+		},
+		PreEvictionFilter: api.PluginSet{
+			Enabled: []string{defaultevictor.PluginName},
+		},
+	}
+}
+
+// mergePlugins merges the custom plugins of a profile into the default ones.
+// For every extension point the enabled plugins are computed as (DefaultEnabled + Enabled - Disabled).
+func mergePlugins(defaultPlugins, customPlugins *api.Plugins) *api.Plugins {
+	if customPlugins == nil {
+		return defaultPlugins
+	}
+
+	defaultPlugins.PreSort = mergePluginSet(defaultPlugins.PreSort, customPlugins.PreSort)
+	defaultPlugins.Sort = mergePluginSet(defaultPlugins.Sort, customPlugins.Sort)
+	defaultPlugins.Deschedule = mergePluginSet(defaultPlugins.Deschedule, customPlugins.Deschedule)
+	defaultPlugins.Balance = mergePluginSet(defaultPlugins.Balance, customPlugins.Balance)
+	defaultPlugins.Filter = mergePluginSet(defaultPlugins.Filter, customPlugins.Filter)
+	defaultPlugins.PreEvictionFilter = mergePluginSet(defaultPlugins.PreEvictionFilter, customPlugins.PreEvictionFilter)
+	defaultPlugins.PreEvict = mergePluginSet(defaultPlugins.PreEvict, customPlugins.PreEvict)
+	defaultPlugins.PostEvict = mergePluginSet(defaultPlugins.PostEvict, customPlugins.PostEvict)
+	return defaultPlugins
+}
+
+// mergePluginSet keeps the default plugins which are not disabled in their default order,
+// followed by the custom enabled plugins. A default plugin listed among the custom enabled
+// plugins keeps its default position. Disabling "*" disables all the default plugins.
+func mergePluginSet(defaultPluginSet, customPluginSet api.PluginSet) api.PluginSet {
+	disabledPlugins := sets.New(customPluginSet.Disabled...)
+	customEnabledPlugins := sets.New(customPluginSet.Enabled...)
+
+	var enabledPlugins []string
+	if !disabledPlugins.Has(allPluginsWildcard) {
+		for _, defaultEnabledPlugin := range defaultPluginSet.Enabled {
+			if disabledPlugins.Has(defaultEnabledPlugin) {
+				continue
+			}
+			if customEnabledPlugins.Has(defaultEnabledPlugin) {
+				klog.V(4).InfoS("Default plugin is explicitly enabled, keeping its default position", "plugin", defaultEnabledPlugin)
+			}
+			enabledPlugins = append(enabledPlugins, defaultEnabledPlugin)
+		}
+	}
+
+	defaultEnabledPlugins := sets.New(enabledPlugins...)
+	for _, customEnabledPlugin := range customPluginSet.Enabled {
+		if !defaultEnabledPlugins.Has(customEnabledPlugin) {
+			enabledPlugins = append(enabledPlugins, customEnabledPlugin)
+		}
+	}
+
+	return api.PluginSet{
+		Enabled:  enabledPlugins,
+		Disabled: customPluginSet.Disabled,
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+)
+
+func TestMergePlugins(t *testing.T) {
+	tests := []struct {
+		name          string
+		customPlugins *api.Plugins
+		expected      *api.Plugins
+	}{
+		{
+			name:          "nil custom plugins",
+			customPlugins: nil,
+			expected:      getDefaultPlugins(),
+		},
+		{
+			name: "custom plugins are appended after the default ones",
+			customPlugins: &api.Plugins{
+				Filter:     api.PluginSet{Enabled: []string{"CustomEvictor"}},
+				Deschedule: api.PluginSet{Enabled: []string{"CustomDeschedule"}},
+			},
+			expected: &api.Plugins{
+				Filter:            api.PluginSet{Enabled: []string{defaultevictor.PluginName, "CustomEvictor"}},
+				PreEvictionFilter: api.PluginSet{Enabled: []string{defaultevictor.PluginName}},
+				Deschedule:        api.PluginSet{Enabled: []string{"CustomDeschedule"}},
+			},
+		},
+		{
+			name: "explicitly enabled default plugin keeps its default position",
+			customPlugins: &api.Plugins{
+				Filter: api.PluginSet{Enabled: []string{"CustomEvictor", defaultevictor.PluginName}},
+			},
+			expected: &api.Plugins{
+				Filter:            api.PluginSet{Enabled: []string{defaultevictor.PluginName, "CustomEvictor"}},
+				PreEvictionFilter: api.PluginSet{Enabled: []string{defaultevictor.PluginName}},
+			},
+		},
+		{
+			name: "default plugin replaced by a custom one",
+			customPlugins: &api.Plugins{
+				Filter:            api.PluginSet{Enabled: []string{"CustomEvictor"}, Disabled: []string{defaultevictor.PluginName}},
+				PreEvictionFilter: api.PluginSet{Enabled: []string{"CustomEvictor"}, Disabled: []string{defaultevictor.PluginName}},
+			},
+			expected: &api.Plugins{
+				Filter:            api.PluginSet{Enabled: []string{"CustomEvictor"}, Disabled: []string{defaultevictor.PluginName}},
+				PreEvictionFilter: api.PluginSet{Enabled: []string{"CustomEvictor"}, Disabled: []string{defaultevictor.PluginName}},
+			},
+		},
+		{
+			name: "all default plugins disabled, default plugin re-enabled after a custom one",
+			customPlugins: &api.Plugins{
+				Filter:            api.PluginSet{Enabled: []string{"CustomEvictor", defaultevictor.PluginName}, Disabled: []string{"*"}},
+				PreEvictionFilter: api.PluginSet{Disabled: []string{"*"}},
+			},
+			expected: &api.Plugins{
+				Filter:            api.PluginSet{Enabled: []string{"CustomEvictor", defaultevictor.PluginName}, Disabled: []string{"*"}},
+				PreEvictionFilter: api.PluginSet{Disabled: []string{"*"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := mergePlugins(getDefaultPlugins(), test.customPlugins)
+			if diff := cmp.Diff(test.expected, result); diff != "" {
+				t.Errorf("unexpected plugins (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
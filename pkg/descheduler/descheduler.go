@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
@@ -38,12 +39,15 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/wait"
 	clientset "k8s.io/client-go/kubernetes"
 	fakeclientset "k8s.io/client-go/kubernetes/fake"
 	listersv1 "k8s.io/client-go/listers/core/v1"
+	policylistersv1 "k8s.io/client-go/listers/policy/v1"
 	schedulingv1 "k8s.io/client-go/listers/scheduling/v1"
 	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/util/flowcontrol"
 
 	"sigs.k8s.io/descheduler/pkg/descheduler/client"
 	eutils "sigs.k8s.io/descheduler/pkg/descheduler/evictions/utils"
@@ -56,12 +60,19 @@ import (
 	"sigs.k8s.io/descheduler/metrics"
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/descheduler/plan"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/descheduler/report"
+	"sigs.k8s.io/descheduler/pkg/framework/parallelize"
 	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
 	frameworkprofile "sigs.k8s.io/descheduler/pkg/framework/profile"
 	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
 )
 
+// errLoopFailed marks the errors failing a single descheduling loop, e.g. on a temporary apiserver error.
+// The descheduler keeps running and the next loop is run on the next interval.
+var errLoopFailed = errors.New("descheduling loop failed")
+
 type eprunner func(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status
 
 type profileRunner struct {
@@ -75,11 +86,30 @@ type descheduler struct {
 	nodeLister                 listersv1.NodeLister
 	namespaceLister            listersv1.NamespaceLister
 	priorityClassLister        schedulingv1.PriorityClassLister
+	pdbLister                  policylistersv1.PodDisruptionBudgetLister
 	getPodsAssignedToNode      podutil.GetPodsAssignedToNodeFunc
 	sharedInformerFactory      informers.SharedInformerFactory
 	evictionPolicyGroupVersion string
 	deschedulerPolicy          *api.DeschedulerPolicy
 	eventRecorder              events.EventRecorder
+	// statusReporter reports the pods evicted in every loop back to the policy when set
+	statusReporter policyStatusReporter
+	// reportWriter writes the report of every loop when set
+	reportWriter *report.Writer
+	// planStore holds the eviction plan the loops produce instead of evicting when set
+	planStore *planStore
+	// evictionRateLimiter paces the evictions of all the loops according to the eviction rate limit of the policy
+	evictionRateLimiter policyBuilt[*api.EvictionRateLimit, flowcontrol.RateLimiter]
+	// workloadBudget caps the evictions of every workload across the loops according to the workload eviction budget of the policy
+	workloadBudget policyBuilt[*api.WorkloadEvictionBudget, *evictions.WorkloadBudget]
+	// cooldown keeps the owners of the pods evicted by the loops cooling down according to the eviction cooldown of the policy
+	cooldown policyBuilt[*api.EvictionCooldown, *evictions.Cooldown]
+	// historyStore persists the eviction history the workload budget and the cooldown are enforced against when set
+	historyStore *historyStore
+	// historyLoaded tells whether the history persisted by the previous instances got loaded
+	historyLoaded bool
+	// workloadListers resolve the workload eviction budgets relative to the replicas of the workloads
+	workloadListers evictions.WorkloadListers
 }
 
 func newDescheduler(ctx context.Context, rs *options.DeschedulerServer, deschedulerPolicy *api.DeschedulerPolicy, evictionPolicyGroupVersion string, eventRecorder events.EventRecorder, sharedInformerFactory informers.SharedInformerFactory) (*descheduler, error) {
@@ -88,6 +118,18 @@ func newDescheduler(ctx context.Context, rs *options.DeschedulerServer, deschedu
 	nodeLister := sharedInformerFactory.Core().V1().Nodes().Lister()
 	namespaceLister := sharedInformerFactory.Core().V1().Namespaces().Lister()
 	priorityClassLister := sharedInformerFactory.Scheduling().V1().PriorityClasses().Lister()
+	// the budgets are only watched by the dry run modes
+	// so evicting for real needs no permissions to list them
+	var pdbLister policylistersv1.PodDisruptionBudgetLister
+	if rs.DryRun || rs.PlanConfigMap != "" {
+		pdbLister = sharedInformerFactory.Policy().V1().PodDisruptionBudgets().Lister()
+	}
+	// the workloads are only watched for the eviction budgets relative to their replicas,
+	// so the descheduler needs no permissions to list them otherwise
+	var workloadListers evictions.WorkloadListers
+	if relativeWorkloadBudget(deschedulerPolicy) {
+		workloadListers = newWorkloadListers(sharedInformerFactory)
+	}
 
 	getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(podInformer)
 	if err != nil {
@@ -100,38 +142,102 @@ func newDescheduler(ctx context.Context, rs *options.DeschedulerServer, deschedu
 		nodeLister:                 nodeLister,
 		namespaceLister:            namespaceLister,
 		priorityClassLister:        priorityClassLister,
+		pdbLister:                  pdbLister,
 		getPodsAssignedToNode:      getPodsAssignedToNode,
 		sharedInformerFactory:      sharedInformerFactory,
 		evictionPolicyGroupVersion: evictionPolicyGroupVersion,
 		deschedulerPolicy:          deschedulerPolicy,
 		eventRecorder:              eventRecorder,
+		workloadListers:            workloadListers,
 	}, nil
 }
 
-func (d *descheduler) runDeschedulerLoop(ctx context.Context, nodes []*v1.Node) error {
+// relativeWorkloadBudget tells whether the policy sets a workload eviction budget relative to the replicas of the workloads
+func relativeWorkloadBudget(deschedulerPolicy *api.DeschedulerPolicy) bool {
+	budget := deschedulerPolicy.WorkloadEvictionBudget
+	return budget != nil && budget.MaxEvictions.Type == intstr.String
+}
+
+// newWorkloadListers registers the informers of the workloads the relative eviction budgets are resolved against
+func newWorkloadListers(sharedInformerFactory informers.SharedInformerFactory) evictions.WorkloadListers {
+	return evictions.WorkloadListers{
+		Deployments:  sharedInformerFactory.Apps().V1().Deployments().Lister(),
+		StatefulSets: sharedInformerFactory.Apps().V1().StatefulSets().Lister(),
+		ReplicaSets:  sharedInformerFactory.Apps().V1().ReplicaSets().Lister(),
+	}
+}
+
+// watchWorkloads starts watching the workloads when a reloaded policy sets the first eviction budget
+// relative to their replicas
+func (d *descheduler) watchWorkloads(ctx context.Context) {
+	if d.workloadListers.Deployments != nil || !relativeWorkloadBudget(d.deschedulerPolicy) {
+		return
+	}
+	d.workloadListers = newWorkloadListers(d.sharedInformerFactory)
+	d.sharedInformerFactory.Start(ctx.Done())
+	d.sharedInformerFactory.WaitForCacheSync(ctx.Done())
+}
+
+// reloadsPolicy tells whether the policy is read from a source it may change in, rather than from a file read once
+func reloadsPolicy(rs *options.DeschedulerServer) bool {
+	return rs.PolicyConfigMap != "" || rs.PolicyResource != "" || rs.ReloadPolicy
+}
+
+func (d *descheduler) runDeschedulerLoop(ctx context.Context, nodes []*v1.Node) (*loopSummary, error) {
 	var span trace.Span
 	ctx, span = tracing.Tracer().Start(ctx, "runDeschedulerLoop")
 	defer span.End()
+	loopStartTime := time.Now()
 	defer func(loopStartDuration time.Time) {
 		metrics.DeschedulerLoopDuration.With(map[string]string{}).Observe(time.Since(loopStartDuration).Seconds())
-	}(time.Now())
+	}(loopStartTime)
 
 	// if len is still <= 1 error out
 	if len(nodes) <= 1 {
 		klog.V(1).InfoS("The cluster size is 0 or 1 meaning eviction causes service disruption or degradation. So aborting..")
-		return fmt.Errorf("the cluster size is 0 or 1")
+		return nil, fmt.Errorf("the cluster size is 0 or 1")
+	}
+
+	// In the plan mode, a pending plan is applied once approved and no new plan is produced meanwhile,
+	// unless it expired. Otherwise the loop runs in the client dry run mode and its evictions make the new plan.
+	var configMap *v1.ConfigMap
+	planning := false
+	if d.planStore != nil {
+		var pending *plan.Plan
+		var err error
+		configMap, pending, err = d.planStore.load(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errLoopFailed, err)
+		}
+		switch {
+		case !pendingPlan(configMap, pending):
+		case expiredPlan(pending, d.rs.PlanExpiration):
+			klog.V(1).InfoS("Replacing the expired eviction plan", "configMap", klog.KObj(configMap), "generatedAt", pending.GeneratedAt)
+		case !d.rs.ApplyPlan && !approvedPlan(configMap):
+			klog.V(1).InfoS("The eviction plan is waiting for approval", "configMap", klog.KObj(configMap), "annotation", plan.ApprovedAnnotation)
+			return newLoopSummary(), nil
+		default:
+			return d.applyPlan(ctx, nodes, configMap, pending)
+		}
+		planning = true
 	}
+	dryRun := d.rs.DryRun || planning
 
 	var client clientset.Interface
 	// When the dry mode is enable, collect all the relevant objects (mostly pods) under a fake client.
 	// So when evicting pods while running multiple strategies in a row have the cummulative effect
 	// as is when evicting pods for real.
-	if d.rs.DryRun {
+	// In the server dry run mode the evictions are sent to the apiserver as dry run requests instead.
+	if clientSideDryRun(d.rs) || planning {
 		klog.V(3).Infof("Building a cached client from the cluster for the dry run")
 		// Create a new cache so we start from scratch without any leftovers
-		fakeClient, err := cachedClient(d.rs.Client, d.podLister, d.nodeLister, d.namespaceLister, d.priorityClassLister)
+		var scoringStrategy string
+		if d.rs.DryRunReschedule {
+			scoringStrategy = d.rs.DryRunScoringStrategy
+		}
+		fakeClient, err := cachedClient(d.rs.Client, d.podLister, d.nodeLister, d.namespaceLister, d.priorityClassLister, d.pdbLister, scoringStrategy)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		// create a new instance of the shared informer factor from the cached client
@@ -139,7 +245,7 @@ func (d *descheduler) runDeschedulerLoop(ctx context.Context, nodes []*v1.Node)
 		// register the pod informer, otherwise it will not get running
 		d.getPodsAssignedToNode, err = podutil.BuildGetPodsAssignedToNodeFunc(fakeSharedInformerFactory.Core().V1().Pods().Informer())
 		if err != nil {
-			return fmt.Errorf("build get pods assigned to node function error: %v", err)
+			return nil, fmt.Errorf("build get pods assigned to node function error: %v", err)
 		}
 
 		fakeCtx, cncl := context.WithCancel(context.TODO())
@@ -147,6 +253,13 @@ func (d *descheduler) runDeschedulerLoop(ctx context.Context, nodes []*v1.Node)
 		fakeSharedInformerFactory.Start(fakeCtx.Done())
 		fakeSharedInformerFactory.WaitForCacheSync(fakeCtx.Done())
 
+		if planning {
+			// the loop applying the plan later evicts for real, through the informers of the cluster
+			sharedInformerFactory, getPodsAssignedToNode := d.sharedInformerFactory, d.getPodsAssignedToNode
+			defer func() {
+				d.sharedInformerFactory, d.getPodsAssignedToNode = sharedInformerFactory, getPodsAssignedToNode
+			}()
+		}
 		client = fakeClient
 		d.sharedInformerFactory = fakeSharedInformerFactory
 	} else {
@@ -157,28 +270,204 @@ func (d *descheduler) runDeschedulerLoop(ctx context.Context, nodes []*v1.Node)
 	podEvictor := evictions.NewPodEvictor(
 		client,
 		d.evictionPolicyGroupVersion,
-		d.rs.DryRun,
+		dryRun,
 		d.deschedulerPolicy.MaxNoOfPodsToEvictPerNode,
 		d.deschedulerPolicy.MaxNoOfPodsToEvictPerNamespace,
 		nodes,
 		!d.rs.DisableMetrics,
 		d.eventRecorder,
 	)
+	podEvictor.SetServerSideDryRun(serverSideDryRun(d.rs))
+	if serverSideDryRun(d.rs) {
+		podEvictor.SetDryRunDisruptionBudgets(d.pdbLister)
+	}
+	podEvictor.SetMaxPodsToEvictTotal(d.deschedulerPolicy.MaxNoOfPodsToEvictTotal)
+	podEvictor.SetRateLimiter(d.rateLimiter())
+	podEvictor.SetRetry(d.rs.EvictionRetryBackoff, d.rs.EvictionRetryDeadline)
+	podEvictor.SetGracePeriodSeconds(d.deschedulerPolicy.GracePeriodSeconds)
+	podEvictor.SetDeleteFallback(deleteFallback(d.deschedulerPolicy))
+	d.watchWorkloads(ctx)
+	workloadBudget, cooldown := d.workloadEvictionBudget(), d.evictionCooldown()
+	d.loadEvictionHistory(ctx, workloadBudget, cooldown)
+	podEvictor.SetCooldown(cooldown)
+	// the evictions of the dry run mode count within the loop only
+	if dryRun {
+		podEvictor.SetWorkloadBudget(workloadBudget.Copy())
+	} else {
+		podEvictor.SetWorkloadBudget(workloadBudget)
+	}
+
+	var recorder *report.Recorder
+	var podsBefore map[string][]*v1.Pod
+	if d.reportWriter != nil {
+		recorder = report.NewRecorder()
+	}
+	if d.reportWriter != nil && enablesNodeUtilization(d.deschedulerPolicy) {
+		podsBefore = nodePods(nodes, d.getPodsAssignedToNode)
+	}
 
-	d.runProfiles(ctx, client, nodes, podEvictor)
+	summary := d.runProfiles(ctx, client, nodes, podEvictor, recorder)
+	summary.addRetries(podEvictor.RetryQueued(ctx))
+	summary.evictions = podEvictor.Evictions()
+	if !dryRun {
+		d.saveEvictionHistory(ctx, workloadBudget, cooldown)
+	}
+	summary.decisions = recorder.Decisions()
+	summary.log()
+
+	if d.reportWriter != nil {
+		loopReport := &report.Report{
+			StartTime: metav1.NewTime(loopStartTime),
+			DryRun:    dryRun,
+			Decisions: summary.decisions,
+		}
+		if podsBefore != nil {
+			loopReport.NodeUtilization = nodeUtilizationReport(nodes, podsBefore, summary.evictions)
+		}
+		if err := d.reportWriter.Write(loopReport); err != nil {
+			klog.ErrorS(err, "Unable to write the descheduling loop report", "file", d.rs.ReportFile)
+		}
+	}
+
+	if planning {
+		p := newPlan(summary.evictions)
+		if err := d.planStore.save(ctx, configMap, p, false); err != nil {
+			return nil, fmt.Errorf("%w: %v", errLoopFailed, err)
+		}
+		klog.V(1).InfoS("Saved the eviction plan", "configMap", klog.KRef(d.planStore.namespace, d.planStore.name), "evictions", len(p.Evictions))
+		return summary, nil
+	}
 
 	klog.V(1).InfoS("Number of evicted pods", "totalEvicted", podEvictor.TotalEvicted())
+	if d.statusReporter != nil {
+		d.statusReporter.reportLoop(ctx, d.deschedulerPolicy, podEvictor.ProfilePluginEvicted())
+	}
 
-	return nil
+	return summary, nil
+}
+
+// loopSummary summarizes the statuses of the extension points run by all the profiles within a descheduling loop
+type loopSummary struct {
+	// runs counts the extension point runs by their status code
+	runs          map[frameworktypes.Code]int
+	podsEvaluated uint
+	podsEvicted   uint
+	podsSkipped   uint
+	// evictions lists the pods evicted within the loop, in the eviction order
+	evictions []evictions.Eviction
+	// decisions lists the decisions about every eviction candidate, when recorded
+	decisions []report.Decision
+}
+
+func newLoopSummary() *loopSummary {
+	return &loopSummary{
+		runs: map[frameworktypes.Code]int{},
+	}
+}
+
+func (s *loopSummary) add(status *frameworktypes.Status) {
+	s.runs[status.GetCode()]++
+	if status == nil {
+		return
+	}
+	s.podsEvaluated += status.PodsEvaluated
+	s.podsEvicted += status.PodsEvicted
+	s.podsSkipped += status.PodsSkipped
+}
+
+// addRetries accounts for the final outcomes of the evictions the plugins got queued for a retry
+func (s *loopSummary) addRetries(outcomes []evictions.RetryOutcome) {
+	for _, outcome := range outcomes {
+		if outcome.Err != nil {
+			s.podsSkipped++
+		} else {
+			s.podsEvicted++
+		}
+	}
+}
+
+func (s *loopSummary) log() {
+	klog.V(1).InfoS("Descheduling loop summary",
+		"evaluatedPods", s.podsEvaluated,
+		"evictedPods", s.podsEvicted,
+		"skippedPods", s.podsSkipped,
+		"succeeded", s.runs[frameworktypes.Success],
+		"noAction", s.runs[frameworktypes.NoAction],
+		"skipped", s.runs[frameworktypes.Skip],
+		"failed", s.runs[frameworktypes.Error],
+	)
+}
+
+// parallelism returns the configured parallelism of the descheduling loop
+func (d *descheduler) parallelism() int {
+	if d.deschedulerPolicy.Parallelism == nil {
+		return parallelize.DefaultParallelism
+	}
+	return int(*d.deschedulerPolicy.Parallelism)
+}
+
+// rateLimiter returns the rate limiter pacing the evictions according to the policy, nil when they are not paced.
+// It is shared by the loops so the rate holds across them, and built again when a reloaded policy changes the rate.
+func (d *descheduler) rateLimiter() flowcontrol.RateLimiter {
+	return d.evictionRateLimiter.get(d.deschedulerPolicy.EvictionRateLimit, func(rateLimit *api.EvictionRateLimit, _ flowcontrol.RateLimiter) flowcontrol.RateLimiter {
+		return newEvictionRateLimiter(rateLimit)
+	})
+}
+
+// policyBuilt is a value built from a section of the policy and shared by the loops.
+// The zero value holds the zero value of T, built from a nil section.
+type policyBuilt[P interface{ DeepCopy() P }, T any] struct {
+	policy P
+	value  T
+}
+
+// get returns the value built from the section of the policy, building it again through build when a reloaded
+// policy changes the section. build gets the previous value, so it can carry over what the value accumulated.
+func (b *policyBuilt[P, T]) get(policy P, build func(policy P, previous T) T) T {
+	if reflect.DeepEqual(b.policy, policy) {
+		return b.value
+	}
+	b.policy = policy.DeepCopy()
+	b.value = build(b.policy, b.value)
+	return b.value
+}
+
+// deleteFallback returns the phases of the pods deleted when their eviction is disallowed, none when
+// the policy does not enable the fallback, and whether the finalizers of such pods are removed
+func deleteFallback(deschedulerPolicy *api.DeschedulerPolicy) ([]v1.PodPhase, bool) {
+	if deschedulerPolicy.DeleteFallback == nil {
+		return nil, false
+	}
+	if len(deschedulerPolicy.DeleteFallback.PodPhases) == 0 {
+		return []v1.PodPhase{v1.PodFailed, v1.PodSucceeded}, deschedulerPolicy.DeleteFallback.RemoveFinalizers
+	}
+	return deschedulerPolicy.DeleteFallback.PodPhases, deschedulerPolicy.DeleteFallback.RemoveFinalizers
+}
+
+// newEvictionRateLimiter builds a token bucket allowing the evictions at the given rate, nil for no rate
+func newEvictionRateLimiter(rateLimit *api.EvictionRateLimit) flowcontrol.RateLimiter {
+	if rateLimit == nil {
+		return nil
+	}
+	qps := float32(rateLimit.Evictions)
+	if rateLimit.Period == api.Minute {
+		qps /= 60
+	}
+	burst := 1
+	if rateLimit.Burst != nil {
+		burst = int(*rateLimit.Burst)
+	}
+	return flowcontrol.NewTokenBucketRateLimiter(qps, burst)
 }
 
 // runProfiles runs all the deschedule plugins of all profiles and
 // later runs through all balance plugins of all profiles. (All Balance plugins should come after all Deschedule plugins)
 // see https://github.com/kubernetes-sigs/descheduler/issues/979
-func (d *descheduler) runProfiles(ctx context.Context, client clientset.Interface, nodes []*v1.Node, podEvictor *evictions.PodEvictor) {
+func (d *descheduler) runProfiles(ctx context.Context, client clientset.Interface, nodes []*v1.Node, podEvictor *evictions.PodEvictor, recorder *report.Recorder) *loopSummary {
 	var span trace.Span
 	ctx, span = tracing.Tracer().Start(ctx, "runProfiles")
 	defer span.End()
+	summary := newLoopSummary()
 	var profileRunners []profileRunner
 	for _, profile := range d.deschedulerPolicy.Profiles {
 		currProfile, err := frameworkprofile.NewProfile(
@@ -188,6 +477,9 @@ func (d *descheduler) runProfiles(ctx context.Context, client clientset.Interfac
 			frameworkprofile.WithSharedInformerFactory(d.sharedInformerFactory),
 			frameworkprofile.WithPodEvictor(podEvictor),
 			frameworkprofile.WithGetPodsAssignedToNodeFnc(d.getPodsAssignedToNode),
+			frameworkprofile.WithParallelism(d.parallelism()),
+			frameworkprofile.WithReportRecorder(recorder),
+			frameworkprofile.WithContext(ctx),
 		)
 		if err != nil {
 			klog.ErrorS(err, "unable to create a profile", "profile", profile.Name)
@@ -196,32 +488,61 @@ func (d *descheduler) runProfiles(ctx context.Context, client clientset.Interfac
 		profileRunners = append(profileRunners, profileRunner{profile.Name, currProfile.RunDeschedulePlugins, currProfile.RunBalancePlugins})
 	}
 
-	for _, profileR := range profileRunners {
-		// First deschedule
-		status := profileR.descheduleEPs(ctx, nodes)
+	// First deschedule. The Deschedule extension points of distinct profiles are independent
+	// of each other so they can run concurrently, the pod evictor keeps the limits exact.
+	descheduleStatuses := make([]*frameworktypes.Status, len(profileRunners))
+	parallelize.NewParallelizer(d.parallelism()).Until(ctx, len(profileRunners), func(i int) {
+		descheduleStatuses[i] = profileRunners[i].descheduleEPs(ctx, nodes)
+	})
+	for i, profileR := range profileRunners {
+		status := descheduleStatuses[i]
+		summary.add(status)
 		if status != nil && status.Err != nil {
 			span.AddEvent("failed to perform deschedule operations", trace.WithAttributes(attribute.String("err", status.Err.Error()), attribute.String("profile", profileR.name), attribute.String("operation", tracing.DescheduleOperation)))
 			klog.ErrorS(status.Err, "running deschedule extension point failed with error", "profile", profileR.name)
 			continue
 		}
+		klog.V(3).InfoS("Deschedule extension point finished", "profile", profileR.name, "code", status.GetCode(), "reason", status.GetReason())
 	}
 
+	// Balance later. Balance plugins rely on the state left by the previous ones
+	// so the profiles always run their Balance extension point one after another.
 	for _, profileR := range profileRunners {
-		// Balance Later
 		status := profileR.balanceEPs(ctx, nodes)
+		summary.add(status)
 		if status != nil && status.Err != nil {
 			span.AddEvent("failed to perform balance operations", trace.WithAttributes(attribute.String("err", status.Err.Error()), attribute.String("profile", profileR.name), attribute.String("operation", tracing.BalanceOperation)))
 			klog.ErrorS(status.Err, "running balance extension point failed with error", "profile", profileR.name)
 			continue
 		}
+		klog.V(3).InfoS("Balance extension point finished", "profile", profileR.name, "code", status.GetCode(), "reason", status.GetReason())
 	}
+
+	span.SetAttributes(
+		attribute.Int("podsEvaluated", int(summary.podsEvaluated)),
+		attribute.Int("podsEvicted", int(summary.podsEvicted)),
+		attribute.Int("podsSkipped", int(summary.podsSkipped)),
+	)
+	return summary
 }
 
-func Run(ctx context.Context, rs *options.DeschedulerServer) error {
+// RunOption configures the descheduler started by Run
+type RunOption func(*runOptions)
+
+type runOptions struct {
+	// explainHandler explains the pods against the running descheduler when set
+	explainHandler *ExplainHandler
+}
+
+func Run(ctx context.Context, rs *options.DeschedulerServer, opts ...RunOption) error {
 	var span trace.Span
 	ctx, span = tracing.Tracer().Start(ctx, "Run")
 	defer span.End()
 	metrics.Register()
+	runOpts := runOptions{}
+	for _, opt := range opts {
+		opt(&runOpts)
+	}
 
 	clientConnection := rs.ClientConnection
 	if rs.KubeconfigFile != "" && clientConnection.Kubeconfig == "" {
@@ -234,9 +555,70 @@ func Run(ctx context.Context, rs *options.DeschedulerServer) error {
 	rs.Client = rsclient
 	rs.EventClient = eventClient
 
-	deschedulerPolicy, err := LoadPolicyConfig(rs.PolicyConfigFile, rs.Client, pluginregistry.PluginRegistry)
-	if err != nil {
-		return err
+	policySources := 0
+	for _, source := range []string{rs.PolicyConfigFile, rs.PolicyConfigMap, rs.PolicyResource} {
+		if source != "" {
+			policySources++
+		}
+	}
+	if policySources > 1 {
+		return fmt.Errorf("only one of policyConfigFile, policyConfigMap and policyResource can be set")
+	}
+	if rs.ReportFile != "" {
+		if err := report.ValidateFormat(report.Format(rs.ReportFormat)); err != nil {
+			return err
+		}
+	}
+	if rs.DryRunMode != options.DryRunModeClient && rs.DryRunMode != options.DryRunModeServer {
+		return fmt.Errorf("unsupported dry run mode %q, expected %q or %q", rs.DryRunMode, options.DryRunModeClient, options.DryRunModeServer)
+	}
+	if rs.DryRunReschedule {
+		if err := ValidateScoringStrategy(rs.DryRunScoringStrategy); err != nil {
+			return err
+		}
+		if rs.DryRunMode != options.DryRunModeClient {
+			return fmt.Errorf("dry run rescheduling requires the %q dry run mode", options.DryRunModeClient)
+		}
+	}
+	if rs.PlanConfigMap != "" && rs.DryRun {
+		return fmt.Errorf("planConfigMap can not be used in the dry run mode")
+	}
+	if rs.ApplyPlan && rs.PlanConfigMap == "" {
+		return fmt.Errorf("applyPlan requires planConfigMap")
+	}
+	if rs.PlanExpiration < 0 {
+		return fmt.Errorf("planExpiration must not be negative")
+	}
+	if rs.EvictionRetryDeadline < 0 {
+		return fmt.Errorf("evictionRetryDeadline must not be negative")
+	}
+	if rs.EvictionRetryDeadline > 0 && rs.EvictionRetryBackoff <= 0 {
+		return fmt.Errorf("evictionRetryBackoff must be positive")
+	}
+	if rs.PolicyResource != "" {
+		rs.DynamicClient, err = client.CreateDynamicClient(clientConnection, "descheduler")
+		if err != nil {
+			return err
+		}
+	}
+
+	var deschedulerPolicy *api.DeschedulerPolicy
+	var reloader *policyReloader
+	if reloadsPolicy(rs) {
+		reloader, err = newPolicyReloaderFromOptions(ctx, rs)
+		if err != nil {
+			return err
+		}
+		deschedulerPolicy = reloader.policy
+		// The custom resource is always watched
+		if !rs.ReloadPolicy && rs.PolicyResource == "" {
+			reloader = nil
+		}
+	} else {
+		deschedulerPolicy, err = LoadPolicyConfig(rs.PolicyConfigFile, rs.Client, pluginregistry.PluginRegistry)
+		if err != nil {
+			return err
+		}
 	}
 	if deschedulerPolicy == nil {
 		return fmt.Errorf("deschedulerPolicy is nil")
@@ -253,7 +635,7 @@ func Run(ctx context.Context, rs *options.DeschedulerServer) error {
 	}
 
 	runFn := func() error {
-		return RunDeschedulerStrategies(ctx, rs, deschedulerPolicy, evictionPolicyGroupVersion)
+		return runDeschedulerStrategies(ctx, rs, deschedulerPolicy, reloader, evictionPolicyGroupVersion, runOpts)
 	}
 
 	if rs.LeaderElection.LeaderElect && rs.DeschedulingInterval.Seconds() == 0 {
@@ -261,11 +643,12 @@ func Run(ctx context.Context, rs *options.DeschedulerServer) error {
 		return fmt.Errorf("leaderElection must be used with deschedulingInterval")
 	}
 
-	if rs.LeaderElection.LeaderElect && rs.DryRun {
-		klog.V(1).Info("Warning: DryRun is set to True. You need to disable it to use Leader Election.")
+	if rs.LeaderElection.LeaderElect && clientSideDryRun(rs) {
+		klog.V(1).Info("Warning: DryRun is set to True. You need to disable it or use the server dry run mode to use Leader Election.")
 	}
 
-	if rs.LeaderElection.LeaderElect && !rs.DryRun {
+	// server side dry runs validate the evictions against the cluster, so only the leader runs them
+	if rs.LeaderElection.LeaderElect && !clientSideDryRun(rs) {
 		if err := NewLeaderElection(runFn, rsclient, &rs.LeaderElection, ctx); err != nil {
 			span.AddEvent("Leader Election Failure", trace.WithAttributes(attribute.String("err", err.Error())))
 			return fmt.Errorf("leaderElection: %w", err)
@@ -276,6 +659,16 @@ func Run(ctx context.Context, rs *options.DeschedulerServer) error {
 	return runFn()
 }
 
+// clientSideDryRun tells whether the pods are evicted from an in-memory copy of the cluster
+func clientSideDryRun(rs *options.DeschedulerServer) bool {
+	return rs.DryRun && rs.DryRunMode != options.DryRunModeServer
+}
+
+// serverSideDryRun tells whether the evictions are sent to the apiserver as dry run requests
+func serverSideDryRun(rs *options.DeschedulerServer) bool {
+	return rs.DryRun && rs.DryRunMode == options.DryRunModeServer
+}
+
 func validateVersionCompatibility(discovery discovery.DiscoveryInterface, versionInfo version.Info) error {
 	serverVersion, serverErr := discovery.ServerVersion()
 	if serverErr != nil {
@@ -306,14 +699,25 @@ func validateVersionCompatibility(discovery discovery.DiscoveryInterface, versio
 	return nil
 }
 
+// cachedClient copies the objects the plugins consume into a fake client evicting the pods by deleting them,
+// as long as their pod disruption budgets, copied as well when the lister is set, allow it.
+// When a scoring strategy is set, the replacement of every evicted pod is bound to the best fitting node.
 func cachedClient(
 	realClient clientset.Interface,
 	podLister listersv1.PodLister,
 	nodeLister listersv1.NodeLister,
 	namespaceLister listersv1.NamespaceLister,
 	priorityClassLister schedulingv1.PriorityClassLister,
+	pdbLister policylistersv1.PodDisruptionBudgetLister,
+	scoringStrategy string,
 ) (clientset.Interface, error) {
 	fakeClient := fakeclientset.NewSimpleClientset()
+	// budgets are set once the pods and their pod disruption budgets are copied
+	var budgets *disruptionBudgets
+	var scheduler *dryRunScheduler
+	if scoringStrategy != "" {
+		scheduler = &dryRunScheduler{tracker: fakeClient.Tracker(), scoringStrategy: scoringStrategy}
+	}
 	// simulate a pod eviction by deleting a pod
 	fakeClient.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
 		if action.GetSubresource() == "eviction" {
@@ -325,9 +729,24 @@ func cachedClient(
 			if !matched {
 				return false, nil, fmt.Errorf("unable to convert action object into *policy.Eviction")
 			}
+			obj, err := fakeClient.Tracker().Get(action.GetResource(), eviction.GetNamespace(), eviction.GetName())
+			if err != nil {
+				return false, nil, fmt.Errorf("unable to get pod %v/%v: %v", eviction.GetNamespace(), eviction.GetName(), err)
+			}
+			if budgets != nil {
+				if err := budgets.check(obj.(*v1.Pod)); err != nil {
+					return true, nil, err
+				}
+			}
 			if err := fakeClient.Tracker().Delete(action.GetResource(), eviction.GetNamespace(), eviction.GetName()); err != nil {
 				return false, nil, fmt.Errorf("unable to delete pod %v/%v: %v", eviction.GetNamespace(), eviction.GetName(), err)
 			}
+			if scheduler != nil {
+				// the eviction succeeded, failing to simulate the rescheduling only skews the later plugins
+				if err := scheduler.reschedule(obj.(*v1.Pod)); err != nil {
+					klog.ErrorS(err, "Unable to reschedule the evicted pod in dry run mode", "pod", klog.KObj(obj.(*v1.Pod)))
+				}
+			}
 			return true, nil, nil
 		}
 		// fallback to the default reactor
@@ -379,21 +798,39 @@ func cachedClient(
 		}
 	}
 
+	if pdbLister != nil {
+		pdbs, err := pdbLister.List(labels.Everything())
+		if err != nil {
+			return nil, fmt.Errorf("unable to list poddisruptionbudgets: %v", err)
+		}
+
+		for _, item := range pdbs {
+			if _, err := fakeClient.PolicyV1().PodDisruptionBudgets(item.Namespace).Create(context.TODO(), item, metav1.CreateOptions{}); err != nil {
+				return nil, fmt.Errorf("unable to copy poddisruptionbudget: %v", err)
+			}
+		}
+
+		if budgets, err = newDisruptionBudgets(fakeClient.Tracker()); err != nil {
+			return nil, err
+		}
+	}
+
 	return fakeClient, nil
 }
 
 func RunDeschedulerStrategies(ctx context.Context, rs *options.DeschedulerServer, deschedulerPolicy *api.DeschedulerPolicy, evictionPolicyGroupVersion string) error {
+	return runDeschedulerStrategies(ctx, rs, deschedulerPolicy, nil, evictionPolicyGroupVersion, runOptions{})
+}
+
+// runDeschedulerStrategies runs the descheduling loops. If a reloader is set,
+// the policy is reloaded before every loop.
+func runDeschedulerStrategies(ctx context.Context, rs *options.DeschedulerServer, deschedulerPolicy *api.DeschedulerPolicy, reloader *policyReloader, evictionPolicyGroupVersion string, opts runOptions) error {
 	var span trace.Span
 	ctx, span = tracing.Tracer().Start(ctx, "RunDeschedulerStrategies")
 	defer span.End()
 	sharedInformerFactory := informers.NewSharedInformerFactory(rs.Client, 0)
 	nodeLister := sharedInformerFactory.Core().V1().Nodes().Lister()
 
-	var nodeSelector string
-	if deschedulerPolicy.NodeSelector != nil {
-		nodeSelector = *deschedulerPolicy.NodeSelector
-	}
-
 	var eventClient clientset.Interface
 	if rs.DryRun {
 		eventClient = fakeclientset.NewSimpleClientset()
@@ -408,6 +845,34 @@ func RunDeschedulerStrategies(ctx context.Context, rs *options.DeschedulerServer
 		span.AddEvent("Failed to create new descheduler", trace.WithAttributes(attribute.String("err", err.Error())))
 		return err
 	}
+	if reloader != nil {
+		if reporter, ok := reloader.source.(policyStatusReporter); ok {
+			descheduler.statusReporter = reporter
+		}
+	}
+	if rs.PlanConfigMap != "" {
+		descheduler.planStore, err = newPlanStore(rs.Client, rs.PlanConfigMap)
+		if err != nil {
+			return err
+		}
+	}
+	if rs.EvictionHistoryConfigMap != "" {
+		descheduler.historyStore, err = newHistoryStore(rs.Client, rs.EvictionHistoryConfigMap)
+		if err != nil {
+			return err
+		}
+	}
+	if rs.ReportFile != "" {
+		descheduler.reportWriter, err = report.NewWriter(rs.ReportFile, report.Format(rs.ReportFormat))
+		if err != nil {
+			return err
+		}
+	}
+	if opts.explainHandler != nil {
+		if err := opts.explainHandler.setDescheduler(descheduler); err != nil {
+			return err
+		}
+	}
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
@@ -418,6 +883,13 @@ func RunDeschedulerStrategies(ctx context.Context, rs *options.DeschedulerServer
 		// A next context is created here intentionally to avoid nesting the spans via context.
 		sCtx, sSpan := tracing.Tracer().Start(ctx, "NonSlidingUntil")
 		defer sSpan.End()
+		if reloader != nil {
+			descheduler.deschedulerPolicy = reloader.reload(sCtx, eventRecorder)
+		}
+		var nodeSelector string
+		if descheduler.deschedulerPolicy.NodeSelector != nil {
+			nodeSelector = *descheduler.deschedulerPolicy.NodeSelector
+		}
 		nodes, err := nodeutil.ReadyNodes(sCtx, rs.Client, nodeLister, nodeSelector)
 		if err != nil {
 			sSpan.AddEvent("Failed to detect ready nodes", trace.WithAttributes(attribute.String("err", err.Error())))
@@ -425,8 +897,15 @@ func RunDeschedulerStrategies(ctx context.Context, rs *options.DeschedulerServer
 			cancel()
 			return
 		}
-		err = descheduler.runDeschedulerLoop(sCtx, nodes)
-		if err != nil {
+		_, err = descheduler.runDeschedulerLoop(sCtx, nodes)
+		// the limits are observed once the loop built them, watching the workloads if needed
+		if opts.explainHandler != nil {
+			opts.explainHandler.observeLoop(descheduler)
+		}
+		if err != nil && errors.Is(err, errLoopFailed) {
+			sSpan.AddEvent("Failed to run descheduler loop", trace.WithAttributes(attribute.String("err", err.Error())))
+			klog.ErrorS(err, "Retrying on the next interval")
+		} else if err != nil {
 			sSpan.AddEvent("Failed to run descheduler loop", trace.WithAttributes(attribute.String("err", err.Error())))
 			klog.Error(err)
 			cancel()
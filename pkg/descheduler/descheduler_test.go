@@ -11,8 +11,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/conversion"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	apiversion "k8s.io/apimachinery/pkg/version"
 	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/informers"
 	fakeclientset "k8s.io/client-go/kubernetes/fake"
 	core "k8s.io/client-go/testing"
 	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
@@ -317,3 +319,58 @@ func podEvictionReactionFuc(evictedPods *[]string) func(action core.Action) (boo
 		return false, nil, nil // fallback to the default reactor
 	}
 }
+
+func TestEvictionRateLimiter(t *testing.T) {
+	burst := uint(3)
+	d := &descheduler{deschedulerPolicy: &api.DeschedulerPolicy{}}
+	if limiter := d.rateLimiter(); limiter != nil {
+		t.Fatalf("Expected no rate limiter without a rate limit, got %v", limiter)
+	}
+
+	d.deschedulerPolicy = &api.DeschedulerPolicy{EvictionRateLimit: &api.EvictionRateLimit{Evictions: 30, Period: api.Minute, Burst: &burst}}
+	limiter := d.rateLimiter()
+	if limiter == nil || limiter.QPS() != 0.5 {
+		t.Fatalf("Expected a rate limiter allowing 0.5 evictions per second, got %v", limiter)
+	}
+	for i := uint(0); i < burst; i++ {
+		if !limiter.TryAccept() {
+			t.Fatalf("Expected the rate limiter to allow a burst of %v evictions, got %v", burst, i)
+		}
+	}
+
+	d.deschedulerPolicy = d.deschedulerPolicy.DeepCopy()
+	if d.rateLimiter() != limiter {
+		t.Errorf("Expected the rate limiter to be kept while the rate limit does not change")
+	}
+
+	d.deschedulerPolicy = &api.DeschedulerPolicy{EvictionRateLimit: &api.EvictionRateLimit{Evictions: 2}}
+	if limiter := d.rateLimiter(); limiter == nil || limiter.QPS() != 2 {
+		t.Errorf("Expected a rate limiter allowing 2 evictions per second, got %v", limiter)
+	}
+}
+
+func TestWatchWorkloads(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := fakeclientset.NewSimpleClientset()
+	sharedInformerFactory := informers.NewSharedInformerFactory(client, 0)
+	d, err := newDescheduler(ctx, &options.DeschedulerServer{Client: client}, &api.DeschedulerPolicy{}, "v1", nil, sharedInformerFactory)
+	if err != nil {
+		t.Fatalf("Unable to create a descheduler: %v", err)
+	}
+	d.watchWorkloads(ctx)
+	if d.workloadListers.Deployments != nil {
+		t.Fatalf("Expected the workloads not to be watched without a relative workload eviction budget")
+	}
+
+	d.deschedulerPolicy = &api.DeschedulerPolicy{
+		WorkloadEvictionBudget: &api.WorkloadEvictionBudget{MaxEvictions: intstr.FromString("10%"), WindowSeconds: 1800},
+	}
+	d.watchWorkloads(ctx)
+	if d.workloadListers.Deployments == nil || d.workloadListers.StatefulSets == nil || d.workloadListers.ReplicaSets == nil {
+		t.Fatalf("Expected the workloads to be watched once the policy sets a relative workload eviction budget")
+	}
+	if !sharedInformerFactory.Apps().V1().Deployments().Informer().HasSynced() {
+		t.Errorf("Expected the workload informers to be synced")
+	}
+}
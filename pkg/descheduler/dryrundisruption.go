@@ -0,0 +1,171 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	core "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+var (
+	pdbsResource = policy.SchemeGroupVersion.WithResource("poddisruptionbudgets")
+	pdbsKind     = policy.SchemeGroupVersion.WithKind("PodDisruptionBudget")
+)
+
+// disruptionBudgets enforces the pod disruption budgets on the evictions in the dry run mode
+type disruptionBudgets struct {
+	tracker core.ObjectTracker
+	// expectedPods holds the number of pods expected by every budget, counted before any eviction
+	// as the disruption controller reads it from the scale of the controllers the cache lacks
+	expectedPods map[types.NamespacedName]int
+}
+
+// newDisruptionBudgets counts the pods expected by every budget in the tracker
+func newDisruptionBudgets(tracker core.ObjectTracker) (*disruptionBudgets, error) {
+	obj, err := tracker.List(pdbsResource, pdbsKind, "")
+	if err != nil {
+		return nil, fmt.Errorf("unable to list pod disruption budgets: %v", err)
+	}
+	budgets := &disruptionBudgets{tracker: tracker, expectedPods: map[types.NamespacedName]int{}}
+	for i := range obj.(*policy.PodDisruptionBudgetList).Items {
+		pdb := &obj.(*policy.PodDisruptionBudgetList).Items[i]
+		expected, _, err := budgets.pods(pdb)
+		if err != nil {
+			return nil, err
+		}
+		budgets.expectedPods[types.NamespacedName{Namespace: pdb.Namespace, Name: pdb.Name}] = expected
+	}
+	return budgets, nil
+}
+
+// check checks the eviction of a pod respects its pod disruption budget,
+// the way the eviction subresource does, returning a 429 error when the budget is exhausted.
+// The disruptions allowed are computed from the healthy pods matching the budget in the tracker,
+// so they account for the pods evicted earlier in the dry run.
+func (b *disruptionBudgets) check(pod *v1.Pod) error {
+	// terminal, pending and terminating pods are evicted regardless of their budget
+	if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed || pod.Status.Phase == v1.PodPending || utils.IsPodTerminating(pod) {
+		return nil
+	}
+
+	obj, err := b.tracker.List(pdbsResource, pdbsKind, pod.Namespace)
+	if err != nil {
+		return fmt.Errorf("unable to list pod disruption budgets: %v", err)
+	}
+	var pdbs []*policy.PodDisruptionBudget
+	for i := range obj.(*policy.PodDisruptionBudgetList).Items {
+		pdb := &obj.(*policy.PodDisruptionBudgetList).Items[i]
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			continue
+		}
+		if selector.Matches(labels.Set(pod.Labels)) {
+			pdbs = append(pdbs, pdb)
+		}
+	}
+	if len(pdbs) == 0 {
+		return nil
+	}
+	if len(pdbs) > 1 {
+		return apierrors.NewInternalError(fmt.Errorf("this pod has more than one PodDisruptionBudget, which the eviction subresource does not support"))
+	}
+	pdb := pdbs[0]
+
+	expected, healthy, err := b.pods(pdb)
+	if err != nil {
+		return err
+	}
+	if initial, ok := b.expectedPods[types.NamespacedName{Namespace: pdb.Namespace, Name: pdb.Name}]; ok {
+		expected = initial
+	}
+	desiredHealthy, err := desiredHealthyPods(pdb, expected)
+	if err != nil {
+		return err
+	}
+
+	// unhealthy pods are evicted as long as the budget is met, or always when the policy says so
+	if !utils.IsPodReady(pod) {
+		if pdb.Spec.UnhealthyPodEvictionPolicy != nil && *pdb.Spec.UnhealthyPodEvictionPolicy == policy.AlwaysAllow {
+			return nil
+		}
+		if healthy >= desiredHealthy {
+			return nil
+		}
+	}
+
+	if healthy-desiredHealthy <= 0 {
+		err := apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+		err.ErrStatus.Details.Causes = append(err.ErrStatus.Details.Causes, metav1.StatusCause{
+			Type:    policy.DisruptionBudgetCause,
+			Message: fmt.Sprintf("The disruption budget %s needs %d healthy pods and has %d currently", pdb.Name, desiredHealthy, healthy),
+		})
+		return err
+	}
+	return nil
+}
+
+// pods counts the pods matching a pod disruption budget, the expected ones
+// being all the pods that are not terminal yet and the healthy ones those that are ready
+func (b *disruptionBudgets) pods(pdb *policy.PodDisruptionBudget) (expected, healthy int, err error) {
+	selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+	if err != nil {
+		return 0, 0, err
+	}
+	obj, err := b.tracker.List(podsResource, podsKind, pdb.Namespace)
+	if err != nil {
+		return 0, 0, fmt.Errorf("unable to list pods: %v", err)
+	}
+	for _, pod := range obj.(*v1.PodList).Items {
+		if !selector.Matches(labels.Set(pod.Labels)) || pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+		expected++
+		if !utils.IsPodTerminating(&pod) && utils.IsPodReady(&pod) {
+			healthy++
+		}
+	}
+	return expected, healthy, nil
+}
+
+// desiredHealthyPods returns the number of healthy pods a pod disruption budget requires
+func desiredHealthyPods(pdb *policy.PodDisruptionBudget, expected int) (int, error) {
+	switch {
+	case pdb.Spec.MaxUnavailable != nil:
+		maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(pdb.Spec.MaxUnavailable, expected, true)
+		if err != nil {
+			return 0, err
+		}
+		desired := expected - maxUnavailable
+		if desired < 0 {
+			desired = 0
+		}
+		return desired, nil
+	case pdb.Spec.MinAvailable != nil:
+		return intstr.GetScaledValueFromIntOrPercent(pdb.Spec.MinAvailable, expected, true)
+	}
+	return 0, nil
+}
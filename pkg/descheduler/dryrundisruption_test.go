@@ -0,0 +1,165 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/informers"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestDryRunDisruptionBudgets(t *testing.T) {
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	buildPod := func(name string, ready bool) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, n1.Name, func(pod *v1.Pod) {
+			test.SetRSOwnerRef(pod)
+			pod.Labels = map[string]string{"app": "a"}
+			status := v1.ConditionFalse
+			if ready {
+				status = v1.ConditionTrue
+			}
+			pod.Status.Conditions = []v1.PodCondition{{Type: v1.PodReady, Status: status}}
+		})
+	}
+	buildPDB := func(name string, apply func(*policy.PodDisruptionBudget)) *policy.PodDisruptionBudget {
+		pdb := &policy.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       policy.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "a"}}},
+		}
+		apply(pdb)
+		return pdb
+	}
+	minAvailable := func(value intstr.IntOrString) func(*policy.PodDisruptionBudget) {
+		return func(pdb *policy.PodDisruptionBudget) { pdb.Spec.MinAvailable = &value }
+	}
+	alwaysAllow := policy.AlwaysAllow
+
+	tests := []struct {
+		name     string
+		pods     []*v1.Pod
+		pdbs     []runtime.Object
+		evict    []string
+		expected []metav1.StatusReason
+	}{
+		{
+			name:     "min available",
+			pods:     []*v1.Pod{buildPod("p1", true), buildPod("p2", true), buildPod("p3", true)},
+			pdbs:     []runtime.Object{buildPDB("pdb", minAvailable(intstr.FromInt(2)))},
+			evict:    []string{"p1", "p2"},
+			expected: []metav1.StatusReason{"", metav1.StatusReasonTooManyRequests},
+		},
+		{
+			name:     "min available percentage",
+			pods:     []*v1.Pod{buildPod("p1", true), buildPod("p2", true), buildPod("p3", true), buildPod("p4", true)},
+			pdbs:     []runtime.Object{buildPDB("pdb", minAvailable(intstr.FromString("25%")))},
+			evict:    []string{"p1", "p2", "p3", "p4"},
+			expected: []metav1.StatusReason{"", "", "", metav1.StatusReasonTooManyRequests},
+		},
+		{
+			name: "max unavailable",
+			pods: []*v1.Pod{buildPod("p1", true), buildPod("p2", true), buildPod("p3", true)},
+			pdbs: []runtime.Object{buildPDB("pdb", func(pdb *policy.PodDisruptionBudget) {
+				maxUnavailable := intstr.FromInt(1)
+				pdb.Spec.MaxUnavailable = &maxUnavailable
+			})},
+			evict:    []string{"p1", "p2"},
+			expected: []metav1.StatusReason{"", metav1.StatusReasonTooManyRequests},
+		},
+		{
+			name:     "unhealthy pods are evicted as long as the budget is met",
+			pods:     []*v1.Pod{buildPod("p1", true), buildPod("p2", true), buildPod("unready", false)},
+			pdbs:     []runtime.Object{buildPDB("pdb", minAvailable(intstr.FromInt(2)))},
+			evict:    []string{"p1", "unready"},
+			expected: []metav1.StatusReason{metav1.StatusReasonTooManyRequests, ""},
+		},
+		{
+			name:     "unhealthy pods are not evicted once the budget is exhausted",
+			pods:     []*v1.Pod{buildPod("p1", true), buildPod("unready", false)},
+			pdbs:     []runtime.Object{buildPDB("pdb", minAvailable(intstr.FromInt(2)))},
+			evict:    []string{"unready"},
+			expected: []metav1.StatusReason{metav1.StatusReasonTooManyRequests},
+		},
+		{
+			name: "unhealthy pods are always evicted with the AlwaysAllow policy",
+			pods: []*v1.Pod{buildPod("p1", true), buildPod("unready", false)},
+			pdbs: []runtime.Object{buildPDB("pdb", func(pdb *policy.PodDisruptionBudget) {
+				minAvailable(intstr.FromInt(2))(pdb)
+				pdb.Spec.UnhealthyPodEvictionPolicy = &alwaysAllow
+			})},
+			evict:    []string{"unready"},
+			expected: []metav1.StatusReason{""},
+		},
+		{
+			name:     "pods matching no budget",
+			pods:     []*v1.Pod{buildPod("p1", true)},
+			pdbs:     []runtime.Object{buildPDB("pdb", func(pdb *policy.PodDisruptionBudget) { pdb.Spec.Selector = nil })},
+			evict:    []string{"p1"},
+			expected: []metav1.StatusReason{""},
+		},
+		{
+			name:     "pods matching several budgets",
+			pods:     []*v1.Pod{buildPod("p1", true), buildPod("p2", true)},
+			pdbs:     []runtime.Object{buildPDB("pdb1", minAvailable(intstr.FromInt(0))), buildPDB("pdb2", minAvailable(intstr.FromInt(0)))},
+			evict:    []string{"p1"},
+			expected: []metav1.StatusReason{metav1.StatusReasonInternalError},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			objects := []runtime.Object{n1}
+			for _, pod := range tc.pods {
+				objects = append(objects, pod)
+			}
+			client := fakeclientset.NewSimpleClientset(append(objects, tc.pdbs...)...)
+			sharedInformerFactory := informers.NewSharedInformerFactory(client, 0)
+			podLister := sharedInformerFactory.Core().V1().Pods().Lister()
+			nodeLister := sharedInformerFactory.Core().V1().Nodes().Lister()
+			namespaceLister := sharedInformerFactory.Core().V1().Namespaces().Lister()
+			priorityClassLister := sharedInformerFactory.Scheduling().V1().PriorityClasses().Lister()
+			pdbLister := sharedInformerFactory.Policy().V1().PodDisruptionBudgets().Lister()
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			fakeClient, err := cachedClient(client, podLister, nodeLister, namespaceLister, priorityClassLister, pdbLister, "")
+			if err != nil {
+				t.Fatalf("Unable to build the cached client: %v", err)
+			}
+			var results []metav1.StatusReason
+			for _, name := range tc.evict {
+				eviction := &policy.Eviction{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+				results = append(results, apierrors.ReasonForError(fakeClient.PolicyV1().Evictions("default").Evict(ctx, eviction)))
+			}
+			if diff := cmp.Diff(tc.expected, results); diff != "" {
+				t.Errorf("Unexpected eviction results (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
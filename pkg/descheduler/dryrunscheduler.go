@@ -0,0 +1,219 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/apiserver/pkg/storage/names"
+	core "k8s.io/client-go/testing"
+	"k8s.io/klog/v2"
+
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+const (
+	// LeastAllocatedScoringStrategy favors the nodes with the least resources requested, spreading the pods
+	LeastAllocatedScoringStrategy = "LeastAllocated"
+	// MostAllocatedScoringStrategy favors the nodes with the most resources requested, bin-packing the pods
+	MostAllocatedScoringStrategy = "MostAllocated"
+)
+
+var (
+	podsResource  = v1.SchemeGroupVersion.WithResource("pods")
+	podsKind      = v1.SchemeGroupVersion.WithKind("Pod")
+	nodesResource = v1.SchemeGroupVersion.WithResource("nodes")
+	nodesKind     = v1.SchemeGroupVersion.WithKind("Node")
+
+	// scoredResources are the resources the nodes are scored by
+	scoredResources = []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory}
+)
+
+// ValidateScoringStrategy checks the scoring strategy of the dry run scheduler is supported
+func ValidateScoringStrategy(strategy string) error {
+	switch strategy {
+	case LeastAllocatedScoringStrategy, MostAllocatedScoringStrategy:
+		return nil
+	}
+	return fmt.Errorf("unsupported scoring strategy %q, expected %q or %q", strategy, LeastAllocatedScoringStrategy, MostAllocatedScoringStrategy)
+}
+
+// dryRunScheduler simulates the rescheduling of the pods evicted in the dry run mode.
+// The replacement the owner of an evicted pod creates is bound to the best fitting node
+// so the plugins running later see the cluster as it would be once the pods got rescheduled.
+// It works on the tracker of the cached client directly, as it runs within its reactors.
+type dryRunScheduler struct {
+	tracker         core.ObjectTracker
+	scoringStrategy string
+}
+
+// reschedule creates the replacement of an evicted pod and binds it to the best fitting node.
+// The replacements of the daemon set pods are bound to the node of the evicted pod, as the daemon
+// set controller does. Pods without an owner are not replaced, nor are the replacements fitting no node created.
+func (s *dryRunScheduler) reschedule(pod *v1.Pod) error {
+	replacement := replacementPod(pod)
+	if replacement == nil {
+		klog.V(3).InfoS("Evicted pod has no owner to replace it in dry run mode", "pod", klog.KObj(pod))
+		return nil
+	}
+
+	nodeName := pod.Spec.NodeName
+	if !utils.IsDaemonsetPod(replacement.OwnerReferences) {
+		node, err := s.bestNode(replacement)
+		if err != nil {
+			return err
+		}
+		if node == nil {
+			klog.V(1).InfoS("Replacement of the evicted pod fits no node in dry run mode", "pod", klog.KObj(pod))
+			return nil
+		}
+		nodeName = node.Name
+	}
+
+	replacement.Spec.NodeName = nodeName
+	replacement.Status = v1.PodStatus{
+		Phase:     v1.PodRunning,
+		StartTime: &replacement.CreationTimestamp,
+		Conditions: []v1.PodCondition{
+			{Type: v1.PodReady, Status: v1.ConditionTrue, LastTransitionTime: replacement.CreationTimestamp},
+			{Type: v1.ContainersReady, Status: v1.ConditionTrue, LastTransitionTime: replacement.CreationTimestamp},
+		},
+	}
+	if err := s.tracker.Create(podsResource, replacement, replacement.Namespace); err != nil {
+		return fmt.Errorf("unable to create the replacement of pod %v/%v: %v", pod.Namespace, pod.Name, err)
+	}
+	klog.V(1).InfoS("Rescheduled evicted pod in dry run mode", "pod", klog.KObj(pod), "replacement", klog.KObj(replacement), "node", nodeName)
+	return nil
+}
+
+// replacementPod returns the pod the owner of an evicted pod would create in its place,
+// not bound to any node yet, or nil when the pod has no owner
+func replacementPod(pod *v1.Pod) *v1.Pod {
+	ownerRefs := podutil.OwnerRef(pod)
+	if len(ownerRefs) == 0 {
+		return nil
+	}
+	owner := ownerRefs[0]
+	if controller := metav1.GetControllerOf(pod); controller != nil {
+		owner = *controller
+	}
+	replacement := pod.DeepCopy()
+	// stateful set pods are recreated under the same name
+	if owner.Kind != "StatefulSet" {
+		prefix := pod.GenerateName
+		if prefix == "" {
+			prefix = pod.Name + "-"
+		}
+		replacement.Name = names.SimpleNameGenerator.GenerateName(prefix)
+	}
+	replacement.UID = uuid.NewUUID()
+	replacement.ResourceVersion = ""
+	replacement.CreationTimestamp = metav1.Now()
+	replacement.DeletionTimestamp = nil
+	replacement.Spec.NodeName = ""
+	replacement.Status = v1.PodStatus{Phase: v1.PodPending}
+	return replacement
+}
+
+// bestNode returns the node fitting the pod with the highest score, or nil when no node fits it.
+// Ties are broken by the node names so the simulation is deterministic.
+func (s *dryRunScheduler) bestNode(pod *v1.Pod) (*v1.Node, error) {
+	obj, err := s.tracker.List(nodesResource, nodesKind, "")
+	if err != nil {
+		return nil, fmt.Errorf("unable to list nodes: %v", err)
+	}
+	nodes := obj.(*v1.NodeList).Items
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+
+	var best *v1.Node
+	var bestScore float64
+	for i := range nodes {
+		node := &nodes[i]
+		if !nodeutil.IsReady(node) {
+			continue
+		}
+		if errs := nodeutil.NodeFit(s.podsAssignedToNode, pod, node); len(errs) > 0 {
+			klog.V(4).InfoS("Replacement pod does not fit on node", "pod", klog.KObj(pod), "node", klog.KObj(node), "error", utilerrors.NewAggregate(errs).Error())
+			continue
+		}
+		score, err := s.score(pod, node)
+		if err != nil {
+			return nil, err
+		}
+		if best == nil || score > bestScore {
+			best, bestScore = node, score
+		}
+	}
+	return best, nil
+}
+
+// score scores a node the pod fits on, from 0 to 100, by the resources the pods
+// on the node would request once the pod is bound to it
+func (s *dryRunScheduler) score(pod *v1.Pod, node *v1.Node) (float64, error) {
+	pods, err := podutil.ListPodsOnANode(node.Name, s.podsAssignedToNode, nil)
+	if err != nil {
+		return 0, err
+	}
+	requested := nodeutil.NodeUtilization(append(pods, pod), scoredResources)
+
+	var total float64
+	var scored int
+	for _, resourceName := range scoredResources {
+		allocatable, ok := node.Status.Allocatable[resourceName]
+		if !ok || allocatable.IsZero() {
+			continue
+		}
+		fraction := float64(requested[resourceName].MilliValue()) / float64(allocatable.MilliValue())
+		if fraction > 1 {
+			fraction = 1
+		}
+		if s.scoringStrategy == MostAllocatedScoringStrategy {
+			total += fraction * 100
+		} else {
+			total += (1 - fraction) * 100
+		}
+		scored++
+	}
+	if scored == 0 {
+		return 0, nil
+	}
+	return total / float64(scored), nil
+}
+
+// podsAssignedToNode lists the pods assigned to a node from the tracker, which is
+// up to date with the evictions and rescheduling unlike the informers of the cached client
+func (s *dryRunScheduler) podsAssignedToNode(nodeName string, filter podutil.FilterFunc) ([]*v1.Pod, error) {
+	obj, err := s.tracker.List(podsResource, podsKind, "")
+	if err != nil {
+		return nil, fmt.Errorf("unable to list pods: %v", err)
+	}
+	var pods []*v1.Pod
+	for i := range obj.(*v1.PodList).Items {
+		pod := &obj.(*v1.PodList).Items[i]
+		if pod.Spec.NodeName == nodeName && (filter == nil || filter(pod)) {
+			pods = append(pods, pod)
+		}
+	}
+	return pods, nil
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/pkg/utils"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestDryRunReschedule(t *testing.T) {
+	// n1 is tainted, n2 is the least and n3 the most allocated of the nodes fitting the replacement
+	n1 := test.BuildTestNode("n1", 2000, 2000, 10, func(node *v1.Node) {
+		node.Spec.Taints = []v1.Taint{{Key: "dedicated", Value: "infra", Effect: v1.TaintEffectNoSchedule}}
+	})
+	n2 := test.BuildTestNode("n2", 2000, 2000, 10, nil)
+	n3 := test.BuildTestNode("n3", 2000, 2000, 10, nil)
+	n4 := test.BuildTestNode("n4", 2000, 2000, 10, func(node *v1.Node) {
+		node.Spec.Unschedulable = true
+	})
+	p3 := test.BuildTestPod("p3", 1000, 1000, n3.Name, nil)
+	full := test.BuildTestPod("full", 1500, 1500, n2.Name, nil)
+
+	tests := []struct {
+		name            string
+		pod             *v1.Pod
+		scoringStrategy string
+		objects         []runtime.Object
+		expectedPods    []string
+	}{
+		{
+			name:            "no rescheduling",
+			pod:             test.BuildTestPod("evicted", 200, 200, n1.Name, test.SetRSOwnerRef),
+			scoringStrategy: "",
+			expectedPods:    []string{"p3@n3"},
+		},
+		{
+			name:            "least allocated node",
+			pod:             test.BuildTestPod("evicted", 200, 200, n1.Name, test.SetRSOwnerRef),
+			scoringStrategy: LeastAllocatedScoringStrategy,
+			expectedPods:    []string{"evicted-*@n2", "p3@n3"},
+		},
+		{
+			name:            "most allocated node",
+			pod:             test.BuildTestPod("evicted", 200, 200, n1.Name, test.SetRSOwnerRef),
+			scoringStrategy: MostAllocatedScoringStrategy,
+			expectedPods:    []string{"evicted-*@n3", "p3@n3"},
+		},
+		{
+			name:            "stateful set pods are recreated under the same name",
+			pod:             test.BuildTestPod("evicted", 200, 200, n1.Name, test.SetSSOwnerRef),
+			scoringStrategy: LeastAllocatedScoringStrategy,
+			expectedPods:    []string{"evicted@n2", "p3@n3"},
+		},
+		{
+			name:            "daemon set pods are replaced on their node",
+			pod:             test.BuildTestPod("evicted", 200, 200, n1.Name, test.SetDSOwnerRef),
+			scoringStrategy: LeastAllocatedScoringStrategy,
+			expectedPods:    []string{"evicted-*@n1", "p3@n3"},
+		},
+		{
+			name:            "pods without an owner are not replaced",
+			pod:             test.BuildTestPod("evicted", 200, 200, n1.Name, nil),
+			scoringStrategy: LeastAllocatedScoringStrategy,
+			expectedPods:    []string{"p3@n3"},
+		},
+		{
+			name:            "replacements fitting no node are not created",
+			pod:             test.BuildTestPod("evicted", 1200, 200, n1.Name, test.SetRSOwnerRef),
+			scoringStrategy: MostAllocatedScoringStrategy,
+			objects:         []runtime.Object{full},
+			expectedPods:    []string{"full@n2", "p3@n3"},
+		},
+		{
+			name:            "the capacity freed by the evicted pod is available to the replacement",
+			pod:             test.BuildTestPod("evicted", 1000, 200, n3.Name, test.SetRSOwnerRef),
+			scoringStrategy: MostAllocatedScoringStrategy,
+			objects:         []runtime.Object{full},
+			expectedPods:    []string{"evicted-*@n3", "full@n2", "p3@n3"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			objects := append([]runtime.Object{n1, n2, n3, n4, p3, tc.pod}, tc.objects...)
+			client := fakeclientset.NewSimpleClientset(objects...)
+			sharedInformerFactory := informers.NewSharedInformerFactory(client, 0)
+			podLister := sharedInformerFactory.Core().V1().Pods().Lister()
+			nodeLister := sharedInformerFactory.Core().V1().Nodes().Lister()
+			namespaceLister := sharedInformerFactory.Core().V1().Namespaces().Lister()
+			priorityClassLister := sharedInformerFactory.Scheduling().V1().PriorityClasses().Lister()
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			fakeClient, err := cachedClient(client, podLister, nodeLister, namespaceLister, priorityClassLister, nil, tc.scoringStrategy)
+			if err != nil {
+				t.Fatalf("Unable to build the cached client: %v", err)
+			}
+			eviction := &policy.Eviction{ObjectMeta: metav1.ObjectMeta{Name: tc.pod.Name, Namespace: tc.pod.Namespace}}
+			if err := fakeClient.PolicyV1().Evictions(tc.pod.Namespace).Evict(ctx, eviction); err != nil {
+				t.Fatalf("Unable to evict the pod: %v", err)
+			}
+
+			pods, err := fakeClient.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Unable to list the pods: %v", err)
+			}
+			var placements []string
+			for _, pod := range pods.Items {
+				name := pod.Name
+				if strings.HasPrefix(name, "evicted-") {
+					name = "evicted-*"
+					if pod.Status.Phase != v1.PodRunning || !utils.IsPodReady(&pod) || pod.UID == tc.pod.UID {
+						t.Errorf("Unexpected replacement pod: %+v", pod)
+					}
+				}
+				placements = append(placements, name+"@"+pod.Spec.NodeName)
+			}
+			sort.Strings(placements)
+			if diff := cmp.Diff(tc.expectedPods, placements); diff != "" {
+				t.Errorf("Unexpected pods (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
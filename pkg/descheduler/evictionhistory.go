@@ -0,0 +1,226 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/descheduler/metrics"
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+)
+
+const (
+	// historyDataKey is the key of the eviction history ConfigMap holding the history
+	historyDataKey = "history.yaml"
+	// historySaveAttempts bounds the attempts to save the eviction history while other descheduler instances save theirs
+	historySaveAttempts = 5
+)
+
+// evictionHistory is the history of the evictions the descheduler enforces its budget and cooldown against
+type evictionHistory struct {
+	// Workloads lists the evictions of the pods of every workload within the window of the workload eviction budget
+	Workloads []evictions.WorkloadEvictions `json:"workloads,omitempty"`
+	// Owners lists the last evictions of the pods of every owner from every node within the eviction cooldown
+	Owners []evictions.OwnerEviction `json:"owners,omitempty"`
+}
+
+// historyStore persists the eviction history in a ConfigMap
+type historyStore struct {
+	client    clientset.Interface
+	namespace string
+	name      string
+}
+
+// newHistoryStore creates a store for the ConfigMap referenced as namespace/name
+func newHistoryStore(client clientset.Interface, configMap string) (*historyStore, error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(configMap)
+	if err != nil {
+		return nil, fmt.Errorf("invalid eviction history ConfigMap reference %q: %v", configMap, err)
+	}
+	if namespace == "" || name == "" {
+		return nil, fmt.Errorf("invalid eviction history ConfigMap reference %q: expected namespace/name", configMap)
+	}
+	return &historyStore{client: client, namespace: namespace, name: name}, nil
+}
+
+// load returns the ConfigMap and the history it holds, both nil when the ConfigMap does not exist yet
+func (s *historyStore) load(ctx context.Context) (*v1.ConfigMap, *evictionHistory, error) {
+	configMap, err := s.client.CoreV1().ConfigMaps(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to get the eviction history ConfigMap %v/%v: %v", s.namespace, s.name, err)
+	}
+	data, ok := configMap.Data[historyDataKey]
+	if !ok {
+		return configMap, nil, nil
+	}
+	history := &evictionHistory{}
+	if err := yaml.Unmarshal([]byte(data), history); err != nil {
+		return nil, nil, fmt.Errorf("invalid eviction history ConfigMap %v/%v: %v", s.namespace, s.name, err)
+	}
+	return configMap, history, nil
+}
+
+// save writes the history to the ConfigMap, creating it when nil. Updating the ConfigMap fails with a conflict
+// when it changed since it got loaded, e.g. by another descheduler instance, as does creating it when it got created meanwhile.
+func (s *historyStore) save(ctx context.Context, configMap *v1.ConfigMap, history *evictionHistory) error {
+	data, err := yaml.Marshal(history)
+	if err != nil {
+		return fmt.Errorf("unable to encode the eviction history: %v", err)
+	}
+
+	create := configMap == nil
+	if create {
+		configMap = &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: s.namespace, Name: s.name}}
+	} else {
+		configMap = configMap.DeepCopy()
+	}
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	configMap.Data[historyDataKey] = string(data)
+
+	if create {
+		_, err = s.client.CoreV1().ConfigMaps(s.namespace).Create(ctx, configMap, metav1.CreateOptions{})
+	} else {
+		_, err = s.client.CoreV1().ConfigMaps(s.namespace).Update(ctx, configMap, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("unable to save the eviction history to the ConfigMap %v/%v: %w", s.namespace, s.name, err)
+	}
+	return nil
+}
+
+// workloadEvictionBudget returns the eviction budget of the workloads, nil when the policy sets none.
+// The budget is rebuilt when the policy changes, keeping the evictions recorded so far.
+func (d *descheduler) workloadEvictionBudget() *evictions.WorkloadBudget {
+	return d.workloadBudget.get(d.deschedulerPolicy.WorkloadEvictionBudget, func(policy *api.WorkloadEvictionBudget, previous *evictions.WorkloadBudget) *evictions.WorkloadBudget {
+		if policy == nil {
+			return nil
+		}
+		window := time.Duration(policy.WindowSeconds) * time.Second
+		budget := evictions.NewWorkloadBudget(d.workloadListers, policy.MaxEvictions, window, clock.RealClock{})
+		if previous != nil {
+			budget.Merge(previous.History())
+		}
+		return budget
+	})
+}
+
+// evictionCooldown returns the eviction cooldown of the owners, nil when the policy sets none.
+// The cooldown is rebuilt when the policy changes, keeping the evictions recorded so far.
+func (d *descheduler) evictionCooldown() *evictions.Cooldown {
+	return d.cooldown.get(d.deschedulerPolicy.EvictionCooldown, func(policy *api.EvictionCooldown, previous *evictions.Cooldown) *evictions.Cooldown {
+		if policy == nil {
+			return nil
+		}
+		window := time.Duration(policy.WindowSeconds) * time.Second
+		cooldown := evictions.NewCooldown(window, policy.Scope == api.OwnerCooldownScope, clock.RealClock{})
+		if previous != nil {
+			cooldown.Merge(previous.History())
+		}
+		return cooldown
+	})
+}
+
+// loadEvictionHistory restores the eviction history persisted by the previous descheduler instances once,
+// when the first loop of this instance starts, i.e. at startup or when it becomes the leader. The history is
+// kept in memory from then on, so the next loops do not depend on the ConfigMap for the evictions of this instance.
+// A failed load does not fail the loop: the in-memory history is enforced and the load is retried on the next loop.
+// Nothing is loaded without a history store or without a budget nor cooldown to enforce.
+func (d *descheduler) loadEvictionHistory(ctx context.Context, workloadBudget *evictions.WorkloadBudget, cooldown *evictions.Cooldown) {
+	if d.historyStore == nil || d.historyLoaded || workloadBudget == nil && cooldown == nil {
+		return
+	}
+	_, history, err := d.historyStore.load(ctx)
+	if err != nil {
+		klog.ErrorS(err, "Unable to load the eviction history, retrying on the next loop", "configMap", klog.KRef(d.historyStore.namespace, d.historyStore.name))
+		metrics.EvictionHistoryErrors.With(map[string]string{"operation": "load"}).Inc()
+		return
+	}
+	if history != nil {
+		mergeEvictionHistory(history, workloadBudget, cooldown)
+	}
+	d.historyLoaded = true
+}
+
+// saveEvictionHistory persists the eviction history for the next descheduler instances. The history persisted
+// meanwhile by other instances is merged in first, so no eviction gets lost, and the save is retried when
+// another instance saved in between. A failed save does not fail the loop: the history is kept in memory
+// and saved again after the next loop.
+func (d *descheduler) saveEvictionHistory(ctx context.Context, workloadBudget *evictions.WorkloadBudget, cooldown *evictions.Cooldown) {
+	if d.historyStore == nil || workloadBudget == nil && cooldown == nil {
+		return
+	}
+	var err error
+	for attempt := 1; attempt <= historySaveAttempts; attempt++ {
+		var history *evictionHistory
+		if history, err = d.mergeSaveEvictionHistory(ctx, workloadBudget, cooldown); err == nil {
+			klog.V(3).InfoS("Saved the eviction history", "configMap", klog.KRef(d.historyStore.namespace, d.historyStore.name), "workloads", len(history.Workloads), "owners", len(history.Owners))
+			return
+		}
+		if !apierrors.IsConflict(err) && !apierrors.IsAlreadyExists(err) {
+			break
+		}
+		klog.V(2).InfoS("The eviction history got saved concurrently, merging it", "configMap", klog.KRef(d.historyStore.namespace, d.historyStore.name), "attempt", attempt)
+	}
+	klog.ErrorS(err, "Unable to save the eviction history, retrying after the next loop", "configMap", klog.KRef(d.historyStore.namespace, d.historyStore.name))
+	metrics.EvictionHistoryErrors.With(map[string]string{"operation": "save"}).Inc()
+}
+
+// mergeSaveEvictionHistory merges the persisted history into the budget and the cooldown and saves the result
+func (d *descheduler) mergeSaveEvictionHistory(ctx context.Context, workloadBudget *evictions.WorkloadBudget, cooldown *evictions.Cooldown) (*evictionHistory, error) {
+	configMap, persisted, err := d.historyStore.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if persisted != nil {
+		mergeEvictionHistory(persisted, workloadBudget, cooldown)
+	}
+	history := &evictionHistory{}
+	if workloadBudget != nil {
+		history.Workloads = workloadBudget.History()
+	}
+	if cooldown != nil {
+		history.Owners = cooldown.History()
+	}
+	return history, d.historyStore.save(ctx, configMap, history)
+}
+
+// mergeEvictionHistory merges the history into the budget and the cooldown, when set
+func mergeEvictionHistory(history *evictionHistory, workloadBudget *evictions.WorkloadBudget, cooldown *evictions.Cooldown) {
+	if workloadBudget != nil {
+		workloadBudget.Merge(history.Workloads)
+	}
+	if cooldown != nil {
+		cooldown.Merge(history.Owners)
+	}
+}
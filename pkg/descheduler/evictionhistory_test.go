@@ -0,0 +1,189 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+)
+
+func TestEvictionHistory(t *testing.T) {
+	ctx := context.Background()
+	client := fakeclientset.NewSimpleClientset()
+	newTestDescheduler := func() *descheduler {
+		store, err := newHistoryStore(client, "kube-system/descheduler-history")
+		if err != nil {
+			t.Fatalf("Unable to create the history store: %v", err)
+		}
+		return &descheduler{
+			rs: &options.DeschedulerServer{Client: client},
+			deschedulerPolicy: &api.DeschedulerPolicy{
+				WorkloadEvictionBudget: &api.WorkloadEvictionBudget{MaxEvictions: intstr.FromInt(1), WindowSeconds: 1800},
+				EvictionCooldown:       &api.EvictionCooldown{WindowSeconds: 3600},
+			},
+			historyStore: store,
+		}
+	}
+	// the times are persisted with a second precision
+	evictedAt := metav1.NewTime(time.Now().Add(-time.Minute).Truncate(time.Second))
+	history := []evictions.WorkloadEvictions{{
+		Workload: evictions.Workload{Kind: "Deployment", Namespace: "default", Name: "web"},
+		Times:    []metav1.Time{evictedAt},
+	}}
+	ownerHistory := []evictions.OwnerEviction{{
+		Workload: evictions.Workload{Kind: "DaemonSet", Namespace: "default", Name: "agent"},
+		Node:     "node1",
+		Time:     evictedAt,
+	}}
+
+	previous := newTestDescheduler()
+	budget, cooldown := previous.workloadEvictionBudget(), previous.evictionCooldown()
+	previous.loadEvictionHistory(ctx, budget, cooldown)
+	if history := budget.History(); len(history) != 0 {
+		t.Fatalf("Expected no history before the first loop, got %v", history)
+	}
+	budget.Merge(history)
+	cooldown.Merge(ownerHistory)
+	previous.saveEvictionHistory(ctx, budget, cooldown)
+
+	// the history survives a restart
+	restarted := newTestDescheduler()
+	budget, cooldown = restarted.workloadEvictionBudget(), restarted.evictionCooldown()
+	restarted.loadEvictionHistory(ctx, budget, cooldown)
+	if diff := cmp.Diff(history, budget.History()); diff != "" {
+		t.Errorf("Unexpected restored history (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(ownerHistory, cooldown.History()); diff != "" {
+		t.Errorf("Unexpected restored cooldown history (-want +got):\n%s", diff)
+	}
+
+	// the history saved meanwhile by another instance is merged in when saving, not when loading again
+	otherHistory := []evictions.WorkloadEvictions{{
+		Workload: evictions.Workload{Kind: "StatefulSet", Namespace: "default", Name: "db"},
+		Times:    []metav1.Time{evictedAt},
+	}}
+	other := newTestDescheduler()
+	otherBudget, otherCooldown := other.workloadEvictionBudget(), other.evictionCooldown()
+	otherBudget.Merge(otherHistory)
+	other.saveEvictionHistory(ctx, otherBudget, otherCooldown)
+	restarted.loadEvictionHistory(ctx, budget, cooldown)
+	if diff := cmp.Diff(history, budget.History()); diff != "" {
+		t.Errorf("Unexpected history loaded again (-want +got):\n%s", diff)
+	}
+	// a concurrent save is retried
+	conflicts := 0
+	client.PrependReactor("update", "configmaps", func(action core.Action) (bool, runtime.Object, error) {
+		if conflicts++; conflicts == 1 {
+			return true, nil, apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "descheduler-history", fmt.Errorf("changed"))
+		}
+		return false, nil, nil
+	})
+	restarted.saveEvictionHistory(ctx, budget, cooldown)
+	merged := append(append([]evictions.WorkloadEvictions{}, history...), otherHistory...)
+	if diff := cmp.Diff(merged, budget.History()); diff != "" {
+		t.Errorf("Unexpected merged history (-want +got):\n%s", diff)
+	}
+	if _, saved, err := restarted.historyStore.load(ctx); err != nil {
+		t.Fatalf("Unable to load the saved history: %v", err)
+	} else if diff := cmp.Diff(merged, saved.Workloads); diff != "" {
+		t.Errorf("Unexpected saved history (-want +got):\n%s", diff)
+	}
+
+	// a failed save keeps the history in memory and is retried after the next loop
+	failing := true
+	client.PrependReactor("update", "configmaps", func(action core.Action) (bool, runtime.Object, error) {
+		if failing {
+			return true, nil, apierrors.NewInternalError(fmt.Errorf("etcd unavailable"))
+		}
+		return false, nil, nil
+	})
+	laterAt := metav1.NewTime(evictedAt.Add(time.Second))
+	budget.Merge([]evictions.WorkloadEvictions{{Workload: history[0].Workload, Times: []metav1.Time{laterAt}}})
+	merged[0].Times = append(merged[0].Times, laterAt)
+	restarted.saveEvictionHistory(ctx, budget, cooldown)
+	if diff := cmp.Diff(merged, budget.History()); diff != "" {
+		t.Errorf("Unexpected history kept in memory (-want +got):\n%s", diff)
+	}
+	failing = false
+	restarted.saveEvictionHistory(ctx, budget, cooldown)
+	if _, saved, err := restarted.historyStore.load(ctx); err != nil {
+		t.Fatalf("Unable to load the saved history: %v", err)
+	} else if diff := cmp.Diff(merged, saved.Workloads); diff != "" {
+		t.Errorf("Unexpected history saved after the failure (-want +got):\n%s", diff)
+	}
+
+	// a failed load enforces the in-memory history and is retried on the next loop
+	failing = true
+	client.PrependReactor("get", "configmaps", func(action core.Action) (bool, runtime.Object, error) {
+		if failing {
+			return true, nil, apierrors.NewServiceUnavailable("apiserver unavailable")
+		}
+		return false, nil, nil
+	})
+	reloaded := newTestDescheduler()
+	reloadedBudget, reloadedCooldown := reloaded.workloadEvictionBudget(), reloaded.evictionCooldown()
+	reloaded.loadEvictionHistory(ctx, reloadedBudget, reloadedCooldown)
+	if reloaded.historyLoaded {
+		t.Errorf("Expected the failed load to be retried")
+	}
+	failing = false
+	reloaded.loadEvictionHistory(ctx, reloadedBudget, reloadedCooldown)
+	if !reloaded.historyLoaded {
+		t.Errorf("Expected the history to be loaded on the next loop")
+	} else if diff := cmp.Diff(merged, reloadedBudget.History()); diff != "" {
+		t.Errorf("Unexpected history loaded on the next loop (-want +got):\n%s", diff)
+	}
+
+	// and a change of the policy
+	restarted.deschedulerPolicy.WorkloadEvictionBudget.MaxEvictions = intstr.FromString("10%")
+	if rebuilt := restarted.workloadEvictionBudget(); rebuilt == budget {
+		t.Errorf("Expected the budget to be rebuilt for the new policy")
+	} else if diff := cmp.Diff(merged, rebuilt.History()); diff != "" {
+		t.Errorf("Unexpected history of the rebuilt budget (-want +got):\n%s", diff)
+	}
+
+	restarted.deschedulerPolicy.EvictionCooldown.Scope = api.OwnerCooldownScope
+	if rebuilt := restarted.evictionCooldown(); rebuilt == cooldown {
+		t.Errorf("Expected the cooldown to be rebuilt for the new policy")
+	} else if diff := cmp.Diff(ownerHistory, rebuilt.History()); diff != "" {
+		t.Errorf("Unexpected history of the rebuilt cooldown (-want +got):\n%s", diff)
+	}
+
+	restarted.deschedulerPolicy.WorkloadEvictionBudget = nil
+	restarted.deschedulerPolicy.EvictionCooldown = nil
+	if budget := restarted.workloadEvictionBudget(); budget != nil {
+		t.Errorf("Expected no budget without a policy, got %v", budget)
+	}
+	if cooldown := restarted.evictionCooldown(); cooldown != nil {
+		t.Errorf("Expected no cooldown without a policy, got %v", cooldown)
+	}
+}
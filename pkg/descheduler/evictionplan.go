@@ -0,0 +1,275 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/descheduler/plan"
+	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
+	frameworkprofile "sigs.k8s.io/descheduler/pkg/framework/profile"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+// planStore persists the eviction plan in a ConfigMap, approved by annotating it
+type planStore struct {
+	client    clientset.Interface
+	namespace string
+	name      string
+}
+
+// newPlanStore creates a store for the ConfigMap referenced as namespace/name
+func newPlanStore(client clientset.Interface, configMap string) (*planStore, error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(configMap)
+	if err != nil {
+		return nil, fmt.Errorf("invalid plan ConfigMap reference %q: %v", configMap, err)
+	}
+	if namespace == "" || name == "" {
+		return nil, fmt.Errorf("invalid plan ConfigMap reference %q: expected namespace/name", configMap)
+	}
+	return &planStore{client: client, namespace: namespace, name: name}, nil
+}
+
+// load returns the ConfigMap and the plan it holds, both nil when the ConfigMap does not exist yet
+func (s *planStore) load(ctx context.Context) (*v1.ConfigMap, *plan.Plan, error) {
+	configMap, err := s.client.CoreV1().ConfigMaps(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to get the plan ConfigMap %v/%v: %v", s.namespace, s.name, err)
+	}
+	data, ok := configMap.Data[plan.DataKey]
+	if !ok {
+		return configMap, nil, nil
+	}
+	p, err := plan.Decode([]byte(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid plan ConfigMap %v/%v: %v", s.namespace, s.name, err)
+	}
+	return configMap, p, nil
+}
+
+// save writes the plan to the ConfigMap, creating it when nil. A new plan clears the approval
+// and an applied plan is marked as such. Updating the ConfigMap fails when it changed since it got loaded,
+// so a plan edited or approved in the meantime is not overwritten.
+func (s *planStore) save(ctx context.Context, configMap *v1.ConfigMap, p *plan.Plan, applied bool) error {
+	data, err := plan.Encode(p)
+	if err != nil {
+		return fmt.Errorf("unable to encode the eviction plan: %v", err)
+	}
+
+	create := configMap == nil
+	if create {
+		configMap = &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: s.namespace, Name: s.name}}
+	} else {
+		configMap = configMap.DeepCopy()
+	}
+	if configMap.Annotations == nil {
+		configMap.Annotations = map[string]string{}
+	}
+	if applied {
+		configMap.Annotations[plan.AppliedAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	} else {
+		delete(configMap.Annotations, plan.ApprovedAnnotation)
+		delete(configMap.Annotations, plan.AppliedAnnotation)
+	}
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	configMap.Data[plan.DataKey] = string(data)
+
+	if create {
+		_, err = s.client.CoreV1().ConfigMaps(s.namespace).Create(ctx, configMap, metav1.CreateOptions{})
+	} else {
+		_, err = s.client.CoreV1().ConfigMaps(s.namespace).Update(ctx, configMap, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("unable to save the eviction plan to the ConfigMap %v/%v: %v", s.namespace, s.name, err)
+	}
+	return nil
+}
+
+// pendingPlan tells whether the ConfigMap holds a plan not applied yet.
+// A plan without evictions is never pending so the next loop replaces it.
+func pendingPlan(configMap *v1.ConfigMap, p *plan.Plan) bool {
+	if p == nil || len(p.Evictions) == 0 {
+		return false
+	}
+	_, applied := configMap.Annotations[plan.AppliedAnnotation]
+	return !applied
+}
+
+// expiredPlan tells whether the plan got produced longer than the expiration ago, zero meaning it never expires
+func expiredPlan(p *plan.Plan, expiration time.Duration) bool {
+	return expiration > 0 && time.Since(p.GeneratedAt.Time) > expiration
+}
+
+// approvedPlan tells whether the plan of the ConfigMap got approved
+func approvedPlan(configMap *v1.ConfigMap) bool {
+	return configMap.Annotations[plan.ApprovedAnnotation] == "true"
+}
+
+// newPlan builds the plan of the evictions performed in the dry run mode
+func newPlan(podEvictions []evictions.Eviction) *plan.Plan {
+	p := &plan.Plan{GeneratedAt: metav1.Now(), Evictions: []plan.Eviction{}}
+	for _, eviction := range podEvictions {
+		requests, _ := utils.PodRequestsAndLimits(eviction.Pod)
+		p.Evictions = append(p.Evictions, plan.Eviction{
+			Namespace:          eviction.Pod.Namespace,
+			Name:               eviction.Pod.Name,
+			UID:                eviction.Pod.UID,
+			Node:               eviction.Pod.Spec.NodeName,
+			Profile:            eviction.Opts.ProfileName,
+			Plugin:             eviction.Opts.PluginName,
+			ExtensionPoint:     eviction.Opts.ExtensionPoint,
+			Reason:             eviction.Opts.Reason,
+			GracePeriodSeconds: eviction.Opts.GracePeriodSeconds,
+			Requests:           requests,
+		})
+	}
+	return p
+}
+
+// applyPlan evicts the pods of the plan in order and saves the plan along with the outcome of every eviction.
+// The pods that changed since the plan got produced, or the profile of their eviction now rejects, are skipped.
+func (d *descheduler) applyPlan(ctx context.Context, nodes []*v1.Node, configMap *v1.ConfigMap, p *plan.Plan) (*loopSummary, error) {
+	klog.V(1).InfoS("Applying the eviction plan", "configMap", klog.KObj(configMap), "evictions", len(p.Evictions))
+	podEvictor := evictions.NewPodEvictor(
+		d.rs.Client,
+		d.evictionPolicyGroupVersion,
+		false,
+		d.deschedulerPolicy.MaxNoOfPodsToEvictPerNode,
+		d.deschedulerPolicy.MaxNoOfPodsToEvictPerNamespace,
+		nodes,
+		!d.rs.DisableMetrics,
+		d.eventRecorder,
+	)
+	podEvictor.SetMaxPodsToEvictTotal(d.deschedulerPolicy.MaxNoOfPodsToEvictTotal)
+	podEvictor.SetRateLimiter(d.rateLimiter())
+	podEvictor.SetDeleteFallback(deleteFallback(d.deschedulerPolicy))
+	d.watchWorkloads(ctx)
+	workloadBudget, cooldown := d.workloadEvictionBudget(), d.evictionCooldown()
+	d.loadEvictionHistory(ctx, workloadBudget, cooldown)
+	podEvictor.SetWorkloadBudget(workloadBudget)
+	podEvictor.SetCooldown(cooldown)
+
+	// The evictions go through the profiles they got planned by, as any eviction of their plugins does
+	profiles := map[string]planProfile{}
+	for _, profile := range d.deschedulerPolicy.Profiles {
+		currProfile, err := frameworkprofile.NewProfile(
+			profile,
+			pluginregistry.PluginRegistry,
+			frameworkprofile.WithClientSet(d.rs.Client),
+			frameworkprofile.WithSharedInformerFactory(d.sharedInformerFactory),
+			frameworkprofile.WithPodEvictor(podEvictor),
+			frameworkprofile.WithGetPodsAssignedToNodeFnc(d.getPodsAssignedToNode),
+			frameworkprofile.WithParallelism(d.parallelism()),
+			frameworkprofile.WithContext(ctx),
+		)
+		if err != nil {
+			klog.ErrorS(err, "unable to create a profile", "profile", profile.Name)
+			continue
+		}
+		profiles[profile.Name] = currProfile
+	}
+
+	for i := range p.Evictions {
+		eviction := &p.Evictions[i]
+		pod, reason := revalidatePlannedEviction(ctx, d.rs.Client, eviction)
+		if pod == nil {
+			klog.V(1).InfoS("Skipping the planned eviction", "pod", klog.KRef(eviction.Namespace, eviction.Name), "reason", reason)
+			eviction.Result, eviction.Message = plan.Skipped, reason
+			continue
+		}
+		profile, ok := profiles[eviction.Profile]
+		if !ok {
+			reason := fmt.Sprintf("the profile %q is no longer configured", eviction.Profile)
+			klog.V(1).InfoS("Skipping the planned eviction", "pod", klog.KObj(pod), "reason", reason)
+			eviction.Result, eviction.Message = plan.Skipped, reason
+			continue
+		}
+		status, err := profile.EvictPlanned(ctx, pod, evictions.EvictOptions{
+			Reason:             eviction.Reason,
+			ProfileName:        eviction.Profile,
+			PluginName:         eviction.Plugin,
+			ExtensionPoint:     eviction.ExtensionPoint,
+			GracePeriodSeconds: eviction.GracePeriodSeconds,
+		})
+		switch {
+		case !status.IsSuccess():
+			klog.V(1).InfoS("Skipping the planned eviction", "pod", klog.KObj(pod), "reason", status.GetReason())
+			eviction.Result, eviction.Message = plan.Skipped, status.GetReason()
+		case err != nil:
+			eviction.Result, eviction.Message = plan.Failed, err.Error()
+		default:
+			eviction.Result, eviction.Message = plan.Evicted, ""
+		}
+	}
+
+	d.saveEvictionHistory(ctx, workloadBudget, cooldown)
+	if err := d.planStore.save(ctx, configMap, p, true); err != nil {
+		return nil, fmt.Errorf("%w: %v", errLoopFailed, err)
+	}
+
+	summary := newLoopSummary()
+	summary.podsEvicted = podEvictor.TotalEvicted()
+	summary.evictions = podEvictor.Evictions()
+	klog.V(1).InfoS("Number of evicted pods", "totalEvicted", podEvictor.TotalEvicted())
+	if d.statusReporter != nil {
+		d.statusReporter.reportLoop(ctx, d.deschedulerPolicy, podEvictor.ProfilePluginEvicted())
+	}
+	return summary, nil
+}
+
+// planProfile is the profile a planned eviction goes through
+type planProfile interface {
+	EvictPlanned(ctx context.Context, pod *v1.Pod, opts evictions.EvictOptions) (*frameworktypes.Status, error)
+}
+
+// revalidatePlannedEviction returns the current state of the pod of a planned eviction,
+// or nil along with the reason when the pod is gone or changed since the plan got produced
+func revalidatePlannedEviction(ctx context.Context, client clientset.Interface, eviction *plan.Eviction) (*v1.Pod, string) {
+	pod, err := client.CoreV1().Pods(eviction.Namespace).Get(ctx, eviction.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, "the pod no longer exists"
+	}
+	if err != nil {
+		return nil, fmt.Sprintf("unable to get the pod: %v", err)
+	}
+	if pod.UID != eviction.UID {
+		return nil, "the pod got replaced by another one of the same name"
+	}
+	if pod.Spec.NodeName != eviction.Node {
+		return nil, fmt.Sprintf("the pod moved from node %q to node %q", eviction.Node, pod.Spec.NodeName)
+	}
+	if utils.IsPodTerminating(pod) {
+		return nil, "the pod is terminating"
+	}
+	return pod, ""
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/events"
+
+	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	"sigs.k8s.io/descheduler/pkg/descheduler/plan"
+	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatingnodetaints"
+)
+
+func TestEvictionPlan(t *testing.T) {
+	SetupPlugins()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objects, err := decodeSnapshot([]byte(simulateTestSnapshot))
+	if err != nil {
+		t.Fatalf("Unable to decode the snapshot: %v", err)
+	}
+	intolerant := objects[3].(*v1.Pod).DeepCopy()
+	intolerant.UID = "intolerant-uid"
+	replaced := intolerant.DeepCopy()
+	replaced.Name, replaced.UID = "replaced", "replaced-uid"
+	orphaned := intolerant.DeepCopy()
+	orphaned.Name, orphaned.UID = "orphaned", "orphaned-uid"
+	objects[3] = intolerant
+	client := fakeclientset.NewSimpleClientset(append(objects, replaced, orphaned)...)
+	var evictedPods []string
+	client.PrependReactor("create", "pods", podEvictionReactionFuc(&evictedPods))
+
+	dir := t.TempDir()
+	policyFile := filepath.Join(dir, "policy.yaml")
+	if err := os.WriteFile(policyFile, []byte(reloaderTestPolicyFor(removepodsviolatingnodetaints.PluginName)), 0o600); err != nil {
+		t.Fatalf("Unable to write the policy: %v", err)
+	}
+	deschedulerPolicy, err := LoadPolicyConfig(policyFile, client, pluginregistry.PluginRegistry)
+	if err != nil {
+		t.Fatalf("Unable to load the policy: %v", err)
+	}
+
+	rs, err := options.NewDeschedulerServer()
+	if err != nil {
+		t.Fatalf("Unable to initialize server: %v", err)
+	}
+	rs.Client = client
+	rs.DisableMetrics = true
+	rs.PlanConfigMap = "kube-system/descheduler-plan"
+
+	sharedInformerFactory := informers.NewSharedInformerFactory(client, 0)
+	nodeLister := sharedInformerFactory.Core().V1().Nodes().Lister()
+	descheduler, err := newDescheduler(ctx, rs, deschedulerPolicy, policy.SchemeGroupVersion.String(), &events.FakeRecorder{}, sharedInformerFactory)
+	if err != nil {
+		t.Fatalf("Unable to create a descheduler instance: %v", err)
+	}
+	descheduler.planStore, err = newPlanStore(client, rs.PlanConfigMap)
+	if err != nil {
+		t.Fatalf("Unable to create the plan store: %v", err)
+	}
+	sharedInformerFactory.Start(ctx.Done())
+	sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+	nodes, err := nodeutil.ReadyNodes(ctx, client, nodeLister, "")
+	if err != nil {
+		t.Fatalf("Unable to list the nodes: %v", err)
+	}
+	runLoop := func() {
+		if _, err := descheduler.runDeschedulerLoop(ctx, nodes); err != nil {
+			t.Fatalf("Unable to run the descheduling loop: %v", err)
+		}
+	}
+	loadPlan := func() (*v1.ConfigMap, *plan.Plan) {
+		configMap, p, err := descheduler.planStore.load(ctx)
+		if err != nil {
+			t.Fatalf("Unable to load the plan: %v", err)
+		}
+		if p == nil {
+			t.Fatalf("Expected the plan ConfigMap to hold a plan")
+		}
+		return configMap, p
+	}
+	results := func(p *plan.Plan) map[string]plan.Result {
+		got := map[string]plan.Result{}
+		for _, eviction := range p.Evictions {
+			got[eviction.Name] = eviction.Result
+		}
+		return got
+	}
+
+	// the first loop plans the evictions without evicting
+	runLoop()
+	configMap, p := loadPlan()
+	if len(evictedPods) != 0 {
+		t.Errorf("Expected no pods to be evicted while planning, got %v", evictedPods)
+	}
+	expected := map[string]plan.Result{"intolerant": "", "replaced": "", "orphaned": ""}
+	if diff := cmp.Diff(expected, results(p)); diff != "" {
+		t.Errorf("Unexpected planned evictions (-want +got):\n%s", diff)
+	}
+	generatedAt := p.GeneratedAt
+
+	// the plan waits for its approval and is not replaced meanwhile
+	runLoop()
+	if _, p = loadPlan(); !p.GeneratedAt.Equal(&generatedAt) || len(evictedPods) != 0 {
+		t.Errorf("Expected the pending plan to be kept and no pods to be evicted, got %v evictions", evictedPods)
+	}
+
+	// a pod recreated under the same name since the plan got produced is skipped
+	if err := client.CoreV1().Pods(replaced.Namespace).Delete(ctx, replaced.Name, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Unable to delete the pod: %v", err)
+	}
+	recreated := replaced.DeepCopy()
+	recreated.UID = types.UID("recreated-uid")
+	if _, err := client.CoreV1().Pods(recreated.Namespace).Create(ctx, recreated, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Unable to recreate the pod: %v", err)
+	}
+	// a pod the profile rejects since the plan got produced is skipped as well
+	orphaned.OwnerReferences = nil
+	if _, err := client.CoreV1().Pods(orphaned.Namespace).Update(ctx, orphaned, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Unable to update the pod: %v", err)
+	}
+	configMap.Annotations = map[string]string{plan.ApprovedAnnotation: "true"}
+	if _, err := client.CoreV1().ConfigMaps(configMap.Namespace).Update(ctx, configMap, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Unable to approve the plan: %v", err)
+	}
+
+	runLoop()
+	configMap, p = loadPlan()
+	if diff := cmp.Diff([]string{"intolerant"}, evictedPods); diff != "" {
+		t.Errorf("Unexpected evicted pods (-want +got):\n%s", diff)
+	}
+	expected = map[string]plan.Result{"intolerant": plan.Evicted, "replaced": plan.Skipped, "orphaned": plan.Skipped}
+	if diff := cmp.Diff(expected, results(p)); diff != "" {
+		t.Errorf("Unexpected eviction results (-want +got):\n%s", diff)
+	}
+	for _, eviction := range p.Evictions {
+		if eviction.Name == "orphaned" && !strings.Contains(eviction.Message, defaultevictor.PluginName) {
+			t.Errorf("Expected the eviction of the orphaned pod to be skipped by %v, got %q", defaultevictor.PluginName, eviction.Message)
+		}
+	}
+	if _, ok := configMap.Annotations[plan.AppliedAnnotation]; !ok {
+		t.Errorf("Expected the plan to be marked as applied")
+	}
+
+	// the next loop replaces the applied plan and clears its approval
+	runLoop()
+	configMap, p = loadPlan()
+	if _, ok := configMap.Annotations[plan.ApprovedAnnotation]; ok || !pendingPlan(configMap, p) {
+		t.Errorf("Expected a new plan waiting for approval, got the annotations %v", configMap.Annotations)
+	}
+	if len(evictedPods) != 1 {
+		t.Errorf("Expected no more pods to be evicted while planning, got %v", evictedPods)
+	}
+
+	// an expired plan is replaced instead of applied, even when approved
+	configMap.Annotations[plan.ApprovedAnnotation] = "true"
+	if _, err := client.CoreV1().ConfigMaps(configMap.Namespace).Update(ctx, configMap, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Unable to approve the plan: %v", err)
+	}
+	rs.PlanExpiration = time.Nanosecond
+	runLoop()
+	configMap, p = loadPlan()
+	if _, ok := configMap.Annotations[plan.ApprovedAnnotation]; ok || !pendingPlan(configMap, p) {
+		t.Errorf("Expected the expired plan to be replaced by a new one waiting for approval, got the annotations %v", configMap.Annotations)
+	}
+	if len(evictedPods) != 1 {
+		t.Errorf("Expected the expired plan not to be applied, got %v evictions", evictedPods)
+	}
+
+	// a plan ConfigMap which can not be read fails the loop only
+	client.PrependReactor("get", "configmaps", func(action core.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewServiceUnavailable("apiserver unavailable")
+	})
+	if _, err := descheduler.runDeschedulerLoop(ctx, nodes); !errors.Is(err, errLoopFailed) {
+		t.Errorf("Expected the loop to fail on its own, got %v", err)
+	}
+}
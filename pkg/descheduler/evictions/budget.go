@@ -0,0 +1,285 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/utils/clock"
+)
+
+var errWorkloadBudgetExhausted = errors.New("eviction budget of the workload exhausted")
+
+// Workload identifies the workload owning a pod
+type Workload struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+func (w Workload) String() string {
+	return w.Kind + "/" + w.Namespace + "/" + w.Name
+}
+
+// PodWorkload returns the workload owning a pod through its controller reference. The pods of a ReplicaSet
+// managed by a Deployment belong to the Deployment, which names its ReplicaSets after the pod template hash
+// it labels their pods with. Returns false when the pod is not owned by a Deployment, a StatefulSet or a ReplicaSet.
+func PodWorkload(pod *v1.Pod) (Workload, bool) {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return Workload{}, false
+	}
+	switch owner.Kind {
+	case "ReplicaSet":
+		if hash := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]; hash != "" && strings.HasSuffix(owner.Name, "-"+hash) {
+			return Workload{Kind: "Deployment", Namespace: pod.Namespace, Name: strings.TrimSuffix(owner.Name, "-"+hash)}, true
+		}
+		return Workload{Kind: owner.Kind, Namespace: pod.Namespace, Name: owner.Name}, true
+	case "StatefulSet":
+		return Workload{Kind: owner.Kind, Namespace: pod.Namespace, Name: owner.Name}, true
+	}
+	return Workload{}, false
+}
+
+// WorkloadListers list the workloads the budgets relative to their replicas are resolved against
+type WorkloadListers struct {
+	Deployments  appsv1listers.DeploymentLister
+	StatefulSets appsv1listers.StatefulSetLister
+	ReplicaSets  appsv1listers.ReplicaSetLister
+}
+
+// WorkloadEvictions records the times the pods of a workload got evicted at, oldest first
+type WorkloadEvictions struct {
+	Workload `json:",inline"`
+	Times    []metav1.Time `json:"times"`
+}
+
+// WorkloadBudget caps the number of pods of every workload evicted within a sliding window.
+// Once a workload exhausted its budget, the evictions of its pods are rejected until its oldest eviction
+// leaves the window. History and Merge carry the evictions recorded over a restart.
+// It is safe for concurrent use by multiple goroutines.
+type WorkloadBudget struct {
+	// listers read the replicas of the workloads, for the budgets relative to them
+	listers      WorkloadListers
+	maxEvictions intstr.IntOrString
+	window       time.Duration
+	clock        clock.PassiveClock
+
+	// lock guards the evictions
+	lock sync.Mutex
+	// evictions lists the times the pods of every workload got evicted at within the window, oldest first.
+	// The times are truncated to the second they get persisted with.
+	evictions map[Workload][]time.Time
+}
+
+// NewWorkloadBudget creates a budget allowing maxEvictions evictions per workload within the window.
+// A percentage is relative to the replicas of the workload, read through the listers, and rounded up.
+func NewWorkloadBudget(listers WorkloadListers, maxEvictions intstr.IntOrString, window time.Duration, clock clock.PassiveClock) *WorkloadBudget {
+	return &WorkloadBudget{
+		listers:      listers,
+		maxEvictions: maxEvictions,
+		window:       window,
+		clock:        clock,
+		evictions:    map[Workload][]time.Time{},
+	}
+}
+
+// Copy returns a budget with the same evictions recorded, which can be consumed without affecting this one,
+// e.g. by the evictions of the dry run mode
+func (b *WorkloadBudget) Copy() *WorkloadBudget {
+	if b == nil {
+		return nil
+	}
+	budget := NewWorkloadBudget(b.listers, b.maxEvictions, b.window, b.clock)
+	budget.Merge(b.History())
+	return budget
+}
+
+// History returns the evictions recorded within the window, sorted by workload
+func (b *WorkloadBudget) History() []WorkloadEvictions {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	now := b.clock.Now()
+	history := []WorkloadEvictions{}
+	for workload := range b.evictions {
+		times := b.prune(workload, now)
+		if len(times) == 0 {
+			continue
+		}
+		evictions := WorkloadEvictions{Workload: workload, Times: make([]metav1.Time, 0, len(times))}
+		for _, t := range times {
+			evictions.Times = append(evictions.Times, metav1.NewTime(t))
+		}
+		history = append(history, evictions)
+	}
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].Workload.String() < history[j].Workload.String()
+	})
+	return history
+}
+
+// Merge adds the evictions of the given history missing from the ones recorded, e.g. persisted by a previous
+// or another descheduler instance. The evictions of a workload at the same second are counted once per
+// history, so merging a history twice or merging back the own history records nothing new.
+func (b *WorkloadBudget) Merge(history []WorkloadEvictions) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for _, evictions := range history {
+		counts := map[int64]int{}
+		for _, t := range b.evictions[evictions.Workload] {
+			counts[t.Unix()]++
+		}
+		times := b.evictions[evictions.Workload]
+		seen := map[int64]int{}
+		for _, t := range evictions.Times {
+			second := t.Unix()
+			if seen[second]++; seen[second] > counts[second] {
+				times = append(times, time.Unix(second, 0))
+			}
+		}
+		sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+		b.evictions[evictions.Workload] = times
+	}
+}
+
+// reserve counts an eviction of a pod of the workload against its budget, unless the budget is exhausted.
+// Returns the time the eviction is recorded at, to release it with.
+func (b *WorkloadBudget) reserve(workload Workload) (time.Time, error) {
+	limit, err := b.limit(workload)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	now := b.clock.Now()
+	times := b.prune(workload, now)
+	if len(times) >= limit {
+		return time.Time{}, b.exhaustedError(workload, len(times))
+	}
+	reserved := time.Unix(now.Unix(), 0)
+	b.evictions[workload] = append(times, reserved)
+	return reserved, nil
+}
+
+// Check returns why the budget of the workload of the pod keeps it from being evicted now, nil when the budget
+// allows it or the pod is not subject to the budget. Nothing is counted, so the budget can be checked without evicting the pod.
+func (b *WorkloadBudget) Check(pod *v1.Pod) error {
+	workload, ok := PodWorkload(pod)
+	if !ok {
+		return nil
+	}
+	limit, err := b.limit(workload)
+	if err != nil {
+		return err
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if times := b.prune(workload, b.clock.Now()); len(times) >= limit {
+		return b.exhaustedError(workload, len(times))
+	}
+	return nil
+}
+
+// exhaustedError tells the number of pods of the workload evicted within the window exhausted its budget
+func (b *WorkloadBudget) exhaustedError(workload Workload, evicted int) error {
+	return fmt.Errorf("%w: %d pods of %v evicted within %v, at most %v allowed", errWorkloadBudgetExhausted, evicted, workload, b.window, b.maxEvictions.String())
+}
+
+// release releases the eviction of the workload reserved at the given time through reserve
+func (b *WorkloadBudget) release(workload Workload, reserved time.Time) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	times := b.evictions[workload]
+	for i := len(times) - 1; i >= 0; i-- {
+		if times[i].Equal(reserved) {
+			b.evictions[workload] = append(times[:i:i], times[i+1:]...)
+			return
+		}
+	}
+}
+
+// prune forgets the evictions of the workload older than the window and returns the remaining ones.
+// The lock must be held.
+func (b *WorkloadBudget) prune(workload Workload, now time.Time) []time.Time {
+	times := b.evictions[workload]
+	i := 0
+	for i < len(times) && !times[i].After(now.Add(-b.window)) {
+		i++
+	}
+	if i == len(times) {
+		delete(b.evictions, workload)
+		return nil
+	}
+	b.evictions[workload] = times[i:]
+	return times[i:]
+}
+
+// limit returns the number of pods of the workload which can be evicted within the window
+func (b *WorkloadBudget) limit(workload Workload) (int, error) {
+	if b.maxEvictions.Type == intstr.Int {
+		return b.maxEvictions.IntValue(), nil
+	}
+	replicas, err := b.replicas(workload)
+	if err != nil {
+		return 0, fmt.Errorf("unable to get the replicas of %v: %w", workload, err)
+	}
+	return intstr.GetScaledValueFromIntOrPercent(&b.maxEvictions, int(replicas), true)
+}
+
+// replicas returns the desired number of replicas of the workload
+func (b *WorkloadBudget) replicas(workload Workload) (int32, error) {
+	var replicas *int32
+	switch {
+	case workload.Kind == "Deployment" && b.listers.Deployments != nil:
+		deployment, err := b.listers.Deployments.Deployments(workload.Namespace).Get(workload.Name)
+		if err != nil {
+			return 0, err
+		}
+		replicas = deployment.Spec.Replicas
+	case workload.Kind == "StatefulSet" && b.listers.StatefulSets != nil:
+		statefulSet, err := b.listers.StatefulSets.StatefulSets(workload.Namespace).Get(workload.Name)
+		if err != nil {
+			return 0, err
+		}
+		replicas = statefulSet.Spec.Replicas
+	case workload.Kind == "ReplicaSet" && b.listers.ReplicaSets != nil:
+		replicaSet, err := b.listers.ReplicaSets.ReplicaSets(workload.Namespace).Get(workload.Name)
+		if err != nil {
+			return 0, err
+		}
+		replicas = replicaSet.Spec.Replicas
+	default:
+		return 0, fmt.Errorf("%v workloads are not watched", workload.Kind)
+	}
+	// the replicas default to 1
+	if replicas == nil {
+		return 1, nil
+	}
+	return *replicas, nil
+}
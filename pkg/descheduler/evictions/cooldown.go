@@ -0,0 +1,228 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+
+	"sigs.k8s.io/descheduler/metrics"
+)
+
+var errEvictionCooldown = errors.New("eviction cooldown of the owner not over")
+
+// PodOwner returns the owner the eviction cooldown of a pod is kept for: its workload as given by PodWorkload,
+// or its controller of any other kind, e.g. a DaemonSet or a Job. Returns false when the pod has no controller.
+func PodOwner(pod *v1.Pod) (Workload, bool) {
+	if workload, ok := PodWorkload(pod); ok {
+		return workload, true
+	}
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return Workload{}, false
+	}
+	return Workload{Kind: owner.Kind, Namespace: pod.Namespace, Name: owner.Name}, true
+}
+
+// OwnerEviction records the last time pods of an owner got evicted from a node
+type OwnerEviction struct {
+	Workload `json:",inline"`
+	Node     string      `json:"node"`
+	Time     metav1.Time `json:"time"`
+}
+
+type ownerNode struct {
+	owner Workload
+	node  string
+}
+
+// Cooldown keeps the pods of an owner from being evicted again within a window since pods of the owner
+// got evicted, either from the same node or from any node. The pod evictor records every eviction as it succeeds,
+// but does not enforce the cooldown of the evictions it recorded itself, so the pods of an owner evicted within
+// the same descheduling loop do not cool each other down. It is safe for concurrent use by multiple goroutines.
+type Cooldown struct {
+	window      time.Duration
+	acrossNodes bool
+	clock       clock.PassiveClock
+
+	// lock guards the evictions
+	lock sync.Mutex
+	// evictions holds the last time pods of every owner got evicted from every node
+	evictions map[ownerNode]time.Time
+}
+
+// NewCooldown creates a cooldown lasting for the window after every eviction. The cooldown applies to the pods
+// of the owner on the node a pod got evicted from, or to all the pods of the owner when acrossNodes is set.
+func NewCooldown(window time.Duration, acrossNodes bool, clock clock.PassiveClock) *Cooldown {
+	return &Cooldown{
+		window:      window,
+		acrossNodes: acrossNodes,
+		clock:       clock,
+		evictions:   map[ownerNode]time.Time{},
+	}
+}
+
+// record starts the cooldown of the owner of a pod evicted at the given time.
+// Returns false when the pod has no owner.
+func (c *Cooldown) record(pod *v1.Pod, evictedAt time.Time) (ownerNode, bool) {
+	owner, ok := PodOwner(pod)
+	if !ok {
+		return ownerNode{}, false
+	}
+	key := ownerNode{owner: owner, node: pod.Spec.NodeName}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.evictions[key] = evictedAt
+	return key, true
+}
+
+// History returns the evictions whose cooldown is not over, sorted by owner and node
+func (c *Cooldown) History() []OwnerEviction {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := c.clock.Now()
+	history := []OwnerEviction{}
+	for key, t := range c.evictions {
+		if !c.cooling(t, now) {
+			delete(c.evictions, key)
+			continue
+		}
+		history = append(history, OwnerEviction{Workload: key.owner, Node: key.node, Time: metav1.NewTime(t)})
+	}
+	sort.Slice(history, func(i, j int) bool {
+		if history[i].Workload != history[j].Workload {
+			return history[i].Workload.String() < history[j].Workload.String()
+		}
+		return history[i].Node < history[j].Node
+	})
+	return history
+}
+
+// Merge adds the evictions of the given history, e.g. persisted by a previous or another descheduler instance,
+// keeping the last eviction of every owner from every node
+func (c *Cooldown) Merge(history []OwnerEviction) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, eviction := range history {
+		key := ownerNode{owner: eviction.Workload, node: eviction.Node}
+		if t, ok := c.evictions[key]; !ok || t.Before(eviction.Time.Time) {
+			c.evictions[key] = eviction.Time.Time
+		}
+	}
+}
+
+// check returns the eviction of pods of the owner of the pod the cooldown is not over for, if any, ignoring
+// the evictions of the owners from the nodes listed in skip. An eviction from the node of the pod takes precedence.
+func (c *Cooldown) check(pod *v1.Pod, skip map[ownerNode]bool) (OwnerEviction, bool) {
+	owner, ok := PodOwner(pod)
+	if !ok {
+		return OwnerEviction{}, false
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := c.clock.Now()
+	if key := (ownerNode{owner: owner, node: pod.Spec.NodeName}); !skip[key] {
+		if t, ok := c.evictions[key]; ok && c.cooling(t, now) {
+			return OwnerEviction{Workload: owner, Node: pod.Spec.NodeName, Time: metav1.NewTime(t)}, true
+		}
+	}
+	if !c.acrossNodes {
+		return OwnerEviction{}, false
+	}
+	var last OwnerEviction
+	found := false
+	for key, t := range c.evictions {
+		if key.owner == owner && !skip[key] && c.cooling(t, now) && (!found || last.Time.Time.Before(t)) {
+			last, found = OwnerEviction{Workload: owner, Node: key.node, Time: metav1.NewTime(t)}, true
+		}
+	}
+	return last, found
+}
+
+// Check returns why the owner of the pod keeps it from being evicted now, nil when its cooldown is over.
+// Nothing is recorded, so the cooldown can be checked without evicting the pod.
+func (c *Cooldown) Check(pod *v1.Pod) error {
+	if last, ok := c.check(pod, nil); ok {
+		return cooldownError(last)
+	}
+	return nil
+}
+
+// cooldownError tells the eviction of pods of the owner the cooldown is not over for
+func cooldownError(last OwnerEviction) error {
+	return fmt.Errorf("%w: pods of %v evicted from node %q at %v", errEvictionCooldown, last.Workload, last.Node, last.Time.UTC().Format(time.RFC3339))
+}
+
+// cooling tells whether the cooldown of an eviction at the given time is not over
+func (c *Cooldown) cooling(t, now time.Time) bool {
+	return now.Sub(t) < c.window
+}
+
+// checkCooldown rejects the eviction of a pod whose owner is cooling down. A pod created on the node pods
+// of its owner got evicted from after that eviction is a replacement scheduled back onto that node,
+// i.e. the evictions flap, which is reported.
+func (pe *PodEvictor) checkCooldown(span trace.Span, pod *v1.Pod, opts EvictOptions) error {
+	if pe.cooldown == nil {
+		return nil
+	}
+	pe.lock.Lock()
+	last, ok := pe.cooldown.check(pod, pe.cooldownRecorded)
+	pe.lock.Unlock()
+	if !ok {
+		return nil
+	}
+	flapping := last.Node == pod.Spec.NodeName && pod.CreationTimestamp.After(last.Time.Time)
+	err := cooldownError(last)
+	pe.reportEvictionResult(pod, opts, "eviction cooldown", "")
+	if pe.metricsEnabled {
+		if flapping {
+			metrics.EvictionsFlapping.With(map[string]string{"strategy": opts.PluginName, "profile": opts.ProfileName, "namespace": pod.Namespace, "node": pod.Spec.NodeName}).Inc()
+		}
+	}
+	span.AddEvent("Eviction Failed", trace.WithAttributes(attribute.String("node", pod.Spec.NodeName), attribute.String("err", err.Error()), attribute.Bool("flapping", flapping)))
+	klog.V(2).InfoS("Skipping the eviction of a pod whose owner is cooling down", "pod", klog.KObj(pod), "reason", err.Error(), "flapping", flapping, "profile", opts.ProfileName, "strategy", opts.PluginName, "extension point", opts.ExtensionPoint)
+	if flapping && !pe.dryRun {
+		pe.eventRecorder.Eventf(pod, nil, v1.EventTypeWarning, "EvictionFlapping", "Descheduling", "pod not evicted from %v node by sigs.k8s.io/descheduler as pods of %v were evicted from it %v ago, within the eviction cooldown", pod.Spec.NodeName, last.Workload, pe.cooldown.clock.Since(last.Time.Time).Round(time.Second))
+	}
+	return err
+}
+
+// recordCooldown starts the cooldown of the owner of a pod evicted for real, at the time of the eviction.
+// The pod evictor keeps track of the owners it started the cooldown of, which it does not enforce.
+func (pe *PodEvictor) recordCooldown(pod *v1.Pod) {
+	if pe.cooldown == nil || pe.dryRun {
+		return
+	}
+	pe.lock.Lock()
+	defer pe.lock.Unlock()
+	if key, ok := pe.cooldown.record(pod, pe.cooldown.clock.Now()); ok {
+		if pe.cooldownRecorded == nil {
+			pe.cooldownRecorded = map[ownerNode]bool{}
+		}
+		pe.cooldownRecorded[key] = true
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	policylistersv1 "k8s.io/client-go/listers/policy/v1"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+// dryRunDisruptions tracks the disruptions consumed by the evictions sent as dry run requests.
+// The apiserver checks such evictions against the disruptions a pod disruption budget allows,
+// but never consumes them, so every eviction would be checked against the budget as if it came first.
+type dryRunDisruptions struct {
+	lister policylistersv1.PodDisruptionBudgetLister
+
+	lock sync.Mutex
+	// consumed counts the disruptions consumed by the dry run evictions, by budget
+	consumed map[types.NamespacedName]int32
+}
+
+// SetDryRunDisruptionBudgets tracks the disruptions consumed by the evictions of the server side dry run mode
+// against the pod disruption budgets listed by the lister. An eviction of a healthy pod is rejected with 429,
+// the way the eviction subresource does, once the disruptions its budget allows are consumed by the earlier ones.
+func (pe *PodEvictor) SetDryRunDisruptionBudgets(lister policylistersv1.PodDisruptionBudgetLister) {
+	if lister == nil {
+		pe.dryRunDisruptions = nil
+		return
+	}
+	pe.dryRunDisruptions = &dryRunDisruptions{lister: lister, consumed: map[types.NamespacedName]int32{}}
+}
+
+// sendEviction sends the eviction request of a pod, consuming a disruption of its pod disruption budget
+// in the server side dry run mode
+func (pe *PodEvictor) sendEviction(ctx context.Context, pod *v1.Pod, opts EvictOptions) error {
+	serverSideDryRun := pe.dryRun && pe.serverSideDryRun
+	var budget *types.NamespacedName
+	if serverSideDryRun && pe.dryRunDisruptions != nil {
+		var err error
+		if budget, err = pe.dryRunDisruptions.reserve(pod); err != nil {
+			return fmt.Errorf("error when evicting pod (ignoring) %q: %w", pod.Name, err)
+		}
+	}
+	err := evictPod(ctx, pe.client, pod, pe.policyGroupVersion, opts, serverSideDryRun)
+	if err != nil && budget != nil {
+		pe.dryRunDisruptions.release(*budget)
+	}
+	return err
+}
+
+// reserve consumes a disruption of the budget covering a healthy pod and returns the budget,
+// or nil when the pod is not covered by a single budget or is not healthy and so consumes none
+func (d *dryRunDisruptions) reserve(pod *v1.Pod) (*types.NamespacedName, error) {
+	if pod.Status.Phase != v1.PodRunning || !utils.IsPodReady(pod) || utils.IsPodTerminating(pod) {
+		return nil, nil
+	}
+	pdb, err := d.budget(pod)
+	if err != nil || pdb == nil {
+		return nil, err
+	}
+
+	key := types.NamespacedName{Namespace: pdb.Namespace, Name: pdb.Name}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if pdb.Status.DisruptionsAllowed-d.consumed[key] <= 0 {
+		err := apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+		err.ErrStatus.Details.Causes = append(err.ErrStatus.Details.Causes, metav1.StatusCause{
+			Type:    policy.DisruptionBudgetCause,
+			Message: fmt.Sprintf("The disruption budget %s allows %d disruptions, all consumed by the earlier dry run evictions", pdb.Name, pdb.Status.DisruptionsAllowed),
+		})
+		return nil, err
+	}
+	d.consumed[key]++
+	return &key, nil
+}
+
+// release gives back a disruption consumed through reserve
+func (d *dryRunDisruptions) release(key types.NamespacedName) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.consumed[key]--
+}
+
+// budget returns the pod disruption budget covering a pod, or nil when none or several do,
+// the latter being rejected by the apiserver anyway
+func (d *dryRunDisruptions) budget(pod *v1.Pod) (*policy.PodDisruptionBudget, error) {
+	pdbs, err := d.lister.PodDisruptionBudgets(pod.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("unable to list pod disruption budgets: %v", err)
+	}
+	var matching *policy.PodDisruptionBudget
+	for _, pdb := range pdbs {
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			klog.V(4).InfoS("Invalid pod disruption budget selector", "pdb", klog.KObj(pdb), "err", err)
+			continue
+		}
+		if selector.Empty() || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		if matching != nil {
+			return nil, nil
+		}
+		matching = pdb
+	}
+	return matching, nil
+}
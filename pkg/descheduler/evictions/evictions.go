@@ -18,7 +18,10 @@ package evictions
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -28,6 +31,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/descheduler/metrics"
 
@@ -41,19 +45,56 @@ type (
 	namespacePodEvictCount map[string]uint
 )
 
+// PodEvictor evicts pods while exercising the eviction limits.
+// It is safe for concurrent use by multiple goroutines.
 type PodEvictor struct {
 	client                     clientset.Interface
 	nodes                      []*v1.Node
 	policyGroupVersion         string
 	dryRun                     bool
+	serverSideDryRun           bool
 	maxPodsToEvictPerNode      *uint
 	maxPodsToEvictPerNamespace *uint
-	nodepodCount               nodePodEvictedCount
-	namespacePodCount          namespacePodEvictCount
+	maxPodsToEvictTotal        *uint
+	rateLimiter                flowcontrol.RateLimiter
+	retryBackoff               time.Duration
+	retryDeadline              time.Duration
+	gracePeriodSeconds         *int64
+	deleteFallbackPodPhases    []v1.PodPhase
+	removeFinalizers           bool
+	workloadBudget             *WorkloadBudget
+	cooldown                   *Cooldown
+	dryRunDisruptions          *dryRunDisruptions
 	metricsEnabled             bool
 	eventRecorder              events.EventRecorder
+
+	// lock guards the eviction counts and the cooldown recorded
+	lock              sync.Mutex
+	nodepodCount      nodePodEvictedCount
+	namespacePodCount namespacePodEvictCount
+	totalPodCount     uint
+	// pluginPodCount keeps count of pods evicted by each plugin, by profile and plugin name
+	pluginPodCount map[string]map[string]uint
+	// evictions lists the pods evicted, in the eviction order
+	evictions []Eviction
+	// cooldownRecorded holds the owners and nodes the cooldown got started for by the evictions of the pod evictor
+	cooldownRecorded map[ownerNode]bool
+	// retryQueue lists the evictions blocked by a pod disruption budget waiting for a retry, in the queuing order
+	retryQueue []*queuedEviction
+}
+
+// Eviction records a pod evicted by the pod evictor, along with the options it got evicted with
+type Eviction struct {
+	Pod  *v1.Pod
+	Opts EvictOptions
 }
 
+var (
+	errNodeLimitReached      = errors.New("maximum number of evicted pods per node reached")
+	errNamespaceLimitReached = errors.New("maximum number of evicted pods per namespace reached")
+	errTotalLimitReached     = errors.New("maximum number of evicted pods per descheduling loop reached")
+)
+
 func NewPodEvictor(
 	client clientset.Interface,
 	policyGroupVersion string,
@@ -80,18 +121,76 @@ func NewPodEvictor(
 		maxPodsToEvictPerNamespace: maxPodsToEvictPerNamespace,
 		nodepodCount:               nodePodCount,
 		namespacePodCount:          namespacePodCount,
+		pluginPodCount:             map[string]map[string]uint{},
 		metricsEnabled:             metricsEnabled,
 		eventRecorder:              eventRecorder,
 	}
 }
 
+// SetServerSideDryRun sends the evictions of the dry run mode to the apiserver as dry run requests,
+// so they get validated, e.g. against the pod disruption budgets and the admission webhooks, without deleting the pods.
+func (pe *PodEvictor) SetServerSideDryRun(serverSideDryRun bool) {
+	pe.serverSideDryRun = serverSideDryRun
+}
+
+// SetMaxPodsToEvictTotal limits the number of pods evicted through the pod evictor, summed through all nodes and namespaces
+func (pe *PodEvictor) SetMaxPodsToEvictTotal(maxPodsToEvictTotal *uint) {
+	pe.maxPodsToEvictTotal = maxPodsToEvictTotal
+}
+
+// SetRateLimiter paces the evictions through the rate limiter, waiting for a token before every eviction.
+// The evictions of the dry run mode are not paced, as they do not disrupt anything.
+func (pe *PodEvictor) SetRateLimiter(rateLimiter flowcontrol.RateLimiter) {
+	pe.rateLimiter = rateLimiter
+}
+
+// SetRetry queues the evictions blocked by a pod disruption budget, i.e. rejected with 429, so RetryQueued retries
+// them with an exponential backoff starting at initialBackoff, until they succeed, fail for another reason or the
+// deadline since the retries started passes. TryEvictPod returns ErrEvictionQueued for such evictions right away.
+// A zero deadline disables the retries, as does the dry run mode.
+func (pe *PodEvictor) SetRetry(initialBackoff, deadline time.Duration) {
+	pe.retryBackoff = initialBackoff
+	pe.retryDeadline = deadline
+}
+
+// SetGracePeriodSeconds overrides the termination grace period of the evicted pods,
+// unless the eviction options override it already
+func (pe *PodEvictor) SetGracePeriodSeconds(gracePeriodSeconds *int64) {
+	pe.gracePeriodSeconds = gracePeriodSeconds
+}
+
+// SetDeleteFallback deletes the pods in the given phases directly when their eviction is disallowed,
+// i.e. rejected as forbidden or not supported, and removes the finalizers of such pods once evicted or
+// deleted when removeFinalizers is set. No phase disables the fallback.
+func (pe *PodEvictor) SetDeleteFallback(podPhases []v1.PodPhase, removeFinalizers bool) {
+	pe.deleteFallbackPodPhases = podPhases
+	pe.removeFinalizers = removeFinalizers
+}
+
+// SetWorkloadBudget caps the evictions of the pods of every workload through the budget. An eviction reserves
+// its share of the budget before being sent, and gives it back when it fails.
+func (pe *PodEvictor) SetWorkloadBudget(workloadBudget *WorkloadBudget) {
+	pe.workloadBudget = workloadBudget
+}
+
+// SetCooldown rejects the evictions of the pods whose owner is cooling down after an eviction recorded
+// in the cooldown. Every eviction but the ones of the dry run mode is recorded in the cooldown as it succeeds,
+// for the next pod evictors to enforce.
+func (pe *PodEvictor) SetCooldown(cooldown *Cooldown) {
+	pe.cooldown = cooldown
+}
+
 // NodeEvicted gives a number of pods evicted for node
 func (pe *PodEvictor) NodeEvicted(node *v1.Node) uint {
+	pe.lock.Lock()
+	defer pe.lock.Unlock()
 	return pe.nodepodCount[node.Name]
 }
 
 // TotalEvicted gives a number of pods evicted through all nodes
 func (pe *PodEvictor) TotalEvicted() uint {
+	pe.lock.Lock()
+	defer pe.lock.Unlock()
 	var total uint
 	for _, count := range pe.nodepodCount {
 		total += count
@@ -99,76 +198,243 @@ func (pe *PodEvictor) TotalEvicted() uint {
 	return total
 }
 
-// NodeLimitExceeded checks if the number of evictions for a node was exceeded
+// ProfilePluginEvicted gives a number of pods evicted by each plugin, by profile and plugin name
+func (pe *PodEvictor) ProfilePluginEvicted() map[string]map[string]uint {
+	pe.lock.Lock()
+	defer pe.lock.Unlock()
+	counts := make(map[string]map[string]uint, len(pe.pluginPodCount))
+	for profile, plugins := range pe.pluginPodCount {
+		counts[profile] = make(map[string]uint, len(plugins))
+		for plugin, count := range plugins {
+			counts[profile][plugin] = count
+		}
+	}
+	return counts
+}
+
+// NodeLimitExceeded checks if the number of evictions for a node was exceeded.
+// Once the total number of evictions is exceeded, it is exceeded for every node.
 func (pe *PodEvictor) NodeLimitExceeded(node *v1.Node) bool {
+	pe.lock.Lock()
+	defer pe.lock.Unlock()
+	if pe.maxPodsToEvictTotal != nil && pe.totalPodCount >= *pe.maxPodsToEvictTotal {
+		return true
+	}
 	if pe.maxPodsToEvictPerNode != nil {
-		return pe.nodepodCount[node.Name] == *pe.maxPodsToEvictPerNode
+		return pe.nodepodCount[node.Name] >= *pe.maxPodsToEvictPerNode
 	}
 	return false
 }
 
+// reserveEviction counts the eviction of a pod against the node, namespace and total limits
+// before the eviction is requested, so concurrent evictions can not exceed the limits.
+// The reservation is to be released through releaseEviction when the eviction fails.
+func (pe *PodEvictor) reserveEviction(pod *v1.Pod) error {
+	pe.lock.Lock()
+	defer pe.lock.Unlock()
+
+	if pod.Spec.NodeName != "" && pe.maxPodsToEvictPerNode != nil && pe.nodepodCount[pod.Spec.NodeName]+1 > *pe.maxPodsToEvictPerNode {
+		return errNodeLimitReached
+	}
+	if pe.maxPodsToEvictPerNamespace != nil && pe.namespacePodCount[pod.Namespace]+1 > *pe.maxPodsToEvictPerNamespace {
+		return errNamespaceLimitReached
+	}
+	if pe.maxPodsToEvictTotal != nil && pe.totalPodCount+1 > *pe.maxPodsToEvictTotal {
+		return errTotalLimitReached
+	}
+
+	if pod.Spec.NodeName != "" {
+		pe.nodepodCount[pod.Spec.NodeName]++
+	}
+	pe.namespacePodCount[pod.Namespace]++
+	pe.totalPodCount++
+	return nil
+}
+
+// releaseEviction releases an eviction reserved through reserveEviction
+func (pe *PodEvictor) releaseEviction(pod *v1.Pod) {
+	pe.lock.Lock()
+	defer pe.lock.Unlock()
+
+	if pod.Spec.NodeName != "" {
+		pe.nodepodCount[pod.Spec.NodeName]--
+	}
+	pe.namespacePodCount[pod.Namespace]--
+	pe.totalPodCount--
+}
+
+// reserveWorkloadBudget counts the eviction of a pod against the eviction budget of its workload, if any.
+// Returns the time the eviction is reserved at, zero without a budget. The reservation is released along
+// with the one of reserveEviction when the eviction fails.
+func (pe *PodEvictor) reserveWorkloadBudget(pod *v1.Pod) (time.Time, error) {
+	if pe.workloadBudget == nil {
+		return time.Time{}, nil
+	}
+	workload, ok := PodWorkload(pod)
+	if !ok {
+		return time.Time{}, nil
+	}
+	return pe.workloadBudget.reserve(workload)
+}
+
+// releaseWorkloadBudget releases the eviction reserved at the given time through reserveWorkloadBudget
+func (pe *PodEvictor) releaseWorkloadBudget(pod *v1.Pod, reserved time.Time) {
+	if pe.workloadBudget == nil || reserved.IsZero() {
+		return
+	}
+	if workload, ok := PodWorkload(pod); ok {
+		pe.workloadBudget.release(workload, reserved)
+	}
+}
+
+// recordEviction records a successful eviction and counts it against the plugin of the profile requesting it
+func (pe *PodEvictor) recordEviction(pod *v1.Pod, opts EvictOptions) {
+	pe.lock.Lock()
+	defer pe.lock.Unlock()
+	if pe.pluginPodCount[opts.ProfileName] == nil {
+		pe.pluginPodCount[opts.ProfileName] = map[string]uint{}
+	}
+	pe.pluginPodCount[opts.ProfileName][opts.PluginName]++
+	pe.evictions = append(pe.evictions, Eviction{Pod: pod, Opts: opts})
+}
+
+// Evictions lists the pods evicted so far, in the eviction order
+func (pe *PodEvictor) Evictions() []Eviction {
+	pe.lock.Lock()
+	defer pe.lock.Unlock()
+	return append([]Eviction(nil), pe.evictions...)
+}
+
 // EvictOptions provides a handle for passing additional info to EvictPod
 type EvictOptions struct {
 	// Reason allows for passing details about the specific eviction for logging.
 	Reason string
+	// ProfileName is the name of the profile requesting the eviction.
+	ProfileName string
+	// PluginName is the name of the plugin requesting the eviction.
+	PluginName string
+	// ExtensionPoint is the extension point the eviction is requested from.
+	ExtensionPoint string
+	// GracePeriodSeconds overrides the termination grace period of the evicted pod when set.
+	GracePeriodSeconds *int64
+	// Annotations are set on the eviction request, e.g. to be consumed by admission webhooks.
+	Annotations map[string]string
 }
 
 // EvictPod evicts a pod while exercising eviction limits.
-// Returns true when the pod is evicted on the server side.
+// Returns true when the pod is evicted on the server side, or its eviction is queued for a retry
+// and counts as an eviction until the retries are over.
 func (pe *PodEvictor) EvictPod(ctx context.Context, pod *v1.Pod, opts EvictOptions) bool {
+	err := pe.TryEvictPod(ctx, pod, opts)
+	return err == nil || errors.Is(err, ErrEvictionQueued)
+}
+
+// TryEvictPod evicts a pod while exercising eviction limits.
+// Returns nil when the pod is evicted on the server side, ErrEvictionQueued when its eviction got blocked
+// by a pod disruption budget and queued for a retry, the reason it did not get evicted otherwise.
+func (pe *PodEvictor) TryEvictPod(ctx context.Context, pod *v1.Pod, opts EvictOptions) error {
 	var span trace.Span
-	ctx, span = tracing.Tracer().Start(ctx, "EvictPod", trace.WithAttributes(attribute.String("podName", pod.Name), attribute.String("podNamespace", pod.Namespace), attribute.String("reason", opts.Reason), attribute.String("operation", tracing.EvictOperation)))
+	ctx, span = tracing.Tracer().Start(ctx, "EvictPod", trace.WithAttributes(attribute.String("podName", pod.Name), attribute.String("podNamespace", pod.Namespace), attribute.String("reason", opts.Reason), attribute.String("profile", opts.ProfileName), attribute.String("plugin", opts.PluginName), attribute.String("extensionPoint", opts.ExtensionPoint), attribute.String("operation", tracing.EvictOperation)))
 	defer span.End()
-	// TODO: Replace context-propagated Strategy name with a defined framework handle for accessing Strategy info
-	strategy := ""
-	if ctx.Value("strategyName") != nil {
-		strategy = ctx.Value("strategyName").(string)
-	}
+	strategy := opts.PluginName
 
-	if pod.Spec.NodeName != "" {
-		if pe.maxPodsToEvictPerNode != nil && pe.nodepodCount[pod.Spec.NodeName]+1 > *pe.maxPodsToEvictPerNode {
-			if pe.metricsEnabled {
-				metrics.PodsEvicted.With(map[string]string{"result": "maximum number of pods per node reached", "strategy": strategy, "namespace": pod.Namespace, "node": pod.Spec.NodeName}).Inc()
-			}
-			span.AddEvent("Eviction Failed", trace.WithAttributes(attribute.String("node", pod.Spec.NodeName), attribute.String("err", "Maximum number of evicted pods per node reached")))
-			klog.ErrorS(fmt.Errorf("maximum number of evicted pods per node reached"), "Error evicting pod", "limit", *pe.maxPodsToEvictPerNode, "node", pod.Spec.NodeName)
-			return false
-		}
+	if err := pe.checkCooldown(span, pod, opts); err != nil {
+		return err
 	}
 
-	if pe.maxPodsToEvictPerNamespace != nil && pe.namespacePodCount[pod.Namespace]+1 > *pe.maxPodsToEvictPerNamespace {
-		if pe.metricsEnabled {
-			metrics.PodsEvicted.With(map[string]string{"result": "maximum number of pods per namespace reached", "strategy": strategy, "namespace": pod.Namespace, "node": pod.Spec.NodeName}).Inc()
-		}
+	switch err := pe.reserveEviction(pod); err {
+	case errNodeLimitReached:
+		pe.reportEvictionResult(pod, opts, "maximum number of pods per node reached", "")
+		span.AddEvent("Eviction Failed", trace.WithAttributes(attribute.String("node", pod.Spec.NodeName), attribute.String("err", "Maximum number of evicted pods per node reached")))
+		klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod), "limit", *pe.maxPodsToEvictPerNode, "node", pod.Spec.NodeName, "profile", opts.ProfileName, "strategy", strategy, "extension point", opts.ExtensionPoint)
+		return err
+	case errNamespaceLimitReached:
+		pe.reportEvictionResult(pod, opts, "maximum number of pods per namespace reached", "")
 		span.AddEvent("Eviction Failed", trace.WithAttributes(attribute.String("node", pod.Spec.NodeName), attribute.String("err", "Maximum number of evicted pods per namespace reached")))
-		klog.ErrorS(fmt.Errorf("maximum number of evicted pods per namespace reached"), "Error evicting pod", "limit", *pe.maxPodsToEvictPerNamespace, "namespace", pod.Namespace)
-		return false
+		klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod), "limit", *pe.maxPodsToEvictPerNamespace, "namespace", pod.Namespace, "profile", opts.ProfileName, "strategy", strategy, "extension point", opts.ExtensionPoint)
+		return err
+	case errTotalLimitReached:
+		pe.reportEvictionResult(pod, opts, "maximum number of pods per loop reached", "")
+		span.AddEvent("Eviction Failed", trace.WithAttributes(attribute.String("node", pod.Spec.NodeName), attribute.String("err", "Maximum number of evicted pods per descheduling loop reached")))
+		klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod), "limit", *pe.maxPodsToEvictTotal, "profile", opts.ProfileName, "strategy", strategy, "extension point", opts.ExtensionPoint)
+		return err
 	}
 
-	err := evictPod(ctx, pe.client, pod, pe.policyGroupVersion)
+	reserved, err := pe.reserveWorkloadBudget(pod)
 	if err != nil {
-		// err is used only for logging purposes
-		span.AddEvent("Eviction Failed", trace.WithAttributes(attribute.String("node", pod.Spec.NodeName), attribute.String("err", err.Error())))
-		klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod), "reason", opts.Reason)
-		if pe.metricsEnabled {
-			metrics.PodsEvicted.With(map[string]string{"result": "error", "strategy": strategy, "namespace": pod.Namespace, "node": pod.Spec.NodeName}).Inc()
+		pe.releaseEviction(pod)
+		if errors.Is(err, errWorkloadBudgetExhausted) {
+			pe.reportEvictionResult(pod, opts, "workload eviction budget exhausted", "")
+		} else {
+			pe.reportEvictionResult(pod, opts, "error", evictionErrorReason(err))
 		}
-		return false
+		span.AddEvent("Eviction Failed", trace.WithAttributes(attribute.String("node", pod.Spec.NodeName), attribute.String("err", err.Error())))
+		klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod), "profile", opts.ProfileName, "strategy", strategy, "extension point", opts.ExtensionPoint)
+		return err
 	}
 
-	if pod.Spec.NodeName != "" {
-		pe.nodepodCount[pod.Spec.NodeName]++
+	if err := pe.waitForRateLimit(ctx, span, pod, opts); err != nil {
+		pe.evictionFailed(span, pod, opts, reserved, err)
+		return err
 	}
-	pe.namespacePodCount[pod.Namespace]++
 
-	if pe.metricsEnabled {
-		metrics.PodsEvicted.With(map[string]string{"result": "success", "strategy": strategy, "namespace": pod.Namespace, "node": pod.Spec.NodeName}).Inc()
+	if opts.GracePeriodSeconds == nil {
+		opts.GracePeriodSeconds = pe.gracePeriodSeconds
+	}
+	err = pe.sendEviction(ctx, pod, opts)
+	if pe.retryable(err) {
+		return pe.queueEviction(ctx, span, pod, opts, reserved, err)
 	}
+	return pe.finishEviction(ctx, span, pod, opts, reserved, err)
+}
+
+// finishEviction completes the eviction of a pod given the outcome of its eviction request, deleting the pod
+// instead when its eviction is disallowed, and returns the final outcome
+func (pe *PodEvictor) finishEviction(ctx context.Context, span trace.Span, pod *v1.Pod, opts EvictOptions, reserved time.Time, err error) error {
+	if err != nil {
+		if pe.deleteFallbackApplies(pod, err) {
+			deleteErr := pe.deletePod(ctx, span, pod, opts, err)
+			if deleteErr == nil {
+				pe.evictionSucceeded(pod, opts, true)
+				pe.removePodFinalizers(ctx, span, pod, opts)
+				return nil
+			}
+			err = fmt.Errorf("%w, deleting the pod failed: %v", err, deleteErr)
+		}
+		pe.evictionFailed(span, pod, opts, reserved, err)
+		return err
+	}
+
+	pe.evictionSucceeded(pod, opts, false)
+	pe.removePodFinalizers(ctx, span, pod, opts)
+	return nil
+}
+
+// evictionFailed releases the reservations of a pod which did not get evicted and reports the failure
+func (pe *PodEvictor) evictionFailed(span trace.Span, pod *v1.Pod, opts EvictOptions, reserved time.Time, err error) {
+	pe.releaseEviction(pod)
+	pe.releaseWorkloadBudget(pod, reserved)
+	// err is used only for logging purposes
+	span.AddEvent("Eviction Failed", trace.WithAttributes(attribute.String("node", pod.Spec.NodeName), attribute.String("err", err.Error())))
+	klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod), "reason", opts.Reason, "profile", opts.ProfileName, "strategy", opts.PluginName, "extension point", opts.ExtensionPoint)
+	pe.reportEvictionResult(pod, opts, "error", evictionErrorReason(err))
+}
+
+// evictionSucceeded records and reports the eviction of a pod, deleted instead of evicted when its eviction was disallowed
+func (pe *PodEvictor) evictionSucceeded(pod *v1.Pod, opts EvictOptions, deleted bool) {
+	strategy := opts.PluginName
+	result, verb := "success", "evicted"
+	if deleted {
+		result, verb = "deleted", "deleted"
+	}
+	pe.recordEviction(pod, opts)
+	pe.recordCooldown(pod)
+	pe.reportEvictionResult(pod, opts, result, "")
 
 	if pe.dryRun {
-		klog.V(1).InfoS("Evicted pod in dry run mode", "pod", klog.KObj(pod), "reason", opts.Reason, "strategy", strategy, "node", pod.Spec.NodeName)
+		klog.V(1).InfoS("Evicted pod in dry run mode", "pod", klog.KObj(pod), "reason", opts.Reason, "profile", opts.ProfileName, "strategy", strategy, "extension point", opts.ExtensionPoint, "node", pod.Spec.NodeName, "deleted", deleted)
 	} else {
-		klog.V(1).InfoS("Evicted pod", "pod", klog.KObj(pod), "reason", opts.Reason, "strategy", strategy, "node", pod.Spec.NodeName)
+		klog.V(1).InfoS("Evicted pod", "pod", klog.KObj(pod), "reason", opts.Reason, "profile", opts.ProfileName, "strategy", strategy, "extension point", opts.ExtensionPoint, "node", pod.Spec.NodeName, "deleted", deleted)
 		reason := opts.Reason
 		if len(reason) == 0 {
 			reason = strategy
@@ -176,32 +442,84 @@ func (pe *PodEvictor) EvictPod(ctx context.Context, pod *v1.Pod, opts EvictOptio
 				reason = "NotSet"
 			}
 		}
-		pe.eventRecorder.Eventf(pod, nil, v1.EventTypeNormal, reason, "Descheduled", "pod evicted from %v node by sigs.k8s.io/descheduler", pod.Spec.NodeName)
+		if len(opts.ProfileName) > 0 {
+			pe.eventRecorder.Eventf(pod, nil, v1.EventTypeNormal, reason, "Descheduled", "pod %v from %v node by sigs.k8s.io/descheduler (profile %v, plugin %v, extension point %v)", verb, pod.Spec.NodeName, opts.ProfileName, strategy, opts.ExtensionPoint)
+		} else {
+			pe.eventRecorder.Eventf(pod, nil, v1.EventTypeNormal, reason, "Descheduled", "pod %v from %v node by sigs.k8s.io/descheduler", verb, pod.Spec.NodeName)
+		}
+	}
+}
+
+// reportEvictionResult counts the result of the eviction of a pod through the pods_evicted metrics.
+// pods_evicted keeps its original labels, pods_evicted_total tells the profile requesting the eviction
+// and, for the error result, why the eviction failed as well.
+func (pe *PodEvictor) reportEvictionResult(pod *v1.Pod, opts EvictOptions, result, errorReason string) {
+	if !pe.metricsEnabled {
+		return
+	}
+	metrics.PodsEvicted.With(map[string]string{"result": result, "strategy": opts.PluginName, "namespace": pod.Namespace, "node": pod.Spec.NodeName}).Inc()
+	metrics.PodsEvictedTotal.With(map[string]string{"result": result, "error_reason": errorReason, "strategy": opts.PluginName, "profile": opts.ProfileName, "namespace": pod.Namespace, "node": pod.Spec.NodeName}).Inc()
+}
+
+// waitForRateLimit waits until the rate limiter allows the eviction of the pod, if any
+func (pe *PodEvictor) waitForRateLimit(ctx context.Context, span trace.Span, pod *v1.Pod, opts EvictOptions) error {
+	if pe.rateLimiter == nil || pe.dryRun || pe.rateLimiter.TryAccept() {
+		return nil
+	}
+	if pe.metricsEnabled {
+		metrics.EvictionsRateLimited.With(map[string]string{"strategy": opts.PluginName, "profile": opts.ProfileName}).Inc()
 	}
-	return true
+	span.AddEvent("Eviction Rate Limited", trace.WithAttributes(attribute.String("node", pod.Spec.NodeName)))
+	klog.V(2).InfoS("Waiting for the eviction rate limit", "pod", klog.KObj(pod), "profile", opts.ProfileName, "strategy", opts.PluginName, "extension point", opts.ExtensionPoint)
+	if err := pe.rateLimiter.Wait(ctx); err != nil {
+		return fmt.Errorf("error waiting for the eviction rate limit: %v", err)
+	}
+	return nil
 }
 
-func evictPod(ctx context.Context, client clientset.Interface, pod *v1.Pod, policyGroupVersion string) error {
-	deleteOptions := &metav1.DeleteOptions{}
-	// GracePeriodSeconds ?
+func evictPod(ctx context.Context, client clientset.Interface, pod *v1.Pod, policyGroupVersion string, opts EvictOptions, serverSideDryRun bool) error {
+	deleteOptions := &metav1.DeleteOptions{
+		GracePeriodSeconds: opts.GracePeriodSeconds,
+	}
+	if serverSideDryRun {
+		deleteOptions.DryRun = []string{metav1.DryRunAll}
+	}
 	eviction := &policy.Eviction{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: policyGroupVersion,
 			Kind:       eutils.EvictionKind,
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      pod.Name,
-			Namespace: pod.Namespace,
+			Name:        pod.Name,
+			Namespace:   pod.Namespace,
+			Annotations: opts.Annotations,
 		},
 		DeleteOptions: deleteOptions,
 	}
 	err := client.PolicyV1().Evictions(eviction.Namespace).Evict(ctx, eviction)
 
 	if apierrors.IsTooManyRequests(err) {
-		return fmt.Errorf("error when evicting pod (ignoring) %q: %v", pod.Name, err)
+		return fmt.Errorf("error when evicting pod (ignoring) %q: %w", pod.Name, err)
 	}
 	if apierrors.IsNotFound(err) {
-		return fmt.Errorf("pod not found when evicting %q: %v", pod.Name, err)
+		return fmt.Errorf("pod not found when evicting %q: %w", pod.Name, err)
 	}
 	return err
 }
+
+// evictionErrorReason classifies an eviction error as the error reason of the pods_evicted_total metric
+func evictionErrorReason(err error) string {
+	switch {
+	case apierrors.IsTooManyRequests(err):
+		// the eviction would violate a pod disruption budget
+		return "blocked"
+	case apierrors.IsNotFound(err):
+		return "not found"
+	case apierrors.IsForbidden(err):
+		return "forbidden"
+	case apierrors.IsInternalError(err):
+		// e.g. an admission webhook failed
+		return "internal error"
+	}
+	return "error"
+}
@@ -18,13 +18,31 @@ package evictions
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes/fake"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	policylistersv1 "k8s.io/client-go/listers/policy/v1"
 	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/events"
+	testingclock "k8s.io/utils/clock/testing"
+	utilpointer "k8s.io/utils/pointer"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	"sigs.k8s.io/descheduler/pkg/utils"
 	"sigs.k8s.io/descheduler/test"
@@ -62,7 +80,7 @@ func TestEvictPod(t *testing.T) {
 		fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
 			return true, &v1.PodList{Items: test.pods}, nil
 		})
-		got := evictPod(ctx, fakeClient, test.pod, "v1")
+		got := evictPod(ctx, fakeClient, test.pod, "v1", EvictOptions{}, false)
 		if got != test.want {
 			t.Errorf("Test error for Desc: %s. Expected %v pod eviction to be %v, got %v", test.description, test.pod.Name, test.want, got)
 		}
@@ -113,3 +131,1006 @@ func TestPodTypes(t *testing.T) {
 		t.Errorf("Expected p1 to be a normal pod.")
 	}
 }
+
+func TestEvictPodConcurrentLimits(t *testing.T) {
+	ctx := context.Background()
+	uint3 := uint(3)
+	uint4 := uint(4)
+
+	tests := []struct {
+		description                string
+		maxPodsToEvictPerNode      *uint
+		maxPodsToEvictPerNamespace *uint
+		maxPodsToEvictTotal        *uint
+		failingEvictions           bool
+		expectedEvicted            uint
+	}{
+		{
+			description:           "per node limit",
+			maxPodsToEvictPerNode: &uint3,
+			expectedEvicted:       6,
+		},
+		{
+			description:                "per namespace limit",
+			maxPodsToEvictPerNamespace: &uint4,
+			expectedEvicted:            4,
+		},
+		{
+			description:         "total limit",
+			maxPodsToEvictTotal: &uint4,
+			expectedEvicted:     4,
+		},
+		{
+			description:           "total limit below the sum of the per node limits",
+			maxPodsToEvictPerNode: &uint3,
+			maxPodsToEvictTotal:   &uint4,
+			expectedEvicted:       4,
+		},
+		{
+			description:           "failed evictions do not count against the per node limit",
+			maxPodsToEvictPerNode: &uint3,
+			failingEvictions:      true,
+			expectedEvicted:       6,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			nodes := []*v1.Node{
+				test.BuildTestNode("node1", 1000, 2000, 20, nil),
+				test.BuildTestNode("node2", 1000, 2000, 20, nil),
+			}
+			var objs []runtime.Object
+			var pods []*v1.Pod
+			for _, node := range nodes {
+				objs = append(objs, node)
+				for i := 0; i < 10; i++ {
+					pod := test.BuildTestPod(fmt.Sprintf("%s-p%d", node.Name, i), 100, 0, node.Name, nil)
+					objs = append(objs, pod)
+					pods = append(pods, pod)
+				}
+			}
+
+			fakeClient := fake.NewSimpleClientset(objs...)
+			if tc.failingEvictions {
+				// every other pod fails to be evicted
+				fakeClient.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+					if action.GetSubresource() != "eviction" {
+						return false, nil, nil
+					}
+					name := action.(core.CreateAction).GetObject().(*policy.Eviction).Name
+					if name[len(name)-1]%2 == 0 {
+						return true, nil, fmt.Errorf("eviction of %s failed", name)
+					}
+					return false, nil, nil
+				})
+			}
+
+			podEvictor := NewPodEvictor(fakeClient, "policy/v1", false, tc.maxPodsToEvictPerNode, tc.maxPodsToEvictPerNamespace, nodes, false, &events.FakeRecorder{})
+			podEvictor.SetMaxPodsToEvictTotal(tc.maxPodsToEvictTotal)
+
+			var wg sync.WaitGroup
+			for _, pod := range pods {
+				wg.Add(1)
+				go func(pod *v1.Pod) {
+					defer wg.Done()
+					podEvictor.EvictPod(ctx, pod, EvictOptions{ProfileName: "profile", PluginName: "plugin"})
+				}(pod)
+			}
+			wg.Wait()
+
+			if total := podEvictor.TotalEvicted(); total != tc.expectedEvicted {
+				t.Errorf("Expected %v evicted pods, got %v", tc.expectedEvicted, total)
+			}
+			if evicted := podEvictor.ProfilePluginEvicted()["profile"]["plugin"]; evicted != tc.expectedEvicted {
+				t.Errorf("Expected %v pods evicted by the plugin, got %v", tc.expectedEvicted, evicted)
+			}
+			if evicted := uint(len(podEvictor.Evictions())); evicted != tc.expectedEvicted {
+				t.Errorf("Expected %v evictions to be recorded, got %v", tc.expectedEvicted, evicted)
+			}
+			if tc.maxPodsToEvictTotal != nil {
+				for _, node := range nodes {
+					if !podEvictor.NodeLimitExceeded(node) {
+						t.Errorf("Expected the limit of node %v to be exceeded once the total limit is reached", node.Name)
+					}
+				}
+			} else if tc.maxPodsToEvictPerNode != nil {
+				for _, node := range nodes {
+					if evicted := podEvictor.NodeEvicted(node); evicted != *tc.maxPodsToEvictPerNode {
+						t.Errorf("Expected %v evicted pods on node %v, got %v", *tc.maxPodsToEvictPerNode, node.Name, evicted)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestServerSideDryRun(t *testing.T) {
+	ctx := context.Background()
+	node := test.BuildTestNode("node1", 1000, 2000, 20, nil)
+	tests := []struct {
+		description      string
+		dryRun           bool
+		serverSideDryRun bool
+		rejected         bool
+		expectedDryRun   []string
+		expectedErr      bool
+	}{
+		{
+			description:    "evictions are not dry run requests by default",
+			dryRun:         true,
+			expectedDryRun: nil,
+		},
+		{
+			description:      "evictions are dry run requests in the server dry run mode",
+			dryRun:           true,
+			serverSideDryRun: true,
+			expectedDryRun:   []string{metav1.DryRunAll},
+		},
+		{
+			description:      "evictions are dry run requests only in the dry run mode",
+			serverSideDryRun: true,
+			expectedDryRun:   nil,
+		},
+		{
+			description:      "evictions rejected by the apiserver fail",
+			dryRun:           true,
+			serverSideDryRun: true,
+			rejected:         true,
+			expectedDryRun:   []string{metav1.DryRunAll},
+			expectedErr:      true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			pod := test.BuildTestPod("p1", 100, 0, node.Name, nil)
+			fakeClient := fake.NewSimpleClientset(node, pod)
+			var dryRun []string
+			fakeClient.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() != "eviction" {
+					return false, nil, nil
+				}
+				dryRun = action.(core.CreateAction).GetObject().(*policy.Eviction).DeleteOptions.DryRun
+				if tc.rejected {
+					return true, nil, apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+				}
+				return true, nil, nil
+			})
+
+			podEvictor := NewPodEvictor(fakeClient, "policy/v1", tc.dryRun, nil, nil, []*v1.Node{node}, false, &events.FakeRecorder{})
+			podEvictor.SetServerSideDryRun(tc.serverSideDryRun)
+			err := podEvictor.TryEvictPod(ctx, pod, EvictOptions{})
+			if (err != nil) != tc.expectedErr {
+				t.Errorf("Unexpected eviction error: %v", err)
+			}
+			if !reflect.DeepEqual(dryRun, tc.expectedDryRun) {
+				t.Errorf("Expected the eviction dry run option to be %v, got %v", tc.expectedDryRun, dryRun)
+			}
+		})
+	}
+}
+
+func TestServerSideDryRunDisruptionBudgets(t *testing.T) {
+	ctx := context.Background()
+	node := test.BuildTestNode("node1", 1000, 2000, 20, nil)
+	healthy := func(pod *v1.Pod) {
+		pod.Labels = map[string]string{"app": "web"}
+		pod.Status.Phase = v1.PodRunning
+		pod.Status.Conditions = []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}}
+	}
+	p1 := test.BuildTestPod("p1", 100, 0, node.Name, healthy)
+	p2 := test.BuildTestPod("p2", 100, 0, node.Name, healthy)
+	p3 := test.BuildTestPod("p3", 100, 0, node.Name, healthy)
+	unready := test.BuildTestPod("unready", 100, 0, node.Name, func(pod *v1.Pod) {
+		healthy(pod)
+		pod.Status.Conditions = nil
+	})
+	pdb := &policy.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: p1.Namespace},
+		Spec:       policy.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+		Status:     policy.PodDisruptionBudgetStatus{DisruptionsAllowed: 2},
+	}
+
+	fakeClient := fake.NewSimpleClientset(node, p1, p2, p3, unready, pdb)
+	failSecond := true
+	fakeClient.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		// the apiserver checks every dry run eviction against the budget status, which stays unchanged
+		if action.(core.CreateAction).GetObject().(*policy.Eviction).Name == p2.Name && failSecond {
+			failSecond = false
+			return true, nil, apierrors.NewInternalError(errors.New("admission webhook failed"))
+		}
+		return true, nil, nil
+	})
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	if err := indexer.Add(pdb); err != nil {
+		t.Fatalf("Unable to index the pod disruption budget: %v", err)
+	}
+
+	podEvictor := NewPodEvictor(fakeClient, "policy/v1", true, nil, nil, []*v1.Node{node}, false, &events.FakeRecorder{})
+	podEvictor.SetServerSideDryRun(true)
+	podEvictor.SetDryRunDisruptionBudgets(policylistersv1.NewPodDisruptionBudgetLister(indexer))
+
+	for _, tc := range []struct {
+		pod         *v1.Pod
+		expectedErr bool
+	}{
+		{pod: p1},
+		// a failed eviction consumes no disruption
+		{pod: p2, expectedErr: true},
+		{pod: p2},
+		// the budget allows two disruptions, both consumed
+		{pod: p3, expectedErr: true},
+		// evicting an unhealthy pod consumes no disruption
+		{pod: unready},
+	} {
+		err := podEvictor.TryEvictPod(ctx, tc.pod, EvictOptions{})
+		if (err != nil) != tc.expectedErr {
+			t.Errorf("Unexpected error evicting %v: %v", tc.pod.Name, err)
+		}
+		if tc.pod == p3 && !apierrors.IsTooManyRequests(err) {
+			t.Errorf("Expected the eviction of %v to be blocked by its budget, got %v", tc.pod.Name, err)
+		}
+	}
+}
+
+// fakeRateLimiter accepts as many evictions as it has tokens, waiting for a token fails when the context is done
+type fakeRateLimiter struct {
+	tokens int
+	waits  int
+}
+
+func (l *fakeRateLimiter) TryAccept() bool {
+	if l.tokens > 0 {
+		l.tokens--
+		return true
+	}
+	return false
+}
+
+func (l *fakeRateLimiter) Accept() {}
+
+func (l *fakeRateLimiter) Stop() {}
+
+func (l *fakeRateLimiter) QPS() float32 {
+	return 1
+}
+
+func (l *fakeRateLimiter) Wait(ctx context.Context) error {
+	l.waits++
+	return ctx.Err()
+}
+
+func TestEvictPodRateLimit(t *testing.T) {
+	node := test.BuildTestNode("node1", 1000, 2000, 20, nil)
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		description     string
+		ctx             context.Context
+		dryRun          bool
+		tokens          int
+		expectedEvicted bool
+		expectedWaits   int
+	}{
+		{
+			description:     "eviction allowed by the rate limit",
+			ctx:             context.Background(),
+			tokens:          1,
+			expectedEvicted: true,
+		},
+		{
+			description:     "eviction waiting for the rate limit",
+			ctx:             context.Background(),
+			expectedEvicted: true,
+			expectedWaits:   1,
+		},
+		{
+			description:   "eviction canceled while waiting for the rate limit",
+			ctx:           canceled,
+			expectedWaits: 1,
+		},
+		{
+			description:     "dry run evictions are not paced",
+			ctx:             context.Background(),
+			dryRun:          true,
+			expectedEvicted: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			pod := test.BuildTestPod("p1", 100, 0, node.Name, nil)
+			fakeClient := fake.NewSimpleClientset(node, pod)
+			rateLimiter := &fakeRateLimiter{tokens: tc.tokens}
+
+			podEvictor := NewPodEvictor(fakeClient, "policy/v1", tc.dryRun, nil, nil, []*v1.Node{node}, false, &events.FakeRecorder{})
+			podEvictor.SetRateLimiter(rateLimiter)
+			if evicted := podEvictor.EvictPod(tc.ctx, pod, EvictOptions{}); evicted != tc.expectedEvicted {
+				t.Errorf("Expected the pod to be evicted: %v, got %v", tc.expectedEvicted, evicted)
+			}
+			if rateLimiter.waits != tc.expectedWaits {
+				t.Errorf("Expected %v waits for the rate limit, got %v", tc.expectedWaits, rateLimiter.waits)
+			}
+			if total, expected := podEvictor.TotalEvicted(), uint(len(podEvictor.Evictions())); total != expected {
+				t.Errorf("Expected the eviction counts to match the %v evictions, got %v", expected, total)
+			}
+		})
+	}
+}
+
+func TestEvictionErrorReason(t *testing.T) {
+	gr := schema.GroupResource{Resource: "pods"}
+	tests := []struct {
+		description string
+		err         error
+		expected    string
+	}{
+		{
+			description: "eviction blocked by a pod disruption budget",
+			err:         apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0),
+			expected:    "blocked",
+		},
+		{
+			description: "pod gone",
+			err:         fmt.Errorf("pod not found when evicting %q: %w", "p1", apierrors.NewNotFound(gr, "p1")),
+			expected:    "not found",
+		},
+		{
+			description: "eviction not allowed by the RBAC",
+			err:         apierrors.NewForbidden(gr, "p1", fmt.Errorf("forbidden")),
+			expected:    "forbidden",
+		},
+		{
+			description: "admission webhook failure",
+			err:         apierrors.NewInternalError(fmt.Errorf("failed calling webhook")),
+			expected:    "internal error",
+		},
+		{
+			description: "unknown error",
+			err:         fmt.Errorf("connection refused"),
+			expected:    "error",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			if reason := evictionErrorReason(tc.err); reason != tc.expected {
+				t.Errorf("Expected the error reason %q, got %q", tc.expected, reason)
+			}
+		})
+	}
+}
+
+func TestEvictPodRetry(t *testing.T) {
+	node := test.BuildTestNode("node1", 1000, 2000, 20, nil)
+	blocked := apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+	notFound := apierrors.NewNotFound(schema.GroupResource{Resource: "pods"}, "p1")
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "p1", fmt.Errorf("forbidden"))
+
+	tests := []struct {
+		description string
+		deadline    time.Duration
+		dryRun      bool
+		// errs are the errors of the consecutive eviction requests, the last one repeating
+		errs             []error
+		expectedQueued   bool
+		expectedRequests int
+		expectedErr      func(error) bool
+		expectedEvicted  uint
+	}{
+		{
+			description:      "eviction succeeding once no longer blocked",
+			deadline:         time.Minute,
+			errs:             []error{blocked, blocked, nil},
+			expectedQueued:   true,
+			expectedRequests: 3,
+			expectedEvicted:  1,
+		},
+		{
+			description:    "eviction blocked past the deadline",
+			deadline:       50 * time.Millisecond,
+			errs:           []error{blocked},
+			expectedQueued: true,
+			expectedErr:    apierrors.IsTooManyRequests,
+		},
+		{
+			description:      "retries stopping once the pod is gone",
+			deadline:         time.Minute,
+			errs:             []error{blocked, notFound},
+			expectedQueued:   true,
+			expectedRequests: 2,
+			expectedErr:      apierrors.IsNotFound,
+		},
+		{
+			description:      "forbidden eviction not retried",
+			deadline:         time.Minute,
+			errs:             []error{forbidden},
+			expectedRequests: 1,
+			expectedErr:      apierrors.IsForbidden,
+		},
+		{
+			description:      "blocked eviction not retried without a deadline",
+			errs:             []error{blocked},
+			expectedRequests: 1,
+			expectedErr:      apierrors.IsTooManyRequests,
+		},
+		{
+			description:      "blocked eviction not retried in the dry run mode",
+			deadline:         time.Minute,
+			dryRun:           true,
+			errs:             []error{blocked},
+			expectedRequests: 1,
+			expectedErr:      apierrors.IsTooManyRequests,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			pod := test.BuildTestPod("p1", 100, 0, node.Name, nil)
+			fakeClient := fake.NewSimpleClientset(node, pod)
+			requests := 0
+			fakeClient.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() != "eviction" {
+					return false, nil, nil
+				}
+				err := tc.errs[len(tc.errs)-1]
+				if requests < len(tc.errs) {
+					err = tc.errs[requests]
+				}
+				requests++
+				return true, nil, err
+			})
+
+			podEvictor := NewPodEvictor(fakeClient, "policy/v1", tc.dryRun, nil, nil, []*v1.Node{node}, false, &events.FakeRecorder{})
+			podEvictor.SetServerSideDryRun(tc.dryRun)
+			podEvictor.SetRetry(time.Millisecond, tc.deadline)
+			var finishedErr error
+			finished := 0
+			ctx := WithRetryFinished(context.Background(), func(_ context.Context, err error) {
+				finished++
+				finishedErr = err
+			})
+			err := podEvictor.TryEvictPod(ctx, pod, EvictOptions{})
+			if queued := errors.Is(err, ErrEvictionQueued); queued != tc.expectedQueued {
+				t.Fatalf("Expected the eviction queued to be %v, got %v", tc.expectedQueued, err)
+			}
+			if tc.expectedQueued {
+				// the queued eviction stays reserved against the limits until retried
+				if total := podEvictor.TotalEvicted(); total != 1 {
+					t.Errorf("Expected the queued eviction to be reserved, got %v pods evicted", total)
+				}
+				outcomes := podEvictor.RetryQueued(context.Background())
+				if len(outcomes) != 1 || outcomes[0].Pod != pod {
+					t.Fatalf("Expected the outcome of the queued eviction, got %v", outcomes)
+				}
+				if finished != 1 || finishedErr != outcomes[0].Err {
+					t.Errorf("Expected the final outcome %v to be reported once, got %v reported %v times", outcomes[0].Err, finishedErr, finished)
+				}
+				err = outcomes[0].Err
+			}
+			if outcomes := podEvictor.RetryQueued(context.Background()); len(outcomes) != 0 {
+				t.Errorf("Expected no eviction left to retry, got %v", outcomes)
+			}
+			if tc.expectedErr == nil && err != nil {
+				t.Errorf("Expected the eviction to succeed, got %v", err)
+			}
+			if tc.expectedErr != nil && !tc.expectedErr(err) {
+				t.Errorf("Unexpected eviction error: %v", err)
+			}
+			if tc.expectedRequests > 0 && requests != tc.expectedRequests {
+				t.Errorf("Expected %v eviction requests, got %v", tc.expectedRequests, requests)
+			}
+			if total := podEvictor.TotalEvicted(); total != tc.expectedEvicted {
+				t.Errorf("Expected %v pods evicted, got %v", tc.expectedEvicted, total)
+			}
+			if evictions := uint(len(podEvictor.Evictions())); evictions != tc.expectedEvicted {
+				t.Errorf("Expected %v evictions recorded, got %v", tc.expectedEvicted, evictions)
+			}
+		})
+	}
+}
+
+func TestEvictPodGracePeriod(t *testing.T) {
+	node := test.BuildTestNode("node1", 1000, 2000, 20, nil)
+	tests := []struct {
+		description         string
+		policyGracePeriod   *int64
+		optsGracePeriod     *int64
+		expectedGracePeriod *int64
+	}{
+		{
+			description: "pod terminating within its own grace period",
+		},
+		{
+			description:         "grace period of the policy",
+			policyGracePeriod:   utilpointer.Int64(30),
+			expectedGracePeriod: utilpointer.Int64(30),
+		},
+		{
+			description:         "grace period of the eviction options taking precedence",
+			policyGracePeriod:   utilpointer.Int64(30),
+			optsGracePeriod:     utilpointer.Int64(0),
+			expectedGracePeriod: utilpointer.Int64(0),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			pod := test.BuildTestPod("p1", 100, 0, node.Name, nil)
+			fakeClient := fake.NewSimpleClientset(node, pod)
+			var gracePeriod *int64
+			fakeClient.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() != "eviction" {
+					return false, nil, nil
+				}
+				gracePeriod = action.(core.CreateAction).GetObject().(*policy.Eviction).DeleteOptions.GracePeriodSeconds
+				return true, nil, nil
+			})
+
+			podEvictor := NewPodEvictor(fakeClient, "policy/v1", false, nil, nil, []*v1.Node{node}, false, &events.FakeRecorder{})
+			podEvictor.SetGracePeriodSeconds(tc.policyGracePeriod)
+			if err := podEvictor.TryEvictPod(context.Background(), pod, EvictOptions{GracePeriodSeconds: tc.optsGracePeriod}); err != nil {
+				t.Fatalf("Unexpected eviction error: %v", err)
+			}
+			if !reflect.DeepEqual(gracePeriod, tc.expectedGracePeriod) {
+				t.Errorf("Expected the grace period %v, got %v", tc.expectedGracePeriod, gracePeriod)
+			}
+		})
+	}
+}
+
+func TestEvictPodDeleteFallback(t *testing.T) {
+	node := test.BuildTestNode("node1", 1000, 2000, 20, nil)
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Resource: "pods/eviction"}, "p1", fmt.Errorf("evictions are disallowed"))
+	blocked := apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+
+	tests := []struct {
+		description               string
+		podPhases                 []v1.PodPhase
+		removeFinalizers          bool
+		phase                     v1.PodPhase
+		evictionErr               error
+		deleteErr                 error
+		expectedDeleted           bool
+		expectedEvicted           bool
+		expectedFinalizersRemoved bool
+	}{
+		{
+			description:     "failed pod deleted once its eviction is forbidden",
+			podPhases:       []v1.PodPhase{v1.PodFailed},
+			phase:           v1.PodFailed,
+			evictionErr:     forbidden,
+			expectedDeleted: true,
+			expectedEvicted: true,
+		},
+		{
+			description: "running pod not deleted when the fallback is limited to failed pods",
+			podPhases:   []v1.PodPhase{v1.PodFailed},
+			phase:       v1.PodRunning,
+			evictionErr: forbidden,
+		},
+		{
+			description: "pod blocked by a pod disruption budget never deleted",
+			podPhases:   []v1.PodPhase{v1.PodFailed, v1.PodRunning},
+			phase:       v1.PodRunning,
+			evictionErr: blocked,
+		},
+		{
+			description: "pod not deleted without the fallback",
+			phase:       v1.PodFailed,
+			evictionErr: forbidden,
+		},
+		{
+			description:     "failed deletion",
+			podPhases:       []v1.PodPhase{v1.PodFailed},
+			phase:           v1.PodFailed,
+			evictionErr:     forbidden,
+			deleteErr:       apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "p1", fmt.Errorf("deletions are disallowed")),
+			expectedDeleted: true,
+		},
+		{
+			description:     "finalizers of an evicted failed pod kept by default",
+			podPhases:       []v1.PodPhase{v1.PodFailed},
+			phase:           v1.PodFailed,
+			expectedEvicted: true,
+		},
+		{
+			description:               "finalizers of an evicted failed pod removed",
+			podPhases:                 []v1.PodPhase{v1.PodFailed},
+			removeFinalizers:          true,
+			phase:                     v1.PodFailed,
+			expectedEvicted:           true,
+			expectedFinalizersRemoved: true,
+		},
+		{
+			description:               "finalizers of a deleted failed pod removed",
+			podPhases:                 []v1.PodPhase{v1.PodFailed},
+			removeFinalizers:          true,
+			phase:                     v1.PodFailed,
+			evictionErr:               forbidden,
+			expectedDeleted:           true,
+			expectedEvicted:           true,
+			expectedFinalizersRemoved: true,
+		},
+		{
+			description:      "finalizers of an evicted pod outside of the fallback phases kept",
+			podPhases:        []v1.PodPhase{v1.PodFailed},
+			removeFinalizers: true,
+			phase:            v1.PodSucceeded,
+			expectedEvicted:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			pod := test.BuildTestPod("p1", 100, 0, node.Name, func(pod *v1.Pod) {
+				pod.UID = "p1-uid"
+				pod.Status.Phase = tc.phase
+				pod.Finalizers = []string{"example.com/cleanup"}
+			})
+			fakeClient := fake.NewSimpleClientset(node, pod)
+			fakeClient.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() != "eviction" {
+					return false, nil, nil
+				}
+				// The pod is kept around by its finalizers
+				return true, nil, tc.evictionErr
+			})
+			deleted := false
+			fakeClient.PrependReactor("delete", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				deleted = true
+				return true, nil, tc.deleteErr
+			})
+
+			podEvictor := NewPodEvictor(fakeClient, "policy/v1", false, nil, nil, []*v1.Node{node}, false, &events.FakeRecorder{})
+			podEvictor.SetDeleteFallback(tc.podPhases, tc.removeFinalizers)
+			if evicted := podEvictor.EvictPod(context.Background(), pod, EvictOptions{}); evicted != tc.expectedEvicted {
+				t.Errorf("Expected the pod to be evicted: %v, got %v", tc.expectedEvicted, evicted)
+			}
+			if deleted != tc.expectedDeleted {
+				t.Errorf("Expected the pod to be deleted: %v, got %v", tc.expectedDeleted, deleted)
+			}
+			if total, expected := podEvictor.TotalEvicted(), uint(len(podEvictor.Evictions())); total != expected {
+				t.Errorf("Expected the eviction counts to match the %v evictions, got %v", expected, total)
+			}
+			current, err := fakeClient.CoreV1().Pods(pod.Namespace).Get(context.Background(), pod.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Unable to get the pod: %v", err)
+			}
+			if removed := len(current.Finalizers) == 0; removed != tc.expectedFinalizersRemoved {
+				t.Errorf("Expected the pod finalizers to be removed: %v, got %v", tc.expectedFinalizersRemoved, current.Finalizers)
+			}
+		})
+	}
+}
+
+func TestPodWorkload(t *testing.T) {
+	controller := func(kind, name string) func(*v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: kind, Name: name, Controller: utilpointer.Bool(true)}}
+		}
+	}
+	tests := []struct {
+		description string
+		apply       func(*v1.Pod)
+		expected    Workload
+		owned       bool
+	}{
+		{
+			description: "pod of a deployment",
+			apply: func(pod *v1.Pod) {
+				controller("ReplicaSet", "web-5d8f7b")(pod)
+				pod.Labels = map[string]string{appsv1.DefaultDeploymentUniqueLabelKey: "5d8f7b"}
+			},
+			expected: Workload{Kind: "Deployment", Namespace: "default", Name: "web"},
+			owned:    true,
+		},
+		{
+			description: "pod of a replicaset",
+			apply:       controller("ReplicaSet", "web"),
+			expected:    Workload{Kind: "ReplicaSet", Namespace: "default", Name: "web"},
+			owned:       true,
+		},
+		{
+			description: "pod of a statefulset",
+			apply:       controller("StatefulSet", "db"),
+			expected:    Workload{Kind: "StatefulSet", Namespace: "default", Name: "db"},
+			owned:       true,
+		},
+		{
+			description: "pod of a daemonset",
+			apply:       controller("DaemonSet", "agent"),
+		},
+		{
+			description: "bare pod",
+			apply:       func(pod *v1.Pod) {},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			pod := test.BuildTestPod("p1", 100, 0, "node1", tc.apply)
+			workload, owned := PodWorkload(pod)
+			if workload != tc.expected || owned != tc.owned {
+				t.Errorf("Expected the workload %v (%v), got %v (%v)", tc.expected, tc.owned, workload, owned)
+			}
+		})
+	}
+}
+
+func TestEvictPodWorkloadBudget(t *testing.T) {
+	node := test.BuildTestNode("node1", 1000, 2000, 20, nil)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+		Spec:       appsv1.DeploymentSpec{Replicas: utilpointer.Int32(20)},
+	}
+	podOf := func(name, owner string) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, node.Name, func(pod *v1.Pod) {
+			if owner != "" {
+				pod.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: owner + "-5d8f7b", Controller: utilpointer.Bool(true)}}
+				pod.Labels = map[string]string{appsv1.DefaultDeploymentUniqueLabelKey: "5d8f7b"}
+			}
+		})
+	}
+	web := []*v1.Pod{podOf("web-1", "web"), podOf("web-2", "web"), podOf("web-3", "web")}
+	api := podOf("api-1", "api")
+	bare := podOf("bare", "")
+
+	tests := []struct {
+		description  string
+		maxEvictions intstr.IntOrString
+		pods         []*v1.Pod
+		evictionErr  error
+		expected     []string
+	}{
+		{
+			description:  "absolute budget per workload",
+			maxEvictions: intstr.FromInt(2),
+			pods:         append(web, api),
+			expected:     []string{"web-1", "web-2", "api-1"},
+		},
+		{
+			description:  "percentage of the replicas rounded up",
+			maxEvictions: intstr.FromString("5%"),
+			pods:         web,
+			expected:     []string{"web-1"},
+		},
+		{
+			description:  "workload replicas not found",
+			maxEvictions: intstr.FromString("5%"),
+			pods:         []*v1.Pod{api},
+		},
+		{
+			description:  "bare pods not budgeted",
+			maxEvictions: intstr.FromInt(1),
+			pods:         []*v1.Pod{bare},
+			expected:     []string{"bare"},
+		},
+		{
+			description:  "failed evictions not counted",
+			maxEvictions: intstr.FromInt(1),
+			pods:         web,
+			evictionErr:  apierrors.NewInternalError(fmt.Errorf("webhook failed")),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			fakeClient := fake.NewSimpleClientset(node)
+			fakeClient.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() != "eviction" {
+					return false, nil, nil
+				}
+				return true, nil, tc.evictionErr
+			})
+			deployments := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			if err := deployments.Add(deployment); err != nil {
+				t.Fatalf("Unable to add the deployment: %v", err)
+			}
+			listers := WorkloadListers{Deployments: appsv1listers.NewDeploymentLister(deployments)}
+			budget := NewWorkloadBudget(listers, tc.maxEvictions, 30*time.Minute, testingclock.NewFakePassiveClock(time.Now()))
+
+			podEvictor := NewPodEvictor(fakeClient, "policy/v1", false, nil, nil, []*v1.Node{node}, false, &events.FakeRecorder{})
+			podEvictor.SetWorkloadBudget(budget)
+			var evicted []string
+			for _, pod := range tc.pods {
+				if podEvictor.EvictPod(context.Background(), pod, EvictOptions{}) {
+					evicted = append(evicted, pod.Name)
+				}
+			}
+			if !reflect.DeepEqual(evicted, tc.expected) {
+				t.Errorf("Expected the pods %v to be evicted, got %v", tc.expected, evicted)
+			}
+			if tc.evictionErr != nil && len(budget.History()) != 0 {
+				t.Errorf("Expected no eviction to be recorded, got %v", budget.History())
+			}
+		})
+	}
+}
+
+func TestWorkloadBudgetWindow(t *testing.T) {
+	node := test.BuildTestNode("node1", 1000, 2000, 20, nil)
+	pod := test.BuildTestPod("web-1", 100, 0, node.Name, func(pod *v1.Pod) {
+		pod.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "web", Controller: utilpointer.Bool(true)}}
+	})
+	fakeClient := fake.NewSimpleClientset(node)
+	fakeClient.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		return action.GetSubresource() == "eviction", nil, nil
+	})
+	fakeClock := testingclock.NewFakePassiveClock(time.Now())
+	budget := NewWorkloadBudget(WorkloadListers{}, intstr.FromInt(1), 30*time.Minute, fakeClock)
+
+	evict := func() bool {
+		podEvictor := NewPodEvictor(fakeClient, "policy/v1", false, nil, nil, []*v1.Node{node}, false, &events.FakeRecorder{})
+		podEvictor.SetWorkloadBudget(budget)
+		return podEvictor.EvictPod(context.Background(), pod, EvictOptions{})
+	}
+	if !evict() {
+		t.Fatalf("Expected the first eviction to succeed")
+	}
+
+	// the history of a previous run holds the budget
+	restored := NewWorkloadBudget(WorkloadListers{}, intstr.FromInt(1), 30*time.Minute, fakeClock)
+	restored.Merge(budget.History())
+	// merging a history again records nothing new
+	restored.Merge(budget.History())
+	budget = restored
+	fakeClock.SetTime(fakeClock.Now().Add(20 * time.Minute))
+	if err := budget.Check(pod); !errors.Is(err, errWorkloadBudgetExhausted) {
+		t.Errorf("Expected the check within the window to report the exhausted budget, got %v", err)
+	}
+	if evict() {
+		t.Errorf("Expected the eviction within the window to be rejected")
+	}
+	dryRun := budget.Copy()
+	fakeClock.SetTime(fakeClock.Now().Add(20 * time.Minute))
+	if err := budget.Check(pod); err != nil {
+		t.Errorf("Expected the check past the window to allow the eviction, got %v", err)
+	}
+	if !evict() {
+		t.Errorf("Expected the eviction past the window to succeed")
+	}
+	if history := dryRun.History(); len(history) != 0 {
+		t.Errorf("Expected the copy to be unaffected past the window, got %v", history)
+	}
+	if history := budget.History(); len(history) != 1 || len(history[0].Times) != 1 {
+		t.Errorf("Expected a single eviction within the window, got %v", history)
+	}
+}
+
+func TestWorkloadBudgetRelease(t *testing.T) {
+	workload := Workload{Kind: "StatefulSet", Namespace: "default", Name: "web"}
+	fakeClock := testingclock.NewFakePassiveClock(time.Now())
+	budget := NewWorkloadBudget(WorkloadListers{}, intstr.FromInt(2), 30*time.Minute, fakeClock)
+
+	first, err := budget.reserve(workload)
+	if err != nil {
+		t.Fatalf("Unable to reserve the first eviction: %v", err)
+	}
+	fakeClock.SetTime(fakeClock.Now().Add(time.Minute))
+	second, err := budget.reserve(workload)
+	if err != nil {
+		t.Fatalf("Unable to reserve the second eviction: %v", err)
+	}
+
+	// releasing the first reservation keeps the second one
+	budget.release(workload, first)
+	history := budget.History()
+	if len(history) != 1 || len(history[0].Times) != 1 || !history[0].Times[0].Time.Equal(second) {
+		t.Errorf("Expected only the eviction reserved at %v to be kept, got %v", second, history)
+	}
+}
+
+func TestEvictPodCooldown(t *testing.T) {
+	node1 := test.BuildTestNode("node1", 1000, 2000, 20, nil)
+	node2 := test.BuildTestNode("node2", 1000, 2000, 20, nil)
+	evictedAt := time.Now()
+	podOf := func(name, owner, nodeName string) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, nodeName, func(pod *v1.Pod) {
+			pod.CreationTimestamp = metav1.NewTime(evictedAt.Add(-time.Hour))
+			if owner != "" {
+				pod.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "DaemonSet", Name: owner, Controller: utilpointer.Bool(true)}}
+			}
+		})
+	}
+	// agent-1 got evicted from node1 by the previous loop, agent-2 is its replacement created afterwards
+	previous := podOf("agent-1", "agent", node1.Name)
+	replacement := podOf("agent-2", "agent", node1.Name)
+	replacement.CreationTimestamp = metav1.NewTime(evictedAt.Add(time.Second))
+
+	tests := []struct {
+		description     string
+		acrossNodes     bool
+		elapsed         time.Duration
+		pods            []*v1.Pod
+		expected        []string
+		expectedFlapped bool
+	}{
+		{
+			description:     "replacement on the same node flapping",
+			pods:            []*v1.Pod{replacement},
+			expectedFlapped: true,
+		},
+		{
+			description: "pod of the owner on the same node older than the eviction",
+			pods:        []*v1.Pod{podOf("agent-3", "agent", node1.Name)},
+		},
+		{
+			description: "pod of the owner on another node",
+			pods:        []*v1.Pod{podOf("agent-3", "agent", node2.Name)},
+			expected:    []string{"agent-3"},
+		},
+		{
+			description: "pod of the owner on another node cooling down across nodes",
+			acrossNodes: true,
+			pods:        []*v1.Pod{podOf("agent-3", "agent", node2.Name)},
+		},
+		{
+			description: "pods of the same owner evicted within the same loop",
+			pods:        []*v1.Pod{podOf("other-1", "other", node1.Name), podOf("other-2", "other", node1.Name)},
+			expected:    []string{"other-1", "other-2"},
+		},
+		{
+			description: "bare pod",
+			pods:        []*v1.Pod{podOf("bare", "", node1.Name)},
+			expected:    []string{"bare"},
+		},
+		{
+			description: "cooldown over",
+			elapsed:     time.Hour,
+			pods:        []*v1.Pod{replacement},
+			expected:    []string{"agent-2"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			fakeClient := fake.NewSimpleClientset(node1, node2)
+			fakeClient.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				return action.GetSubresource() == "eviction", nil, nil
+			})
+			fakeClock := testingclock.NewFakePassiveClock(evictedAt)
+			cooldown := NewCooldown(time.Hour, tc.acrossNodes, fakeClock)
+			cooldown.record(previous, evictedAt)
+			fakeClock.SetTime(fakeClock.Now().Add(tc.elapsed))
+			if err := cooldown.Check(tc.pods[0]); (err == nil) != (len(tc.expected) > 0) {
+				t.Errorf("Unexpected cooldown check of the pod %v: %v", tc.pods[0].Name, err)
+			}
+
+			recorder := events.NewFakeRecorder(10)
+			podEvictor := NewPodEvictor(fakeClient, "policy/v1", false, nil, nil, []*v1.Node{node1, node2}, false, recorder)
+			podEvictor.SetCooldown(cooldown)
+			var evicted []string
+			for _, pod := range tc.pods {
+				if podEvictor.EvictPod(context.Background(), pod, EvictOptions{}) {
+					evicted = append(evicted, pod.Name)
+				}
+			}
+			if !reflect.DeepEqual(evicted, tc.expected) {
+				t.Errorf("Expected the pods %v to be evicted, got %v", tc.expected, evicted)
+			}
+			close(recorder.Events)
+			flapped := false
+			for event := range recorder.Events {
+				flapped = flapped || strings.HasPrefix(event, v1.EventTypeWarning+" EvictionFlapping ")
+			}
+			if flapped != tc.expectedFlapped {
+				t.Errorf("Expected a flapping event: %v, got %v", tc.expectedFlapped, flapped)
+			}
+
+			// the evictions start the cooldown for the next pod evictors as they succeed
+			podEvictor = NewPodEvictor(fakeClient, "policy/v1", false, nil, nil, []*v1.Node{node1, node2}, false, &events.FakeRecorder{})
+			podEvictor.SetCooldown(cooldown)
+			for _, pod := range tc.pods {
+				if _, owned := PodOwner(pod); owned && podEvictor.EvictPod(context.Background(), pod, EvictOptions{}) {
+					t.Errorf("Expected the pod %v to be cooling down", pod.Name)
+				}
+			}
+		})
+	}
+}
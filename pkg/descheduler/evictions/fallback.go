@@ -0,0 +1,110 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/metrics"
+)
+
+// deleteFallbackApplies tells whether a pod whose eviction failed is to be deleted instead.
+// Only the evictions disallowed, i.e. rejected as forbidden or not supported, of the pods
+// in the phases the fallback is enabled for fall back to the deletion.
+func (pe *PodEvictor) deleteFallbackApplies(pod *v1.Pod, err error) bool {
+	if !apierrors.IsForbidden(err) && !apierrors.IsMethodNotSupported(err) {
+		return false
+	}
+	return pe.inDeleteFallbackPhase(pod)
+}
+
+// inDeleteFallbackPhase tells whether a pod is in one of the phases the fallback is enabled for
+func (pe *PodEvictor) inDeleteFallbackPhase(pod *v1.Pod) bool {
+	for _, phase := range pe.deleteFallbackPodPhases {
+		if pod.Status.Phase == phase {
+			return true
+		}
+	}
+	return false
+}
+
+// deletePod deletes a pod whose eviction got disallowed. The deletion bypasses the pod disruption budgets.
+func (pe *PodEvictor) deletePod(ctx context.Context, span trace.Span, pod *v1.Pod, opts EvictOptions, evictionErr error) error {
+	span.AddEvent("Eviction Fell Back To Delete", trace.WithAttributes(attribute.String("node", pod.Spec.NodeName), attribute.String("err", evictionErr.Error())))
+	klog.V(2).InfoS("Eviction disallowed, deleting the pod", "pod", klog.KObj(pod), "err", evictionErr, "phase", pod.Status.Phase, "profile", opts.ProfileName, "strategy", opts.PluginName, "extension point", opts.ExtensionPoint)
+
+	deleteOptions := metav1.DeleteOptions{
+		GracePeriodSeconds: opts.GracePeriodSeconds,
+		// The pod may have been recreated under the same name meanwhile
+		Preconditions: metav1.NewUIDPreconditions(string(pod.UID)),
+	}
+	if pe.dryRun && pe.serverSideDryRun {
+		deleteOptions.DryRun = []string{metav1.DryRunAll}
+	}
+	err := pe.client.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, deleteOptions)
+	if pe.metricsEnabled {
+		result := "success"
+		if err != nil {
+			result = evictionErrorReason(err)
+		}
+		metrics.PodsDeleted.With(map[string]string{"result": result, "strategy": opts.PluginName, "profile": opts.ProfileName, "namespace": pod.Namespace, "node": pod.Spec.NodeName}).Inc()
+	}
+	return err
+}
+
+// removePodFinalizers removes the finalizers of a pod evicted or deleted in one of the phases the fallback
+// is enabled for, when enabled. The pod is gone for the descheduler either way, so a failure is only reported.
+func (pe *PodEvictor) removePodFinalizers(ctx context.Context, span trace.Span, pod *v1.Pod, opts EvictOptions) {
+	if !pe.removeFinalizers || len(pod.Finalizers) == 0 || !pe.inDeleteFallbackPhase(pod) {
+		return
+	}
+	if pe.dryRun && !pe.serverSideDryRun {
+		klog.V(1).InfoS("Removed the pod finalizers in dry run mode", "pod", klog.KObj(pod), "finalizers", pod.Finalizers)
+		return
+	}
+
+	// The pod may have been recreated under the same name meanwhile
+	patch := fmt.Sprintf(`[{"op":"test","path":"/metadata/uid","value":%q},{"op":"remove","path":"/metadata/finalizers"}]`, pod.UID)
+	patchOptions := metav1.PatchOptions{}
+	if pe.dryRun {
+		patchOptions.DryRun = []string{metav1.DryRunAll}
+	}
+	_, err := pe.client.CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, types.JSONPatchType, []byte(patch), patchOptions)
+	if pe.metricsEnabled {
+		result := "success"
+		if err != nil {
+			result = evictionErrorReason(err)
+		}
+		metrics.PodFinalizersRemoved.With(map[string]string{"result": result, "strategy": opts.PluginName, "profile": opts.ProfileName, "namespace": pod.Namespace, "node": pod.Spec.NodeName}).Inc()
+	}
+	if err != nil && !apierrors.IsNotFound(err) {
+		span.AddEvent("Pod Finalizers Not Removed", trace.WithAttributes(attribute.String("node", pod.Spec.NodeName), attribute.String("err", err.Error())))
+		klog.ErrorS(err, "Error removing the pod finalizers", "pod", klog.KObj(pod), "finalizers", pod.Finalizers, "profile", opts.ProfileName, "strategy", opts.PluginName, "extension point", opts.ExtensionPoint)
+		return
+	}
+	span.AddEvent("Pod Finalizers Removed", trace.WithAttributes(attribute.String("node", pod.Spec.NodeName)))
+	klog.V(2).InfoS("Removed the pod finalizers", "pod", klog.KObj(pod), "finalizers", pod.Finalizers, "profile", opts.ProfileName, "strategy", opts.PluginName, "extension point", opts.ExtensionPoint)
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/tracing"
+)
+
+// ErrEvictionQueued is returned by TryEvictPod when the eviction got blocked by a pod disruption budget
+// and queued for a retry. RetryQueued retries the queued evictions and reports their final outcome.
+var ErrEvictionQueued = errors.New("eviction queued for a retry")
+
+// RetryFinishedFunc is informed about the final outcome of a queued eviction, err being nil when the pod got evicted
+type RetryFinishedFunc func(ctx context.Context, err error)
+
+// retryFinishedKey is the context key of the RetryFinishedFunc of an eviction
+type retryFinishedKey struct{}
+
+// WithRetryFinished returns a copy of ctx telling the function informed about the final outcome
+// of the evictions requested within ctx, when they get queued for a retry
+func WithRetryFinished(ctx context.Context, finished RetryFinishedFunc) context.Context {
+	return context.WithValue(ctx, retryFinishedKey{}, finished)
+}
+
+// retryFinishedFrom returns the RetryFinishedFunc ctx tells, if any
+func retryFinishedFrom(ctx context.Context) RetryFinishedFunc {
+	finished, _ := ctx.Value(retryFinishedKey{}).(RetryFinishedFunc)
+	return finished
+}
+
+// RetryOutcome is the final outcome of a queued eviction
+type RetryOutcome struct {
+	Pod  *v1.Pod
+	Opts EvictOptions
+	// Attempts is the number of eviction requests sent for the pod, including the one getting it queued
+	Attempts int
+	// Err is nil when the pod got evicted, the reason it did not otherwise
+	Err error
+}
+
+// queuedEviction is an eviction blocked by a pod disruption budget, waiting for a retry.
+// It stays reserved against the limits and the workload budget until its final outcome is known.
+type queuedEviction struct {
+	pod      *v1.Pod
+	opts     EvictOptions
+	reserved time.Time
+	attempts int
+	err      error
+	finished RetryFinishedFunc
+}
+
+// retryable tells whether a failed eviction is to be queued for a retry. The evictions of the dry run mode are not,
+// as the pod disruption budgets blocking them do not change until the evicted pods are actually gone.
+func (pe *PodEvictor) retryable(err error) bool {
+	return pe.retryDeadline > 0 && !pe.dryRun && apierrors.IsTooManyRequests(err)
+}
+
+// queueEviction queues the eviction of a pod blocked by a pod disruption budget for a retry
+func (pe *PodEvictor) queueEviction(ctx context.Context, span trace.Span, pod *v1.Pod, opts EvictOptions, reserved time.Time, err error) error {
+	span.AddEvent("Eviction Queued", trace.WithAttributes(attribute.String("node", pod.Spec.NodeName), attribute.String("err", err.Error())))
+	klog.V(2).InfoS("Eviction blocked, queued for a retry", "pod", klog.KObj(pod), "err", err, "profile", opts.ProfileName, "strategy", opts.PluginName, "extension point", opts.ExtensionPoint)
+
+	pe.lock.Lock()
+	defer pe.lock.Unlock()
+	pe.retryQueue = append(pe.retryQueue, &queuedEviction{
+		pod:      pod,
+		opts:     opts,
+		reserved: reserved,
+		attempts: 1,
+		err:      err,
+		finished: retryFinishedFrom(ctx),
+	})
+	return fmt.Errorf("%w: %v", ErrEvictionQueued, err)
+}
+
+// RetryQueued retries the queued evictions with an exponential backoff until they are no longer blocked by
+// a pod disruption budget, the retry deadline passes or ctx is done, and returns their final outcomes.
+// The evictions are retried one after another, in the order they got queued, so the retries are paced
+// by the rate limiter as any eviction is. It is meant to be called once the plugins ran.
+func (pe *PodEvictor) RetryQueued(ctx context.Context) []RetryOutcome {
+	pe.lock.Lock()
+	queue := pe.retryQueue
+	pe.retryQueue = nil
+	pe.lock.Unlock()
+	if len(queue) == 0 {
+		return nil
+	}
+
+	var span trace.Span
+	ctx, span = tracing.Tracer().Start(ctx, "RetryQueued", trace.WithAttributes(attribute.Int("evictions", len(queue)), attribute.String("operation", tracing.EvictOperation)))
+	defer span.End()
+	klog.V(1).InfoS("Retrying the evictions blocked by a pod disruption budget", "evictions", len(queue), "deadline", pe.retryDeadline)
+
+	outcomes := make([]RetryOutcome, 0, len(queue))
+	deadline := time.NewTimer(pe.retryDeadline)
+	defer deadline.Stop()
+	backoff := pe.retryBackoff
+	for len(queue) > 0 {
+		var stopped string
+		select {
+		case <-ctx.Done():
+			stopped = "retries interrupted"
+		case <-deadline.C:
+			stopped = fmt.Sprintf("within %v", pe.retryDeadline)
+		case <-time.After(backoff):
+		}
+		if stopped != "" {
+			for _, q := range queue {
+				outcomes = append(outcomes, pe.retryFinished(ctx, span, q, fmt.Errorf("eviction still blocked after %d attempts %s: %w", q.attempts, stopped, q.err)))
+			}
+			break
+		}
+		backoff *= 2
+
+		var blocked []*queuedEviction
+		for _, q := range queue {
+			err := pe.waitForRateLimit(ctx, span, q.pod, q.opts)
+			if err == nil {
+				q.attempts++
+				err = pe.sendEviction(ctx, q.pod, q.opts)
+				klog.V(3).InfoS("Eviction retried", "pod", klog.KObj(q.pod), "attempt", q.attempts, "err", err)
+				if apierrors.IsTooManyRequests(err) {
+					q.err = err
+					blocked = append(blocked, q)
+					continue
+				}
+				if err != nil {
+					err = fmt.Errorf("eviction failed after %d attempts: %w", q.attempts, err)
+				}
+			}
+			outcomes = append(outcomes, pe.retryFinished(ctx, span, q, err))
+		}
+		queue = blocked
+	}
+	return outcomes
+}
+
+// retryFinished reports the final outcome of a queued eviction, through the metrics and the events
+// as any eviction, and to the RetryFinishedFunc of the eviction if any
+func (pe *PodEvictor) retryFinished(ctx context.Context, span trace.Span, q *queuedEviction, err error) RetryOutcome {
+	err = pe.finishEviction(ctx, span, q.pod, q.opts, q.reserved, err)
+	if err == nil {
+		klog.V(2).InfoS("Retried eviction succeeded", "pod", klog.KObj(q.pod), "attempts", q.attempts)
+	}
+	if q.finished != nil {
+		q.finished(ctx, err)
+	}
+	return RetryOutcome{Pod: q.pod, Opts: q.opts, Attempts: q.attempts, Err: err}
+}
@@ -0,0 +1,319 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
+	frameworkprofile "sigs.k8s.io/descheduler/pkg/framework/profile"
+)
+
+// ExplainPath is the path of the debug endpoint explaining the pods
+const ExplainPath = "/debug/explain"
+
+// PodExplanation explains why the descheduler would or would not evict a pod
+type PodExplanation struct {
+	// Pod is the pod explained, as namespace/name
+	Pod  string `json:"pod"`
+	Node string `json:"node,omitempty"`
+	// NodeProcessed tells whether the node of the pod is among the ready nodes
+	// matching the node selector of the policy, the only ones the plugins process
+	NodeProcessed bool `json:"nodeProcessed"`
+	// Profiles lists the verdicts of the plugins of every profile about the pod
+	Profiles []ProfileExplanation `json:"profiles"`
+	// Limits lists the verdicts of the limits the descheduler enforces across its loops about evicting the pod now
+	Limits []LimitExplanation `json:"limits"`
+}
+
+// ProfileExplanation lists the verdicts of the plugins of a profile about a pod
+type ProfileExplanation struct {
+	Profile string `json:"profile"`
+	// Error tells why the profile could not be built, in which case it has no verdicts
+	Error    string                     `json:"error,omitempty"`
+	Verdicts []frameworkprofile.Verdict `json:"verdicts"`
+}
+
+// LimitExplanation tells whether a limit the descheduler enforces across its loops allows evicting a pod now
+type LimitExplanation struct {
+	Limit   string `json:"limit"`
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// evictionLimits holds the limits the descheduler enforces across its loops, as built for the policy of a loop
+type evictionLimits struct {
+	rateLimiter    flowcontrol.RateLimiter
+	workloadBudget *evictions.WorkloadBudget
+	cooldown       *evictions.Cooldown
+}
+
+// evictionLimits returns the limits the descheduler enforces across its loops for its policy
+func (d *descheduler) evictionLimits() evictionLimits {
+	return evictionLimits{
+		rateLimiter:    d.rateLimiter(),
+		workloadBudget: d.workloadEvictionBudget(),
+		cooldown:       d.evictionCooldown(),
+	}
+}
+
+// ExplainPod evaluates a pod against every profile of the policy and the limits it sets,
+// against the objects served by the client, to tell why the pod would or would not get evicted.
+func ExplainPod(ctx context.Context, client clientset.Interface, deschedulerPolicy *api.DeschedulerPolicy, pod types.NamespacedName) (*PodExplanation, error) {
+	rs, err := explainServer(client)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sharedInformerFactory := informers.NewSharedInformerFactory(client, 0)
+	descheduler, err := newDescheduler(ctx, rs, deschedulerPolicy, policy.SchemeGroupVersion.String(), &events.FakeRecorder{}, sharedInformerFactory)
+	if err != nil {
+		return nil, err
+	}
+	sharedInformerFactory.Start(ctx.Done())
+	sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+	return descheduler.explainPod(ctx, pod, descheduler.evictionLimits())
+}
+
+// explainServer returns the options of a descheduler explaining pods, which never evicts them
+func explainServer(client clientset.Interface) (*options.DeschedulerServer, error) {
+	rs, err := options.NewDeschedulerServer()
+	if err != nil {
+		return nil, err
+	}
+	rs.Client = client
+	rs.DryRun = true
+	rs.DisableMetrics = true
+	return rs, nil
+}
+
+// explainPod evaluates a pod against every profile of the policy of the descheduler and the given limits,
+// against the objects of its informers, to tell why the pod would or would not get evicted. Nothing is evicted
+// nor counted against the limits.
+func (d *descheduler) explainPod(ctx context.Context, pod types.NamespacedName, limits evictionLimits) (*PodExplanation, error) {
+	target, err := d.podLister.Pods(pod.Namespace).Get(pod.Name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get pod %v: %w", pod, err)
+	}
+
+	var nodeSelector string
+	if d.deschedulerPolicy.NodeSelector != nil {
+		nodeSelector = *d.deschedulerPolicy.NodeSelector
+	}
+	nodes, err := nodeutil.ReadyNodes(ctx, d.rs.Client, d.nodeLister, nodeSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	explanation := &PodExplanation{
+		Pod:  klog.KObj(target).String(),
+		Node: target.Spec.NodeName,
+	}
+	for _, node := range nodes {
+		if node.Name == target.Spec.NodeName {
+			explanation.NodeProcessed = true
+			break
+		}
+	}
+
+	podEvictor := evictions.NewPodEvictor(d.rs.Client, d.evictionPolicyGroupVersion, true, nil, nil, nodes, false, d.eventRecorder)
+	for _, profile := range d.deschedulerPolicy.Profiles {
+		profileExplanation := ProfileExplanation{Profile: profile.Name}
+		currProfile, err := frameworkprofile.NewProfile(
+			profile,
+			pluginregistry.PluginRegistry,
+			frameworkprofile.WithClientSet(d.rs.Client),
+			frameworkprofile.WithSharedInformerFactory(d.sharedInformerFactory),
+			frameworkprofile.WithPodEvictor(podEvictor),
+			frameworkprofile.WithGetPodsAssignedToNodeFnc(d.getPodsAssignedToNode),
+			frameworkprofile.WithParallelism(d.parallelism()),
+			frameworkprofile.WithContext(ctx),
+		)
+		if err != nil {
+			profileExplanation.Error = err.Error()
+		} else {
+			profileExplanation.Verdicts = currProfile.Explain(target, nodes)
+		}
+		explanation.Profiles = append(explanation.Profiles, profileExplanation)
+	}
+
+	explanation.Limits = explainLimits(target, nodes, limits)
+	return explanation, nil
+}
+
+// explainLimits tells whether the limits enforced across the loops allow evicting the pod now. No pod is evicted
+// from a cluster of a single ready node, which is reported first.
+func explainLimits(pod *v1.Pod, nodes []*v1.Node, limits evictionLimits) []LimitExplanation {
+	var explanations []LimitExplanation
+	if len(nodes) <= 1 {
+		explanations = append(explanations, LimitExplanation{
+			Limit:  "clusterSize",
+			Reason: fmt.Sprintf("%d ready nodes match the node selector, evicting needs at least 2", len(nodes)),
+		})
+	}
+	if limits.rateLimiter != nil {
+		explanations = append(explanations, LimitExplanation{
+			Limit:   "evictionRateLimit",
+			Allowed: true,
+			Reason:  fmt.Sprintf("at most %v evictions per second, the eviction waits for its turn", limits.rateLimiter.QPS()),
+		})
+	}
+	if limits.workloadBudget != nil {
+		explanations = append(explanations, limitExplanation("workloadEvictionBudget", limits.workloadBudget.Check(pod)))
+	}
+	if limits.cooldown != nil {
+		explanations = append(explanations, limitExplanation("evictionCooldown", limits.cooldown.Check(pod)))
+	}
+	return explanations
+}
+
+// limitExplanation explains the result of checking a limit
+func limitExplanation(limit string, err error) LimitExplanation {
+	if err != nil {
+		return LimitExplanation{Limit: limit, Reason: err.Error()}
+	}
+	return LimitExplanation{Limit: limit, Allowed: true}
+}
+
+// ExplainHandler serves the explanation of the pod given as namespace/name by the pod query parameter,
+// against the informers of the running descheduler, and the policy and the limits of its last descheduling loop.
+// Every explanation evaluates the plugins of every profile, so a single one runs at a time and the others
+// are rejected with 429 Too Many Requests meanwhile.
+type ExplainHandler struct {
+	// running holds a token while an explanation runs
+	running chan struct{}
+
+	lock sync.Mutex
+	// descheduler explains the pods against the informers of the running descheduler, nil until it starts
+	descheduler *descheduler
+	policy      *api.DeschedulerPolicy
+	// limits are the rate limiter, the workload budget and the cooldown of the running descheduler,
+	// shared with its loops
+	limits evictionLimits
+}
+
+// NewExplainHandler creates an ExplainHandler serving explanations once the descheduler it is passed to through
+// WithExplainHandler ran a descheduling loop
+func NewExplainHandler() *ExplainHandler {
+	return &ExplainHandler{running: make(chan struct{}, 1)}
+}
+
+// WithExplainHandler makes the handler explain the pods against the running descheduler
+func WithExplainHandler(handler *ExplainHandler) RunOption {
+	return func(o *runOptions) {
+		o.explainHandler = handler
+	}
+}
+
+// setDescheduler shares the informers of the running descheduler with the explanations.
+// It is called before the first loop, as the loops of the client dry run mode replace them.
+func (h *ExplainHandler) setDescheduler(d *descheduler) error {
+	rs, err := explainServer(d.rs.Client)
+	if err != nil {
+		return err
+	}
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.descheduler = &descheduler{
+		rs:                         rs,
+		podLister:                  d.podLister,
+		nodeLister:                 d.nodeLister,
+		namespaceLister:            d.namespaceLister,
+		priorityClassLister:        d.priorityClassLister,
+		pdbLister:                  d.pdbLister,
+		getPodsAssignedToNode:      d.getPodsAssignedToNode,
+		sharedInformerFactory:      d.sharedInformerFactory,
+		evictionPolicyGroupVersion: d.evictionPolicyGroupVersion,
+		eventRecorder:              &events.FakeRecorder{},
+	}
+	return nil
+}
+
+// observeLoop sets the policy and the limits of the last descheduling loop of the descheduler,
+// which the explanations evaluate the pods against. It is called from the loops.
+func (h *ExplainHandler) observeLoop(d *descheduler) {
+	limits := d.evictionLimits()
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.policy = d.deschedulerPolicy
+	h.limits = limits
+}
+
+func (h *ExplainHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(r.URL.Query().Get("pod"))
+	if err != nil || namespace == "" || name == "" {
+		http.Error(w, "the pod query parameter must be set to namespace/name", http.StatusBadRequest)
+		return
+	}
+
+	h.lock.Lock()
+	template, deschedulerPolicy, limits := h.descheduler, h.policy, h.limits
+	h.lock.Unlock()
+	if template == nil || deschedulerPolicy == nil {
+		http.Error(w, "no descheduling loop ran yet", http.StatusServiceUnavailable)
+		return
+	}
+
+	select {
+	case h.running <- struct{}{}:
+		defer func() { <-h.running }()
+	default:
+		w.Header().Set("Retry-After", "1")
+		http.Error(w, "another explanation is running", http.StatusTooManyRequests)
+		return
+	}
+
+	// every explanation evaluates the pod with a descheduler of its own, set with the policy of the last loop
+	descheduler := *template
+	descheduler.deschedulerPolicy = deschedulerPolicy
+	explanation, err := descheduler.explainPod(r.Context(), types.NamespacedName{Namespace: namespace, Name: name}, limits)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if apierrors.IsNotFound(err) {
+			code = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), code)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(explanation); err != nil {
+		klog.ErrorS(err, "Unable to write the pod explanation", "pod", klog.KRef(namespace, name))
+	}
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/informers"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/events"
+	utilpointer "k8s.io/utils/pointer"
+
+	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatingnodetaints"
+	frameworkprofile "sigs.k8s.io/descheduler/pkg/framework/profile"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+func TestExplainPod(t *testing.T) {
+	SetupPlugins()
+	objects, err := decodeSnapshot([]byte(simulateTestSnapshot))
+	if err != nil {
+		t.Fatalf("Unable to decode the snapshot: %v", err)
+	}
+	client := fakeclientset.NewSimpleClientset(objects...)
+
+	policyFile := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(policyFile, []byte(reloaderTestPolicyFor(removepodsviolatingnodetaints.PluginName)), 0o600); err != nil {
+		t.Fatalf("Unable to write the policy: %v", err)
+	}
+	deschedulerPolicy, err := LoadPolicyConfig(policyFile, client, pluginregistry.PluginRegistry)
+	if err != nil {
+		t.Fatalf("Unable to load the policy: %v", err)
+	}
+
+	filterVerdicts := []frameworkprofile.Verdict{
+		{Plugin: defaultevictor.PluginName, ExtensionPoint: frameworktypes.FilterExtensionPoint, Result: frameworkprofile.Accepted},
+		{Plugin: defaultevictor.PluginName, ExtensionPoint: frameworktypes.PreEvictionFilterExtensionPoint, Result: frameworkprofile.Accepted},
+	}
+	tests := []struct {
+		name     string
+		pod      string
+		expected *PodExplanation
+	}{
+		{
+			name: "pod evicted",
+			pod:  "intolerant",
+			expected: &PodExplanation{
+				Pod:           "default/intolerant",
+				Node:          "tainted",
+				NodeProcessed: true,
+				Profiles: []ProfileExplanation{{
+					Profile: "ProfileName",
+					Verdicts: append(filterVerdicts, frameworkprofile.Verdict{
+						Plugin:         removepodsviolatingnodetaints.PluginName,
+						ExtensionPoint: frameworktypes.DescheduleExtensionPoint,
+						Result:         frameworkprofile.Selected,
+						Reason:         `pod does not tolerate the taints [dedicated=infra:NoSchedule] of node "tainted"`,
+					}),
+				}},
+			},
+		},
+		{
+			name: "pod not selected",
+			pod:  "tolerant",
+			expected: &PodExplanation{
+				Pod:           "default/tolerant",
+				Node:          "tainted",
+				NodeProcessed: true,
+				Profiles: []ProfileExplanation{{
+					Profile: "ProfileName",
+					Verdicts: append(filterVerdicts, frameworkprofile.Verdict{
+						Plugin:         removepodsviolatingnodetaints.PluginName,
+						ExtensionPoint: frameworktypes.DescheduleExtensionPoint,
+						Result:         frameworkprofile.NotSelected,
+						Reason:         `pod tolerates the taints of node "tainted"`,
+					}),
+				}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			explanation, err := ExplainPod(context.Background(), client, deschedulerPolicy, types.NamespacedName{Namespace: "default", Name: test.pod})
+			if err != nil {
+				t.Fatalf("Unable to explain the pod: %v", err)
+			}
+			if diff := cmp.Diff(test.expected, explanation); diff != "" {
+				t.Errorf("Unexpected explanation (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	// a single ready node is explained as keeping the pod from being evicted
+	var singleNode []runtime.Object
+	for _, object := range objects {
+		if node, ok := object.(*v1.Node); !ok || node.Name == "tainted" {
+			singleNode = append(singleNode, object)
+		}
+	}
+	explanation, err := ExplainPod(context.Background(), fakeclientset.NewSimpleClientset(singleNode...), deschedulerPolicy, types.NamespacedName{Namespace: "default", Name: "intolerant"})
+	if err != nil {
+		t.Fatalf("Unable to explain the pod of a single node cluster: %v", err)
+	}
+	expectedLimits := []LimitExplanation{{Limit: "clusterSize", Reason: "1 ready nodes match the node selector, evicting needs at least 2"}}
+	if diff := cmp.Diff(expectedLimits, explanation.Limits); diff != "" {
+		t.Errorf("Unexpected limits of a single node cluster (-want +got):\n%s", diff)
+	}
+
+	// a pod of a workload the running descheduler evicted pods of
+	controlled := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "controlled",
+			Namespace:       "default",
+			OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "rs", UID: "rs-uid", Controller: utilpointer.Bool(true)}},
+		},
+		Spec: v1.PodSpec{NodeName: "tainted", Containers: []v1.Container{{Name: "c", Image: "image"}}},
+	}
+	if _, err := client.CoreV1().Pods("default").Create(context.Background(), controlled, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Unable to create the pod: %v", err)
+	}
+
+	handler := NewExplainHandler()
+	serve := func(query string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, ExplainPath+query, nil))
+		return recorder
+	}
+	if code := serve("?pod=default/intolerant").Code; code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status %d before any loop ran, got %d", http.StatusServiceUnavailable, code)
+	}
+	// the explanations share the informers of the running descheduler
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	rs, err := options.NewDeschedulerServer()
+	if err != nil {
+		t.Fatalf("Unable to initialize server: %v", err)
+	}
+	rs.Client = client
+	rs.EnableExplainEndpoint = true
+	sharedInformerFactory := informers.NewSharedInformerFactory(client, 0)
+	running, err := newDescheduler(ctx, rs, deschedulerPolicy, policy.SchemeGroupVersion.String(), &events.FakeRecorder{}, sharedInformerFactory)
+	if err != nil {
+		t.Fatalf("Unable to create a descheduler instance: %v", err)
+	}
+	sharedInformerFactory.Start(ctx.Done())
+	sharedInformerFactory.WaitForCacheSync(ctx.Done())
+	if err := handler.setDescheduler(running); err != nil {
+		t.Fatalf("Unable to set the descheduler: %v", err)
+	}
+	if code := serve("?pod=default/intolerant").Code; code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status %d before any loop ran, got %d", http.StatusServiceUnavailable, code)
+	}
+	handler.observeLoop(running)
+	if code := serve("?pod=intolerant").Code; code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for a pod without namespace, got %d", http.StatusBadRequest, code)
+	}
+	if code := serve("?pod=default/missing").Code; code != http.StatusNotFound {
+		t.Errorf("Expected status %d for a missing pod, got %d", http.StatusNotFound, code)
+	}
+	response := serve("?pod=default/intolerant")
+	if response.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, response.Code, response.Body.String())
+	}
+	explanation = &PodExplanation{}
+	if err := json.Unmarshal(response.Body.Bytes(), explanation); err != nil {
+		t.Fatalf("Unable to decode the explanation: %v", err)
+	}
+	if diff := cmp.Diff(tests[0].expected, explanation); diff != "" {
+		t.Errorf("Unexpected served explanation (-want +got):\n%s", diff)
+	}
+	if running.sharedInformerFactory != sharedInformerFactory {
+		t.Errorf("Expected the explanation to leave the informers of the running descheduler in place")
+	}
+
+	// the limits of the running descheduler are enforced without running a loop
+	evictedAt := metav1.NewTime(time.Now().Add(-time.Minute).Truncate(time.Second))
+	limited := deschedulerPolicy.DeepCopy()
+	limited.WorkloadEvictionBudget = &api.WorkloadEvictionBudget{MaxEvictions: intstr.FromInt(1), WindowSeconds: 1800}
+	limited.EvictionCooldown = &api.EvictionCooldown{WindowSeconds: 3600}
+	running.deschedulerPolicy = limited
+	workload := evictions.Workload{Kind: "ReplicaSet", Namespace: "default", Name: "rs"}
+	running.workloadEvictionBudget().Merge([]evictions.WorkloadEvictions{{Workload: workload, Times: []metav1.Time{evictedAt}}})
+	running.evictionCooldown().Merge([]evictions.OwnerEviction{{Workload: workload, Node: "tainted", Time: evictedAt}})
+	handler.observeLoop(running)
+	response = serve("?pod=default/controlled")
+	if response.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, response.Code, response.Body.String())
+	}
+	explanation = &PodExplanation{}
+	if err := json.Unmarshal(response.Body.Bytes(), explanation); err != nil {
+		t.Fatalf("Unable to decode the explanation: %v", err)
+	}
+	var allowed []bool
+	for _, limit := range explanation.Limits {
+		allowed = append(allowed, limit.Allowed)
+	}
+	if diff := cmp.Diff([]bool{false, false}, allowed); diff != "" {
+		t.Errorf("Expected the workload budget and the cooldown to keep the pod from being evicted (-want +got):\n%s\n%v", diff, explanation.Limits)
+	}
+
+	// a single explanation runs at a time
+	handler.running <- struct{}{}
+	if code := serve("?pod=default/intolerant").Code; code != http.StatusTooManyRequests {
+		t.Errorf("Expected status %d while another explanation runs, got %d", http.StatusTooManyRequests, code)
+	}
+	<-handler.running
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"math"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/descheduler/report"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/nodeutilization"
+)
+
+// reportedResources are the resources the node utilization is reported for
+var reportedResources = []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory, v1.ResourcePods}
+
+// enablesNodeUtilization tells whether any profile of the policy enables a node utilization plugin
+func enablesNodeUtilization(policy *api.DeschedulerPolicy) bool {
+	for _, profile := range policy.Profiles {
+		for _, pluginName := range profile.Plugins.Balance.Enabled {
+			if pluginName == nodeutilization.LowNodeUtilizationPluginName || pluginName == nodeutilization.HighNodeUtilizationPluginName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// nodePods lists the pods assigned to every node
+func nodePods(nodes []*v1.Node, getPodsAssignedToNode podutil.GetPodsAssignedToNodeFunc) map[string][]*v1.Pod {
+	pods := make(map[string][]*v1.Pod, len(nodes))
+	for _, node := range nodes {
+		nodePods, err := podutil.ListPodsOnANode(node.Name, getPodsAssignedToNode, nil)
+		if err != nil {
+			klog.ErrorS(err, "Unable to list the pods of the node for the report", "node", klog.KObj(node))
+			continue
+		}
+		pods[node.Name] = nodePods
+	}
+	return pods
+}
+
+// nodeUtilizationReport reports the utilization of every node before the loop, given the pods
+// assigned to the nodes then, and after the loop, once the pods evicted within the loop are gone
+func nodeUtilizationReport(nodes []*v1.Node, podsBefore map[string][]*v1.Pod, evicted []evictions.Eviction) []report.NodeUtilization {
+	after := podsAfter(podsBefore, evicted)
+	utilization := make([]report.NodeUtilization, 0, len(nodes))
+	for _, node := range nodes {
+		utilization = append(utilization, report.NodeUtilization{
+			Node:   node.Name,
+			Before: resourcePercentages(node, podsBefore[node.Name]),
+			After:  resourcePercentages(node, after[node.Name]),
+		})
+	}
+	return utilization
+}
+
+// podsAfter returns the pods assigned to every node once the evicted pods are gone.
+// Pods are matched by their namespace and name, as the pods of snapshots may have no UID.
+func podsAfter(podsBefore map[string][]*v1.Pod, evicted []evictions.Eviction) map[string][]*v1.Pod {
+	evictedPods := map[types.NamespacedName]bool{}
+	for _, eviction := range evicted {
+		evictedPods[types.NamespacedName{Namespace: eviction.Pod.Namespace, Name: eviction.Pod.Name}] = true
+	}
+	after := make(map[string][]*v1.Pod, len(podsBefore))
+	for nodeName, pods := range podsBefore {
+		for _, pod := range pods {
+			if !evictedPods[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}] {
+				after[nodeName] = append(after[nodeName], pod)
+			}
+		}
+	}
+	return after
+}
+
+// resourcePercentages returns the resources requested by the pods in percents of the node allocatable resources
+func resourcePercentages(node *v1.Node, pods []*v1.Pod) map[v1.ResourceName]float64 {
+	usage := nodeutil.NodeUtilization(pods, reportedResources)
+	percentages := map[v1.ResourceName]float64{}
+	for _, resourceName := range reportedResources {
+		allocatable, ok := node.Status.Allocatable[resourceName]
+		if !ok || allocatable.IsZero() {
+			continue
+		}
+		var percentage float64
+		if resourceName == v1.ResourceCPU {
+			percentage = float64(usage[resourceName].MilliValue()) / float64(allocatable.MilliValue()) * 100
+		} else {
+			percentage = float64(usage[resourceName].Value()) / float64(allocatable.Value()) * 100
+		}
+		percentages[resourceName] = math.Round(percentage*100) / 100
+	}
+	return percentages
+}
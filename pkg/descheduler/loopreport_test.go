@@ -0,0 +1,161 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	"k8s.io/client-go/informers"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/events"
+
+	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	"sigs.k8s.io/descheduler/pkg/descheduler/report"
+	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatingnodetaints"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestLoopReport(t *testing.T) {
+	SetupPlugins()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objects, err := decodeSnapshot([]byte(simulateTestSnapshot))
+	if err != nil {
+		t.Fatalf("Unable to decode the snapshot: %v", err)
+	}
+	// a bare pod gets rejected by the default evictor
+	bare := objects[3].(*v1.Pod).DeepCopy()
+	bare.Name = "bare"
+	bare.OwnerReferences = nil
+	client := fakeclientset.NewSimpleClientset(append(objects, bare)...)
+
+	dir := t.TempDir()
+	policyFile := filepath.Join(dir, "policy.yaml")
+	if err := os.WriteFile(policyFile, []byte(reloaderTestPolicyFor(removepodsviolatingnodetaints.PluginName)), 0o600); err != nil {
+		t.Fatalf("Unable to write the policy: %v", err)
+	}
+	deschedulerPolicy, err := LoadPolicyConfig(policyFile, client, pluginregistry.PluginRegistry)
+	if err != nil {
+		t.Fatalf("Unable to load the policy: %v", err)
+	}
+
+	rs, err := options.NewDeschedulerServer()
+	if err != nil {
+		t.Fatalf("Unable to initialize server: %v", err)
+	}
+	rs.Client = client
+	rs.DryRun = true
+	rs.DisableMetrics = true
+	rs.ReportFile = filepath.Join(dir, "report.json")
+
+	sharedInformerFactory := informers.NewSharedInformerFactory(client, 0)
+	nodeLister := sharedInformerFactory.Core().V1().Nodes().Lister()
+	descheduler, err := newDescheduler(ctx, rs, deschedulerPolicy, policy.SchemeGroupVersion.String(), &events.FakeRecorder{}, sharedInformerFactory)
+	if err != nil {
+		t.Fatalf("Unable to create a descheduler instance: %v", err)
+	}
+	descheduler.reportWriter, err = report.NewWriter(rs.ReportFile, report.FormatJSON)
+	if err != nil {
+		t.Fatalf("Unable to create the report writer: %v", err)
+	}
+	sharedInformerFactory.Start(ctx.Done())
+	sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+	nodes, err := nodeutil.ReadyNodes(ctx, client, nodeLister, "")
+	if err != nil {
+		t.Fatalf("Unable to list the nodes: %v", err)
+	}
+	if _, err := descheduler.runDeschedulerLoop(ctx, nodes); err != nil {
+		t.Fatalf("Unable to run the descheduling loop: %v", err)
+	}
+
+	data, err := os.ReadFile(rs.ReportFile)
+	if err != nil {
+		t.Fatalf("Unable to read the report: %v", err)
+	}
+	loopReport := &report.Report{}
+	if err := json.Unmarshal(data, loopReport); err != nil {
+		t.Fatalf("Unable to decode the report: %v", err)
+	}
+	if !loopReport.DryRun || loopReport.StartTime.IsZero() || loopReport.NodeUtilization != nil {
+		t.Errorf("Unexpected report: %+v", loopReport)
+	}
+	expected := []report.Decision{
+		{
+			Pod:            "default/bare",
+			Node:           "tainted",
+			Profile:        "ProfileName",
+			Plugin:         removepodsviolatingnodetaints.PluginName,
+			ExtensionPoint: "Deschedule",
+			Result:         report.Rejected,
+			RejectedBy:     defaultevictor.PluginName,
+			RejectedAt:     "Filter",
+			Reason:         "pod does not have any ownerRefs",
+		},
+		{
+			Pod:            "default/intolerant",
+			Node:           "tainted",
+			Profile:        "ProfileName",
+			Plugin:         removepodsviolatingnodetaints.PluginName,
+			ExtensionPoint: "Deschedule",
+			Result:         report.Evicted,
+		},
+	}
+	if diff := cmp.Diff(expected, loopReport.Decisions); diff != "" {
+		t.Errorf("Unexpected decisions (-want +got):\n%s", diff)
+	}
+}
+
+func TestNodeUtilizationReport(t *testing.T) {
+	n1 := test.BuildTestNode("n1", 2000, 4000, 10, nil)
+	n2 := test.BuildTestNode("n2", 2000, 4000, 10, nil)
+	p1 := test.BuildTestPod("p1", 500, 1000, n1.Name, nil)
+	p2 := test.BuildTestPod("p2", 1000, 2000, n1.Name, nil)
+
+	utilization := nodeUtilizationReport(
+		[]*v1.Node{n1, n2},
+		map[string][]*v1.Pod{n1.Name: {p1, p2}},
+		[]evictions.Eviction{{Pod: p2}},
+	)
+	expected := []report.NodeUtilization{
+		{
+			Node:   n1.Name,
+			Before: map[v1.ResourceName]float64{v1.ResourceCPU: 75, v1.ResourceMemory: 75, v1.ResourcePods: 20},
+			After:  map[v1.ResourceName]float64{v1.ResourceCPU: 25, v1.ResourceMemory: 25, v1.ResourcePods: 10},
+		},
+		{
+			Node:   n2.Name,
+			Before: map[v1.ResourceName]float64{v1.ResourceCPU: 0, v1.ResourceMemory: 0, v1.ResourcePods: 0},
+			After:  map[v1.ResourceName]float64{v1.ResourceCPU: 0, v1.ResourceMemory: 0, v1.ResourcePods: 0},
+		},
+	}
+	if diff := cmp.Diff(expected, utilization); diff != "" {
+		t.Errorf("Unexpected node utilization (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plan provides the eviction plans the descheduler produces instead of evicting,
+// to be applied once approved.
+package plan
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DataKey is the key of the plan ConfigMap holding the plan
+	DataKey = "plan.yaml"
+	// ApprovedAnnotation approves the plan of a ConfigMap to be applied, when set to "true"
+	ApprovedAnnotation = "descheduler.alpha.kubernetes.io/plan-approved"
+	// AppliedAnnotation records the time the plan of a ConfigMap got applied at
+	AppliedAnnotation = "descheduler.alpha.kubernetes.io/plan-applied"
+)
+
+// Result is the outcome of a planned eviction, once the plan got applied
+type Result string
+
+const (
+	// Evicted means the pod got evicted
+	Evicted Result = "Evicted"
+	// Skipped means the pod changed since the plan got produced, so it was not evicted
+	Skipped Result = "Skipped"
+	// Failed means the eviction was requested but failed, e.g. because of a pod disruption budget
+	Failed Result = "Failed"
+)
+
+// Plan lists the evictions a descheduling loop would perform
+type Plan struct {
+	// GeneratedAt is the time the plan got produced at
+	GeneratedAt metav1.Time `json:"generatedAt"`
+	// Evictions lists the planned evictions, in the order they get applied
+	Evictions []Eviction `json:"evictions"`
+}
+
+// Eviction is a planned eviction
+type Eviction struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// UID identifies the pod, so a pod recreated under the same name is not evicted
+	UID types.UID `json:"uid"`
+	// Node is the node the pod runs on
+	Node string `json:"node"`
+	// Profile is the profile evicting the pod
+	Profile string `json:"profile"`
+	// Plugin is the plugin evicting the pod
+	Plugin string `json:"plugin"`
+	// ExtensionPoint is the extension point the plugin evicts the pod from
+	ExtensionPoint string `json:"extensionPoint"`
+	// Reason explains the eviction, when the plugin gives one
+	Reason string `json:"reason,omitempty"`
+	// GracePeriodSeconds is the termination grace period the pod is evicted with, when overridden
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
+	// Requests are the resources the eviction frees on the node, i.e. its expected effect
+	Requests v1.ResourceList `json:"requests,omitempty"`
+	// Result is the outcome of the eviction, set once the plan got applied
+	Result Result `json:"result,omitempty"`
+	// Message explains why the eviction got skipped or failed
+	Message string `json:"message,omitempty"`
+}
+
+// Encode encodes a plan as YAML
+func Encode(plan *Plan) ([]byte, error) {
+	return yaml.Marshal(plan)
+}
+
+// Decode decodes a plan from YAML, rejecting unknown fields
+func Decode(data []byte) (*Plan, error) {
+	plan := &Plan{}
+	if err := yaml.UnmarshalStrict(data, plan); err != nil {
+		return nil, fmt.Errorf("unable to decode the eviction plan: %v", err)
+	}
+	return plan, nil
+}
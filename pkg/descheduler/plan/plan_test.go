@@ -0,0 +1,78 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	plan := &Plan{
+		GeneratedAt: metav1.NewTime(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)),
+		Evictions: []Eviction{{
+			Namespace:      "default",
+			Name:           "p1",
+			UID:            "uid",
+			Node:           "n1",
+			Profile:        "profile",
+			Plugin:         "PodLifeTime",
+			ExtensionPoint: "Deschedule",
+			Requests:       v1.ResourceList{v1.ResourceCPU: resource.MustParse("500m")},
+			Result:         Skipped,
+			Message:        "the pod no longer exists",
+		}},
+	}
+	expected := `evictions:
+- extensionPoint: Deschedule
+  message: the pod no longer exists
+  name: p1
+  namespace: default
+  node: n1
+  plugin: PodLifeTime
+  profile: profile
+  requests:
+    cpu: 500m
+  result: Skipped
+  uid: uid
+generatedAt: "2023-01-01T00:00:00Z"
+`
+
+	data, err := Encode(plan)
+	if err != nil {
+		t.Fatalf("Unable to encode the plan: %v", err)
+	}
+	if diff := cmp.Diff(expected, string(data)); diff != "" {
+		t.Errorf("Unexpected encoded plan (-want +got):\n%s", diff)
+	}
+	decoded, err := Decode(data)
+	if err != nil {
+		t.Fatalf("Unable to decode the plan: %v", err)
+	}
+	if diff := cmp.Diff(plan, decoded); diff != "" {
+		t.Errorf("Unexpected decoded plan (-want +got):\n%s", diff)
+	}
+
+	if _, err := Decode([]byte("evictions:\n- pod: default/p1\n")); err == nil {
+		t.Errorf("Expected a plan with unknown fields to be rejected")
+	}
+}
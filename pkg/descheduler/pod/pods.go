@@ -17,6 +17,7 @@ limitations under the License.
 package pod
 
 import (
+	"fmt"
 	"sort"
 
 	v1 "k8s.io/api/core/v1"
@@ -35,6 +36,9 @@ const (
 // FilterFunc is a filter for a pod.
 type FilterFunc func(*v1.Pod) bool
 
+// LessFunc reports whether the first pod should be ordered before the second one.
+type LessFunc func(*v1.Pod, *v1.Pod) bool
+
 // GetPodsAssignedToNodeFunc is a function which accept a node name and a pod filter function
 // as input and returns the pods that assigned to the node.
 type GetPodsAssignedToNodeFunc func(string, FilterFunc) ([]*v1.Pod, error)
@@ -51,6 +55,42 @@ func WrapFilterFuncs(filters ...FilterFunc) FilterFunc {
 	}
 }
 
+// FilterReasonsFunc is a filter for a pod telling why it rejects the pod. It returns a reason
+// per failed check, and no reason when it accepts the pod.
+type FilterReasonsFunc func(*v1.Pod) []string
+
+// UnknownFilterReason is the reason a FilterFunc turned into a FilterReasonsFunc rejects the pods with
+const UnknownFilterReason = "unknown"
+
+// AsFilterReasonsFunc turns a FilterFunc into a FilterReasonsFunc, rejecting the pods with UnknownFilterReason.
+func AsFilterReasonsFunc(filter FilterFunc) FilterReasonsFunc {
+	return func(pod *v1.Pod) []string {
+		if filter(pod) {
+			return nil
+		}
+		return []string{UnknownFilterReason}
+	}
+}
+
+// WrapLessFuncs wraps a set of LessFunc in one.
+// The functions are consulted in order until one of them tells the pods apart.
+func WrapLessFuncs(lessFuncs ...LessFunc) LessFunc {
+	return func(pod1, pod2 *v1.Pod) bool {
+		for _, less := range lessFuncs {
+			if less == nil {
+				continue
+			}
+			if less(pod1, pod2) {
+				return true
+			}
+			if less(pod2, pod1) {
+				return false
+			}
+		}
+		return false
+	}
+}
+
 type Options struct {
 	filter             FilterFunc
 	includedNamespaces sets.Set[string]
@@ -115,6 +155,31 @@ func (o *Options) BuildFilterFunc() (FilterFunc, error) {
 	}, nil
 }
 
+// BuildExplainFunc builds a function telling why the namespaces and the label selector
+// of the Options exclude a pod, or nil when they do not. The filter is not consulted.
+func (o *Options) BuildExplainFunc() (func(*v1.Pod) error, error) {
+	var s labels.Selector
+	var err error
+	if o.labelSelector != nil {
+		s, err = metav1.LabelSelectorAsSelector(o.labelSelector)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return func(pod *v1.Pod) error {
+		if len(o.includedNamespaces) > 0 && !o.includedNamespaces.Has(pod.Namespace) {
+			return fmt.Errorf("namespace %q is not among the included namespaces %v", pod.Namespace, sets.List(o.includedNamespaces))
+		}
+		if len(o.excludedNamespaces) > 0 && o.excludedNamespaces.Has(pod.Namespace) {
+			return fmt.Errorf("namespace %q is excluded", pod.Namespace)
+		}
+		if s != nil && !s.Matches(labels.Set(pod.GetLabels())) {
+			return fmt.Errorf("pod labels do not match the label selector %q", s.String())
+		}
+		return nil
+	}, nil
+}
+
 // BuildGetPodsAssignedToNodeFunc establishes an indexer to map the pods and their assigned nodes.
 // It returns a function to help us get all the pods that assigned to a node based on the indexer.
 func BuildGetPodsAssignedToNodeFunc(podInformer cache.SharedIndexInformer) (GetPodsAssignedToNodeFunc, error) {
@@ -257,3 +322,25 @@ func SortPodsBasedOnAge(pods []*v1.Pod) {
 		return pods[i].CreationTimestamp.Before(&pods[j].CreationTimestamp)
 	})
 }
+
+// SortPods sorts pods in place based on the given LessFunc.
+// Pods which are equal according to the LessFunc keep their original order.
+func SortPods(pods []*v1.Pod, less LessFunc) {
+	if less == nil {
+		return
+	}
+	sort.SliceStable(pods, func(i, j int) bool {
+		return less(pods[i], pods[j])
+	})
+}
+
+// SortPodsReversed sorts pods in place in the reverse order of the given LessFunc.
+// Pods which are equal according to the LessFunc keep their original order.
+func SortPodsReversed(pods []*v1.Pod, less LessFunc) {
+	if less == nil {
+		return
+	}
+	SortPods(pods, func(pod1, pod2 *v1.Pod) bool {
+		return less(pod2, pod1)
+	})
+}
@@ -18,14 +18,19 @@ package descheduler
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 
+	v1 "k8s.io/api/core/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
 
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/api/v1alpha1"
@@ -50,6 +55,85 @@ func LoadPolicyConfig(policyConfigFile string, client clientset.Interface, regis
 	return decode(policyConfigFile, policy, client, registry)
 }
 
+// ConvertPolicyConfig reads a policy of any supported version and converts it into
+// the internal version, without validating it or setting any defaults
+func ConvertPolicyConfig(policyConfigFile string) (*api.DeschedulerPolicy, error) {
+	policy, err := os.ReadFile(policyConfigFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy config file %q: %+v", policyConfigFile, err)
+	}
+
+	internalPolicy := &api.DeschedulerPolicy{}
+	decoder := scheme.Codecs.UniversalDecoder(v1alpha1.SchemeGroupVersion, v1alpha2.SchemeGroupVersion, api.SchemeGroupVersion)
+	if err := runtime.DecodeInto(decoder, policy, internalPolicy); err != nil {
+		return nil, fmt.Errorf("failed decoding descheduler's policy config %q: %v", policyConfigFile, err)
+	}
+	return internalPolicy, nil
+}
+
+// EncodePolicy serializes a policy as a v1alpha2 YAML policy. Unset fields are omitted.
+func EncodePolicy(in *api.DeschedulerPolicy) ([]byte, error) {
+	// The plugin args are not registered in the scheme, so the profiles are converted
+	// one by one and their args serialized as they are.
+	withoutProfiles := *in
+	withoutProfiles.Profiles = nil
+	out := &v1alpha2.DeschedulerPolicy{}
+	if err := v1alpha2.Convert_api_DeschedulerPolicy_To_v1alpha2_DeschedulerPolicy(&withoutProfiles, out, nil); err != nil {
+		return nil, err
+	}
+	out.APIVersion = v1alpha2.SchemeGroupVersion.String()
+	out.Kind = "DeschedulerPolicy"
+
+	for i := range in.Profiles {
+		profile := v1alpha2.DeschedulerProfile{}
+		if err := v1alpha2.Convert_api_DeschedulerProfile_To_v1alpha2_DeschedulerProfile(&in.Profiles[i], &profile, nil); err != nil {
+			return nil, err
+		}
+		for j := range profile.PluginConfigs {
+			args := &profile.PluginConfigs[j].Args
+			if args.Object == nil {
+				continue
+			}
+			raw, err := json.Marshal(args.Object)
+			if err != nil {
+				return nil, fmt.Errorf("failed encoding the args of plugin %v in profile %v: %v", profile.PluginConfigs[j].Name, profile.Name, err)
+			}
+			args.Raw, args.Object = raw, nil
+		}
+		out.Profiles = append(out.Profiles, profile)
+	}
+
+	data, err := json.Marshal(out)
+	if err != nil {
+		return nil, err
+	}
+	var policy map[string]interface{}
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(pruneEmpty(policy))
+}
+
+// pruneEmpty drops the null values and the maps left empty, e.g. the plugin sets with nothing enabled or disabled
+func pruneEmpty(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, item := range typed {
+			item = pruneEmpty(item)
+			if nested, ok := item.(map[string]interface{}); item == nil || (ok && len(nested) == 0) {
+				delete(typed, key)
+				continue
+			}
+			typed[key] = item
+		}
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = pruneEmpty(item)
+		}
+	}
+	return value
+}
+
 func decode(policyConfigFile string, policy []byte, client clientset.Interface, registry pluginregistry.Registry) (*api.DeschedulerPolicy, error) {
 	internalPolicy := &api.DeschedulerPolicy{}
 	var err error
@@ -72,6 +156,7 @@ func decode(policyConfigFile string, policy []byte, client clientset.Interface,
 func setDefaults(in api.DeschedulerPolicy, registry pluginregistry.Registry, client clientset.Interface) *api.DeschedulerPolicy {
 	for idx, profile := range in.Profiles {
 		// If we need to set defaults coming from loadtime in each profile we do it here
+		profile.Plugins = *mergePlugins(getDefaultPlugins(), &profile.Plugins)
 		in.Profiles[idx] = setDefaultEvictor(profile, client)
 		for _, pluginConfig := range profile.PluginConfigs {
 			setDefaultsPluginConfig(&pluginConfig, registry)
@@ -109,17 +194,13 @@ func setDefaultEvictor(profile api.DeschedulerProfile, client clientset.Interfac
 		},
 	}
 
-	// Always enable DefaultEvictor plugin for filter/preEvictionFilter extension points
-	if !findPluginName(profile.Plugins.Filter.Enabled, defaultevictor.PluginName) {
-		profile.Plugins.Filter.Enabled = append([]string{defaultevictor.PluginName}, profile.Plugins.Filter.Enabled...)
-	}
-
-	if !findPluginName(profile.Plugins.PreEvictionFilter.Enabled, defaultevictor.PluginName) {
-		profile.Plugins.PreEvictionFilter.Enabled = append([]string{defaultevictor.PluginName}, profile.Plugins.PreEvictionFilter.Enabled...)
-	}
-
 	defaultevictorPluginConfig, idx := GetPluginConfig(defaultevictor.PluginName, profile.PluginConfigs)
 	if defaultevictorPluginConfig == nil {
+		// The DefaultEvictor plugin can be disabled (or replaced) in both filter/preEvictionFilter extension points
+		if !findPluginName(profile.Plugins.Filter.Enabled, defaultevictor.PluginName) &&
+			!findPluginName(profile.Plugins.PreEvictionFilter.Enabled, defaultevictor.PluginName) {
+			return profile
+		}
 		profile.PluginConfigs = append([]api.PluginConfig{newPluginConfig}, profile.PluginConfigs...)
 		defaultevictorPluginConfig = &newPluginConfig
 		idx = 0
@@ -137,12 +218,24 @@ func setDefaultEvictor(profile api.DeschedulerProfile, client clientset.Interfac
 func validateDeschedulerConfiguration(in api.DeschedulerPolicy, registry pluginregistry.Registry) error {
 	var errorsInProfiles []error
 	for _, profile := range in.Profiles {
+		instanceNames := sets.New[string]()
 		for _, pluginConfig := range profile.PluginConfigs {
 			if _, ok := registry[pluginConfig.Name]; !ok {
 				errorsInProfiles = append(errorsInProfiles, fmt.Errorf("in profile %s: plugin %s in pluginConfig not registered", profile.Name, pluginConfig.Name))
 				continue
 			}
 
+			if instanceName := pluginregistry.InstanceName(pluginConfig.Args); instanceName != "" {
+				if _, ok := registry[instanceName]; ok || instanceNames.Has(instanceName) {
+					errorsInProfiles = append(errorsInProfiles, fmt.Errorf("in profile %s: plugin %s instance name %s is not unique", profile.Name, pluginConfig.Name, instanceName))
+				}
+				instanceNames.Insert(instanceName)
+			}
+
+			if pluginConfig.GracePeriodSeconds != nil && *pluginConfig.GracePeriodSeconds < 0 {
+				errorsInProfiles = append(errorsInProfiles, fmt.Errorf("in profile %s: plugin %s gracePeriodSeconds must not be negative", profile.Name, pluginConfig.Name))
+			}
+
 			pluginUtilities := registry[pluginConfig.Name]
 			if pluginUtilities.PluginArgValidator == nil {
 				continue
@@ -151,6 +244,69 @@ func validateDeschedulerConfiguration(in api.DeschedulerPolicy, registry pluginr
 				errorsInProfiles = append(errorsInProfiles, fmt.Errorf("in profile %s: %s", profile.Name, err.Error()))
 			}
 		}
+		for _, pluginSet := range []api.PluginSet{
+			profile.Plugins.PreSort,
+			profile.Plugins.Sort,
+			profile.Plugins.Deschedule,
+			profile.Plugins.Balance,
+			profile.Plugins.Filter,
+			profile.Plugins.PreEvictionFilter,
+			profile.Plugins.PreEvict,
+			profile.Plugins.PostEvict,
+		} {
+			for _, pluginName := range pluginSet.Disabled {
+				if _, ok := registry[pluginName]; !ok && !instanceNames.Has(pluginName) && pluginName != allPluginsWildcard {
+					errorsInProfiles = append(errorsInProfiles, fmt.Errorf("in profile %s: disabled plugin %s not registered", profile.Name, pluginName))
+				}
+			}
+		}
+	}
+	if in.Parallelism != nil && *in.Parallelism == 0 {
+		errorsInProfiles = append(errorsInProfiles, fmt.Errorf("parallelism must be greater than 0"))
+	}
+	if rateLimit := in.EvictionRateLimit; rateLimit != nil {
+		if rateLimit.Evictions == 0 {
+			errorsInProfiles = append(errorsInProfiles, fmt.Errorf("evictionRateLimit.evictions must be greater than 0"))
+		}
+		if rateLimit.Period != "" && rateLimit.Period != api.Second && rateLimit.Period != api.Minute {
+			errorsInProfiles = append(errorsInProfiles, fmt.Errorf("evictionRateLimit.period must be %s or %s, got %q", api.Second, api.Minute, rateLimit.Period))
+		}
+		if rateLimit.Burst != nil && *rateLimit.Burst == 0 {
+			errorsInProfiles = append(errorsInProfiles, fmt.Errorf("evictionRateLimit.burst must be greater than 0"))
+		}
+	}
+	if in.GracePeriodSeconds != nil && *in.GracePeriodSeconds < 0 {
+		errorsInProfiles = append(errorsInProfiles, fmt.Errorf("gracePeriodSeconds must not be negative"))
+	}
+	if in.DeleteFallback != nil {
+		for _, phase := range in.DeleteFallback.PodPhases {
+			switch phase {
+			case v1.PodSucceeded, v1.PodFailed, v1.PodUnknown:
+			case v1.PodPending, v1.PodRunning:
+				errorsInProfiles = append(errorsInProfiles, fmt.Errorf("deleteFallback.podPhases: the %v pods are protected by their pod disruption budgets and cannot be deleted", phase))
+			default:
+				errorsInProfiles = append(errorsInProfiles, fmt.Errorf("deleteFallback.podPhases: unknown pod phase %q", phase))
+			}
+		}
+	}
+	if budget := in.WorkloadEvictionBudget; budget != nil {
+		// the percentages are scaled against 100 replicas so they are checked to be within 1% and 100%
+		if maxEvictions, err := intstr.GetScaledValueFromIntOrPercent(&budget.MaxEvictions, 100, true); err != nil {
+			errorsInProfiles = append(errorsInProfiles, fmt.Errorf("workloadEvictionBudget.maxEvictions: %v", err))
+		} else if maxEvictions <= 0 || budget.MaxEvictions.Type == intstr.String && maxEvictions > 100 {
+			errorsInProfiles = append(errorsInProfiles, fmt.Errorf("workloadEvictionBudget.maxEvictions must be greater than 0, and at most 100%% when a percentage, got %v", budget.MaxEvictions.String()))
+		}
+		if budget.WindowSeconds == 0 {
+			errorsInProfiles = append(errorsInProfiles, fmt.Errorf("workloadEvictionBudget.windowSeconds must be greater than 0"))
+		}
+	}
+	if cooldown := in.EvictionCooldown; cooldown != nil {
+		if cooldown.WindowSeconds == 0 {
+			errorsInProfiles = append(errorsInProfiles, fmt.Errorf("evictionCooldown.windowSeconds must be greater than 0"))
+		}
+		if cooldown.Scope != "" && cooldown.Scope != api.NodeCooldownScope && cooldown.Scope != api.OwnerCooldownScope {
+			errorsInProfiles = append(errorsInProfiles, fmt.Errorf("evictionCooldown.scope must be %s or %s, got %q", api.NodeCooldownScope, api.OwnerCooldownScope, cooldown.Scope))
+		}
 	}
 	return utilerrors.NewAggregate(errorsInProfiles)
 }
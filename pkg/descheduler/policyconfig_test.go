@@ -18,10 +18,14 @@ package descheduler
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	fakeclientset "k8s.io/client-go/kubernetes/fake"
 	utilpointer "k8s.io/utils/pointer"
 	"sigs.k8s.io/descheduler/pkg/api"
@@ -30,6 +34,7 @@ import (
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/nodeutilization"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/podlifetime"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/remoteplugin"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removeduplicates"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removefailedpods"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodshavingtoomanyrestarts"
@@ -995,6 +1000,84 @@ profiles:
 				},
 			},
 		},
+		{
+			description: "v1alpha2 to internal with grace periods and delete fallback",
+			policy: []byte(`apiVersion: "descheduler/v1alpha2"
+kind: "DeschedulerPolicy"
+gracePeriodSeconds: 30
+deleteFallback:
+  podPhases:
+  - Failed
+profiles:
+  - name: ProfileName
+    pluginConfig:
+    - name: "DefaultEvictor"
+      args:
+        evictFailedBarePods: true
+    - name: "RemoveFailedPods"
+      gracePeriodSeconds: 0
+    plugins:
+      deschedule:
+        enabled:
+          - "RemoveFailedPods"
+`),
+			result: &api.DeschedulerPolicy{
+				GracePeriodSeconds: utilpointer.Int64(30),
+				DeleteFallback:     &api.DeleteFallback{PodPhases: []v1.PodPhase{v1.PodFailed}},
+				Profiles: []api.DeschedulerProfile{
+					{
+						Name: "ProfileName",
+						PluginConfigs: []api.PluginConfig{
+							{
+								Name: defaultevictor.PluginName,
+								Args: &defaultevictor.DefaultEvictorArgs{
+									EvictFailedBarePods: true,
+									PriorityThreshold:   &api.PriorityThreshold{Value: utilpointer.Int32(2000000000)},
+								},
+							},
+							{
+								Name:               removefailedpods.PluginName,
+								Args:               &removefailedpods.RemoveFailedPodsArgs{MinPodLifetimeSeconds: utilpointer.Uint(3600)},
+								GracePeriodSeconds: utilpointer.Int64(0),
+							},
+						},
+						Plugins: api.Plugins{
+							Filter: api.PluginSet{
+								Enabled: []string{defaultevictor.PluginName},
+							},
+							PreEvictionFilter: api.PluginSet{
+								Enabled: []string{defaultevictor.PluginName},
+							},
+							Deschedule: api.PluginSet{
+								Enabled: []string{removefailedpods.PluginName},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "v1alpha2 to internal with a workload eviction budget and an eviction cooldown",
+			policy: []byte(`apiVersion: "descheduler/v1alpha2"
+kind: "DeschedulerPolicy"
+workloadEvictionBudget:
+  maxEvictions: 10%
+  windowSeconds: 1800
+evictionCooldown:
+  windowSeconds: 3600
+  scope: Owner
+`),
+			result: &api.DeschedulerPolicy{
+				WorkloadEvictionBudget: &api.WorkloadEvictionBudget{
+					MaxEvictions:  intstr.FromString("10%"),
+					WindowSeconds: 1800,
+				},
+				EvictionCooldown: &api.EvictionCooldown{
+					WindowSeconds: 3600,
+					Scope:         api.OwnerCooldownScope,
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1065,6 +1148,107 @@ func TestValidateDeschedulerConfiguration(t *testing.T) {
 			},
 			result: fmt.Errorf("[in profile RemoveFailedPods: only one of Include/Exclude namespaces can be set, in profile RemovePodsViolatingTopologySpreadConstraint: only one of Include/Exclude namespaces can be set]"),
 		},
+		{
+			description: "disabled plugin not registered",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				Profiles: []api.DeschedulerProfile{
+					{
+						Name: removefailedpods.PluginName,
+						Plugins: api.Plugins{
+							Filter:            api.PluginSet{Disabled: []string{"*"}},
+							PreEvictionFilter: api.PluginSet{Disabled: []string{"NonExistingPlugin"}},
+						},
+					},
+				},
+			},
+			result: fmt.Errorf("in profile RemoveFailedPods: disabled plugin NonExistingPlugin not registered"),
+		},
+		{
+			description: "plugin instance names not unique",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				Profiles: []api.DeschedulerProfile{
+					{
+						Name: remoteplugin.PluginName,
+						Plugins: api.Plugins{
+							Filter: api.PluginSet{Disabled: []string{"team-a"}},
+						},
+						PluginConfigs: []api.PluginConfig{
+							{
+								Name: remoteplugin.PluginName,
+								Args: &remoteplugin.RemotePluginArgs{Name: "team-a", Endpoint: "http://team-a:8080"},
+							},
+							{
+								Name: remoteplugin.PluginName,
+								Args: &remoteplugin.RemotePluginArgs{Name: "team-a", Endpoint: "http://team-b:8080"},
+							},
+							{
+								Name: remoteplugin.PluginName,
+								Args: &remoteplugin.RemotePluginArgs{Name: removefailedpods.PluginName, Endpoint: "http://team-c:8080"},
+							},
+						},
+					},
+				},
+			},
+			result: fmt.Errorf("[in profile RemotePlugin: plugin RemotePlugin instance name team-a is not unique, in profile RemotePlugin: plugin RemotePlugin instance name RemoveFailedPods is not unique]"),
+		},
+		{
+			description: "zero parallelism",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				Parallelism: func(i uint) *uint { return &i }(0),
+			},
+			result: fmt.Errorf("parallelism must be greater than 0"),
+		},
+		{
+			description: "invalid eviction rate limit",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				EvictionRateLimit: &api.EvictionRateLimit{
+					Period: "Hour",
+					Burst:  func(i uint) *uint { return &i }(0),
+				},
+			},
+			result: fmt.Errorf(`[evictionRateLimit.evictions must be greater than 0, evictionRateLimit.period must be Second or Minute, got "Hour", evictionRateLimit.burst must be greater than 0]`),
+		},
+		{
+			description: "invalid grace periods and delete fallback",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				Profiles: []api.DeschedulerProfile{
+					{
+						Name: removefailedpods.PluginName,
+						PluginConfigs: []api.PluginConfig{
+							{
+								Name:               removefailedpods.PluginName,
+								Args:               &removefailedpods.RemoveFailedPodsArgs{},
+								GracePeriodSeconds: utilpointer.Int64(-1),
+							},
+						},
+					},
+				},
+				GracePeriodSeconds: utilpointer.Int64(-1),
+				DeleteFallback:     &api.DeleteFallback{PodPhases: []v1.PodPhase{v1.PodFailed, v1.PodRunning, "Terminated"}},
+			},
+			result: fmt.Errorf(`[in profile RemoveFailedPods: plugin RemoveFailedPods gracePeriodSeconds must not be negative, gracePeriodSeconds must not be negative, deleteFallback.podPhases: the Running pods are protected by their pod disruption budgets and cannot be deleted, deleteFallback.podPhases: unknown pod phase "Terminated"]`),
+		},
+		{
+			description: "workload eviction budget percentage above 100% without window",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				WorkloadEvictionBudget: &api.WorkloadEvictionBudget{MaxEvictions: intstr.FromString("150%")},
+			},
+			result: fmt.Errorf(`[workloadEvictionBudget.maxEvictions must be greater than 0, and at most 100%% when a percentage, got 150%%, workloadEvictionBudget.windowSeconds must be greater than 0]`),
+		},
+		{
+			description: "workload eviction budget not a percentage",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				WorkloadEvictionBudget: &api.WorkloadEvictionBudget{MaxEvictions: intstr.FromString("ten"), WindowSeconds: 1800},
+			},
+			result: fmt.Errorf(`workloadEvictionBudget.maxEvictions: invalid value for IntOrString: invalid type: string is not a percentage`),
+		},
+		{
+			description: "invalid eviction cooldown",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				EvictionCooldown: &api.EvictionCooldown{Scope: "Namespace"},
+			},
+			result: fmt.Errorf(`[evictionCooldown.windowSeconds must be greater than 0, evictionCooldown.scope must be Node or Owner, got "Namespace"]`),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1205,6 +1389,101 @@ profiles:
 				},
 			},
 		},
+		{
+			description: "disable default evictor in both filter extension points",
+			policy: []byte(`apiVersion: "descheduler/v1alpha2"
+kind: "DeschedulerPolicy"
+profiles:
+  - name: ProfileName
+    pluginConfig:
+    - name: "RemoveFailedPods"
+    plugins:
+      filter:
+        disabled:
+          - "DefaultEvictor"
+      preevictionfilter:
+        disabled:
+          - "*"
+      deschedule:
+        enabled:
+          - "RemoveFailedPods"
+`),
+			result: &api.DeschedulerPolicy{
+				Profiles: []api.DeschedulerProfile{
+					{
+						Name: "ProfileName",
+						PluginConfigs: []api.PluginConfig{
+							{
+								Name: removefailedpods.PluginName,
+								Args: &removefailedpods.RemoveFailedPodsArgs{
+									MinPodLifetimeSeconds: utilpointer.Uint(3600),
+								},
+							},
+						},
+						Plugins: api.Plugins{
+							Filter: api.PluginSet{
+								Disabled: []string{defaultevictor.PluginName},
+							},
+							PreEvictionFilter: api.PluginSet{
+								Disabled: []string{"*"},
+							},
+							Deschedule: api.PluginSet{
+								Enabled: []string{removefailedpods.PluginName},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "disable default evictor only in preEvictionFilter extension point",
+			policy: []byte(`apiVersion: "descheduler/v1alpha2"
+kind: "DeschedulerPolicy"
+profiles:
+  - name: ProfileName
+    pluginConfig:
+    - name: "RemoveFailedPods"
+    plugins:
+      preevictionfilter:
+        disabled:
+          - "DefaultEvictor"
+      deschedule:
+        enabled:
+          - "RemoveFailedPods"
+`),
+			result: &api.DeschedulerPolicy{
+				Profiles: []api.DeschedulerProfile{
+					{
+						Name: "ProfileName",
+						PluginConfigs: []api.PluginConfig{
+							{
+								Name: defaultevictor.PluginName,
+								Args: &defaultevictor.DefaultEvictorArgs{
+									PriorityThreshold: &api.PriorityThreshold{Value: utilpointer.Int32(2000000000)},
+								},
+							},
+							{
+								Name: removefailedpods.PluginName,
+								Args: &removefailedpods.RemoveFailedPodsArgs{
+									MinPodLifetimeSeconds: utilpointer.Uint(3600),
+								},
+							},
+						},
+						Plugins: api.Plugins{
+							Filter: api.PluginSet{
+								Enabled: []string{defaultevictor.PluginName},
+							},
+							PreEvictionFilter: api.PluginSet{
+								Disabled: []string{defaultevictor.PluginName},
+							},
+							Deschedule: api.PluginSet{
+								Enabled: []string{removefailedpods.PluginName},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1224,3 +1503,74 @@ profiles:
 		})
 	}
 }
+
+func TestEncodePolicy(t *testing.T) {
+	client := fakeclientset.NewSimpleClientset()
+	SetupPlugins()
+
+	v1alpha1Policy := []byte(`apiVersion: "descheduler/v1alpha1"
+kind: "DeschedulerPolicy"
+maxNoOfPodsToEvictPerNode: 5
+strategies:
+  "RemoveFailedPods":
+     enabled: true
+     params:
+       failedPods:
+         minPodLifetimeSeconds: 600
+  "RemoveDuplicates":
+     enabled: true
+`)
+	policyConfigFile := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(policyConfigFile, v1alpha1Policy, 0o600); err != nil {
+		t.Fatalf("Unable to write the policy: %v", err)
+	}
+
+	converted, err := ConvertPolicyConfig(policyConfigFile)
+	if err != nil {
+		t.Fatalf("Unable to convert the policy: %v", err)
+	}
+	var profileNames []string
+	for _, profile := range converted.Profiles {
+		profileNames = append(profileNames, profile.Name)
+	}
+	if diff := cmp.Diff([]string{"strategy-RemoveDuplicates-profile", "strategy-RemoveFailedPods-profile"}, profileNames); diff != "" {
+		t.Errorf("Unexpected profiles (-want +got):\n%s", diff)
+	}
+
+	encoded, err := EncodePolicy(converted)
+	if err != nil {
+		t.Fatalf("Unable to encode the policy: %v", err)
+	}
+	if !strings.HasPrefix(string(encoded), "apiVersion: descheduler/v1alpha2\nkind: DeschedulerPolicy\n") {
+		t.Errorf("Expected a v1alpha2 policy, got:\n%s", encoded)
+	}
+	if strings.Contains(string(encoded), "null") {
+		t.Errorf("Expected the unset fields to be omitted, got:\n%s", encoded)
+	}
+
+	// The encoded policy decodes to the same policy as the original one
+	expected, err := decode(policyConfigFile, v1alpha1Policy, client, pluginregistry.PluginRegistry)
+	if err != nil {
+		t.Fatalf("Unable to decode the original policy: %v", err)
+	}
+	got, err := decode("encoded", encoded, client, pluginregistry.PluginRegistry)
+	if err != nil {
+		t.Fatalf("Unable to decode the encoded policy: %v\n%s", err, encoded)
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("Unexpected policy after encoding (-want +got):\n%s", diff)
+	}
+
+	// Encoding a defaulted policy is stable
+	reencoded, err := EncodePolicy(got)
+	if err != nil {
+		t.Fatalf("Unable to encode the decoded policy: %v", err)
+	}
+	regot, err := decode("reencoded", reencoded, client, pluginregistry.PluginRegistry)
+	if err != nil {
+		t.Fatalf("Unable to decode the reencoded policy: %v\n%s", err, reencoded)
+	}
+	if diff := cmp.Diff(got, regot); diff != "" {
+		t.Errorf("Unexpected policy after encoding a defaulted policy (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	clientset "k8s.io/client-go/kubernetes"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
+	"sigs.k8s.io/descheduler/metrics"
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
+)
+
+// policySource provides the serialized descheduler policy
+type policySource interface {
+	// read returns the current serialized policy
+	read() ([]byte, error)
+	// regarding returns the object the policy events are recorded on, nil if there is none
+	regarding() runtime.Object
+	String() string
+}
+
+// policyStatusReporter is implemented by the policy sources reporting the status of the policy back
+type policyStatusReporter interface {
+	// reportValidation reports whether the policy read is valid
+	reportValidation(ctx context.Context, err error)
+	// reportLoop reports the pods evicted by each plugin of each profile in a finished descheduling loop
+	reportLoop(ctx context.Context, policy *api.DeschedulerPolicy, evicted map[string]map[string]uint)
+}
+
+// reportPolicyValidation reports whether the policy read is valid if the source supports it
+func reportPolicyValidation(ctx context.Context, source policySource, err error) {
+	if reporter, ok := source.(policyStatusReporter); ok {
+		reporter.reportValidation(ctx, err)
+	}
+}
+
+// filePolicySource reads the policy from a file, e.g. a mounted ConfigMap.
+// The policy events are recorded on the descheduler pod, if known.
+type filePolicySource struct {
+	path string
+	// pod references the pod the descheduler runs in, nil when not known
+	pod *v1.ObjectReference
+}
+
+// deschedulerPod references the pod the descheduler runs in, as exposed by the downward API through
+// the POD_NAME and POD_NAMESPACE environment variables. Returns nil when they are not set.
+func deschedulerPod() *v1.ObjectReference {
+	name, namespace := os.Getenv("POD_NAME"), os.Getenv("POD_NAMESPACE")
+	if name == "" || namespace == "" {
+		return nil
+	}
+	return &v1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: namespace, Name: name}
+}
+
+func (s *filePolicySource) read() ([]byte, error) {
+	policy, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy config file %q: %+v", s.path, err)
+	}
+	return policy, nil
+}
+
+func (s *filePolicySource) regarding() runtime.Object {
+	if s.pod == nil {
+		return nil
+	}
+	return s.pod
+}
+
+func (s *filePolicySource) String() string {
+	return s.path
+}
+
+// configMapPolicySource reads the policy from a key of a ConfigMap kept up to date by an informer
+type configMapPolicySource struct {
+	namespace string
+	name      string
+	key       string
+	lister    listersv1.ConfigMapNamespaceLister
+}
+
+// newConfigMapPolicySource watches the ConfigMap referenced as namespace/name
+func newConfigMapPolicySource(ctx context.Context, client clientset.Interface, configMap, key string) (*configMapPolicySource, error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(configMap)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy ConfigMap reference %q: %v", configMap, err)
+	}
+	if namespace == "" || name == "" {
+		return nil, fmt.Errorf("invalid policy ConfigMap reference %q: expected namespace/name", configMap)
+	}
+
+	sharedInformerFactory := informers.NewSharedInformerFactoryWithOptions(client, 0,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector(metav1.ObjectNameField, name).String()
+		}),
+	)
+	lister := sharedInformerFactory.Core().V1().ConfigMaps().Lister().ConfigMaps(namespace)
+	sharedInformerFactory.Start(ctx.Done())
+	sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+	return &configMapPolicySource{
+		namespace: namespace,
+		name:      name,
+		key:       key,
+		lister:    lister,
+	}, nil
+}
+
+func (s *configMapPolicySource) read() ([]byte, error) {
+	configMap, err := s.lister.Get(s.name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get policy ConfigMap %q: %v", s, err)
+	}
+	policy, ok := configMap.Data[s.key]
+	if !ok {
+		return nil, fmt.Errorf("policy ConfigMap %q has no %q key", s, s.key)
+	}
+	return []byte(policy), nil
+}
+
+func (s *configMapPolicySource) regarding() runtime.Object {
+	configMap, err := s.lister.Get(s.name)
+	if err != nil {
+		return nil
+	}
+	return configMap
+}
+
+func (s *configMapPolicySource) String() string {
+	return s.namespace + "/" + s.name
+}
+
+// policyReloader reloads the descheduler policy from its source in between descheduling loops,
+// so a loop always runs with a single policy. An invalid policy is reported in metrics,
+// logs and events, and the last valid policy is kept.
+type policyReloader struct {
+	source   policySource
+	client   clientset.Interface
+	registry pluginregistry.Registry
+
+	// lastData is the last policy read from the source, valid or not,
+	// so an invalid policy gets reported only once
+	lastData []byte
+	policy   *api.DeschedulerPolicy
+}
+
+// newPolicyReloader loads the initial policy, which is required to be valid
+func newPolicyReloader(ctx context.Context, source policySource, client clientset.Interface, registry pluginregistry.Registry) (*policyReloader, error) {
+	data, err := source.read()
+	if err != nil {
+		return nil, err
+	}
+	policy, err := decode(source.String(), data, client, registry)
+	reportPolicyValidation(ctx, source, err)
+	if err != nil {
+		return nil, err
+	}
+	return &policyReloader{
+		source:   source,
+		client:   client,
+		registry: registry,
+		lastData: data,
+		policy:   policy,
+	}, nil
+}
+
+// reload returns the policy to run the next descheduling loop with
+func (r *policyReloader) reload(ctx context.Context, eventRecorder events.EventRecorder) *api.DeschedulerPolicy {
+	data, err := r.source.read()
+	if err == nil {
+		if bytes.Equal(data, r.lastData) {
+			return r.policy
+		}
+		r.lastData = data
+
+		var policy *api.DeschedulerPolicy
+		policy, err = decode(r.source.String(), data, r.client, r.registry)
+		reportPolicyValidation(ctx, r.source, err)
+		if err == nil {
+			r.policy = policy
+			metrics.PolicyReloads.With(map[string]string{"result": "success"}).Inc()
+			metrics.PolicyLastReloadSuccessful.Set(1)
+			klog.V(1).InfoS("Descheduler policy reloaded", "source", r.source)
+			if regarding := r.source.regarding(); regarding != nil {
+				eventRecorder.Eventf(regarding, nil, v1.EventTypeNormal, "PolicyReloaded", "Reload", "descheduler policy reloaded")
+			}
+			return r.policy
+		}
+	}
+
+	metrics.PolicyReloads.With(map[string]string{"result": "error"}).Inc()
+	metrics.PolicyLastReloadSuccessful.Set(0)
+	klog.ErrorS(err, "Unable to reload the descheduler policy, keeping the last valid one", "source", r.source)
+	if regarding := r.source.regarding(); regarding != nil {
+		eventRecorder.Eventf(regarding, nil, v1.EventTypeWarning, "PolicyReloadFailed", "Reload", "unable to reload the descheduler policy, keeping the last valid one: %v", err)
+	}
+	return r.policy
+}
+
+// newPolicyReloaderFromOptions builds a reloader for the policy file, ConfigMap or custom resource set in the options
+func newPolicyReloaderFromOptions(ctx context.Context, rs *options.DeschedulerServer) (*policyReloader, error) {
+	var source policySource
+	switch {
+	case rs.PolicyResource != "":
+		source = newResourcePolicySource(ctx, rs.DynamicClient, rs.PolicyResource)
+	case rs.PolicyConfigMap != "":
+		configMapSource, err := newConfigMapPolicySource(ctx, rs.Client, rs.PolicyConfigMap, rs.PolicyConfigMapKey)
+		if err != nil {
+			return nil, err
+		}
+		source = configMapSource
+	case rs.PolicyConfigFile != "":
+		source = &filePolicySource{path: rs.PolicyConfigFile, pod: deschedulerPod()}
+	default:
+		return nil, fmt.Errorf("reloading the policy requires one of policyConfigFile, policyConfigMap or policyResource to be set")
+	}
+	return newPolicyReloader(ctx, source, rs.Client, pluginregistry.PluginRegistry)
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/events"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removefailedpods"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatingnodetaints"
+)
+
+const reloaderTestPolicy = `
+apiVersion: "descheduler/v1alpha2"
+kind: "DeschedulerPolicy"
+profiles:
+  - name: ProfileName
+    pluginConfig:
+    - name: "DefaultEvictor"
+    - name: "%s"
+    plugins:
+      deschedule:
+        enabled:
+          - "%s"
+`
+
+func reloaderTestPolicyFor(pluginName string) string {
+	return fmt.Sprintf(reloaderTestPolicy, pluginName, pluginName)
+}
+
+func TestPolicyReloader(t *testing.T) {
+	SetupPlugins()
+
+	configMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "descheduler-policy", Namespace: "kube-system"},
+		Data:       map[string]string{"policy.yaml": reloaderTestPolicyFor(removefailedpods.PluginName)},
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	if err := indexer.Add(configMap); err != nil {
+		t.Fatalf("Unable to add the policy ConfigMap: %v", err)
+	}
+	source := &configMapPolicySource{
+		namespace: configMap.Namespace,
+		name:      configMap.Name,
+		key:       "policy.yaml",
+		lister:    listersv1.NewConfigMapLister(indexer).ConfigMaps(configMap.Namespace),
+	}
+
+	reloader, err := newPolicyReloader(context.TODO(), source, fakeclientset.NewSimpleClientset(), pluginregistry.PluginRegistry)
+	if err != nil {
+		t.Fatalf("Unable to load the initial policy: %v", err)
+	}
+
+	profilePlugin := func(policy *api.DeschedulerPolicy) string {
+		return policy.Profiles[0].Plugins.Deschedule.Enabled[0]
+	}
+	updatePolicy := func(policy string) {
+		updated := configMap.DeepCopy()
+		updated.Data["policy.yaml"] = policy
+		if err := indexer.Update(updated); err != nil {
+			t.Fatalf("Unable to update the policy ConfigMap: %v", err)
+		}
+	}
+
+	tests := []struct {
+		description    string
+		policy         string
+		expectedPlugin string
+		expectedEvents []string
+	}{
+		{
+			description:    "unchanged policy is kept without any event",
+			expectedPlugin: removefailedpods.PluginName,
+		},
+		{
+			description:    "valid policy is swapped in",
+			policy:         reloaderTestPolicyFor(removepodsviolatingnodetaints.PluginName),
+			expectedPlugin: removepodsviolatingnodetaints.PluginName,
+			expectedEvents: []string{"Normal PolicyReloaded"},
+		},
+		{
+			description:    "invalid policy keeps the last valid one",
+			policy:         reloaderTestPolicyFor("NonExistingPlugin"),
+			expectedPlugin: removepodsviolatingnodetaints.PluginName,
+			expectedEvents: []string{"Warning PolicyReloadFailed"},
+		},
+		{
+			description:    "same invalid policy is reported only once",
+			expectedPlugin: removepodsviolatingnodetaints.PluginName,
+		},
+		{
+			description:    "policy that can not be decoded keeps the last valid one",
+			policy:         "profiles: [",
+			expectedPlugin: removepodsviolatingnodetaints.PluginName,
+			expectedEvents: []string{"Warning PolicyReloadFailed"},
+		},
+		{
+			description:    "valid policy is swapped in after an invalid one",
+			policy:         reloaderTestPolicyFor(removefailedpods.PluginName),
+			expectedPlugin: removefailedpods.PluginName,
+			expectedEvents: []string{"Normal PolicyReloaded"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			if tc.policy != "" {
+				updatePolicy(tc.policy)
+			}
+			eventRecorder := events.NewFakeRecorder(10)
+
+			policy := reloader.reload(context.TODO(), eventRecorder)
+			if got := profilePlugin(policy); got != tc.expectedPlugin {
+				t.Errorf("Expected the policy to enable %v, got %v", tc.expectedPlugin, got)
+			}
+
+			close(eventRecorder.Events)
+			var got []string
+			for event := range eventRecorder.Events {
+				got = append(got, event)
+			}
+			if len(got) != len(tc.expectedEvents) {
+				t.Fatalf("Expected %v events, got %v", len(tc.expectedEvents), got)
+			}
+			for i, prefix := range tc.expectedEvents {
+				if !strings.HasPrefix(got[i], prefix) {
+					t.Errorf("Expected event %q to start with %q", got[i], prefix)
+				}
+			}
+		})
+	}
+}
+
+func TestFilePolicyReloaderEvents(t *testing.T) {
+	SetupPlugins()
+
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	writePolicy := func(policy string) {
+		if err := os.WriteFile(path, []byte(policy), 0o600); err != nil {
+			t.Fatalf("Unable to write the policy file: %v", err)
+		}
+	}
+	writePolicy(reloaderTestPolicyFor(removefailedpods.PluginName))
+
+	t.Setenv("POD_NAME", "descheduler-5d8f7b-x2x9z")
+	t.Setenv("POD_NAMESPACE", "kube-system")
+	source := &filePolicySource{path: path, pod: deschedulerPod()}
+	if pod, ok := source.regarding().(*v1.ObjectReference); !ok || pod.Name != "descheduler-5d8f7b-x2x9z" || pod.Namespace != "kube-system" {
+		t.Fatalf("Expected the events to be recorded on the descheduler pod, got %v", source.regarding())
+	}
+	reloader, err := newPolicyReloader(context.TODO(), source, fakeclientset.NewSimpleClientset(), pluginregistry.PluginRegistry)
+	if err != nil {
+		t.Fatalf("Unable to load the initial policy: %v", err)
+	}
+
+	writePolicy(reloaderTestPolicyFor("NonExistingPlugin"))
+	eventRecorder := events.NewFakeRecorder(10)
+	reloader.reload(context.TODO(), eventRecorder)
+	close(eventRecorder.Events)
+	var got []string
+	for event := range eventRecorder.Events {
+		got = append(got, event)
+	}
+	if len(got) != 1 || !strings.HasPrefix(got[0], "Warning PolicyReloadFailed") {
+		t.Errorf("Expected a PolicyReloadFailed event, got %v", got)
+	}
+
+	t.Setenv("POD_NAME", "")
+	if pod := deschedulerPod(); pod != nil {
+		t.Errorf("Expected no descheduler pod without the downward API, got %v", pod)
+	}
+}
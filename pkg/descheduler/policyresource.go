@@ -0,0 +1,190 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/api/v1alpha2"
+	deschedulerv1alpha2 "sigs.k8s.io/descheduler/pkg/apis/descheduler/v1alpha2"
+)
+
+// statusUpdateAttempts is the number of attempts to update the policy status on conflicts
+const statusUpdateAttempts = 5
+
+// resourcePolicySource reads the policy from the spec of a cluster-scoped DeschedulerPolicy
+// custom resource kept up to date by an informer, and reports the status of the policy back
+type resourcePolicySource struct {
+	name   string
+	lister cache.GenericLister
+	client dynamic.ResourceInterface
+
+	// generation is the generation of the last policy read
+	generation int64
+	status     deschedulerv1alpha2.DeschedulerPolicyStatus
+}
+
+var _ policyStatusReporter = &resourcePolicySource{}
+
+// newResourcePolicySource watches the DeschedulerPolicy custom resource of the given name
+func newResourcePolicySource(ctx context.Context, client dynamic.Interface, name string) *resourcePolicySource {
+	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(client, 0, metav1.NamespaceAll, func(options *metav1.ListOptions) {
+		options.FieldSelector = fields.OneTermEqualSelector(metav1.ObjectNameField, name).String()
+	})
+	lister := factory.ForResource(deschedulerv1alpha2.DeschedulerPoliciesResource).Lister()
+	factory.Start(ctx.Done())
+	factory.WaitForCacheSync(ctx.Done())
+
+	return &resourcePolicySource{
+		name:   name,
+		lister: lister,
+		client: client.Resource(deschedulerv1alpha2.DeschedulerPoliciesResource),
+	}
+}
+
+func (s *resourcePolicySource) get() (*unstructured.Unstructured, error) {
+	obj, err := s.lister.Get(s.name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DeschedulerPolicy %q: %v", s.name, err)
+	}
+	policy, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unexpected DeschedulerPolicy %q object of type %T", s.name, obj)
+	}
+	return policy, nil
+}
+
+// read returns the spec of the policy serialized as a descheduler/v1alpha2 policy
+func (s *resourcePolicySource) read() ([]byte, error) {
+	policy, err := s.get()
+	if err != nil {
+		return nil, err
+	}
+	s.generation = policy.GetGeneration()
+
+	spec, _, err := unstructured.NestedMap(policy.Object, "spec")
+	if err != nil {
+		return nil, fmt.Errorf("invalid DeschedulerPolicy %q spec: %v", s.name, err)
+	}
+	if spec == nil {
+		spec = map[string]interface{}{}
+	}
+	spec["apiVersion"] = v1alpha2.SchemeGroupVersion.String()
+	spec["kind"] = "DeschedulerPolicy"
+	return json.Marshal(spec)
+}
+
+func (s *resourcePolicySource) regarding() runtime.Object {
+	policy, err := s.get()
+	if err != nil {
+		return nil
+	}
+	return policy
+}
+
+func (s *resourcePolicySource) String() string {
+	return s.name
+}
+
+// reportValidation sets the Valid condition and the observed generation of the policy
+func (s *resourcePolicySource) reportValidation(ctx context.Context, err error) {
+	condition := metav1.Condition{
+		Type:               deschedulerv1alpha2.PolicyValidCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: s.generation,
+		Reason:             deschedulerv1alpha2.PolicyValidReason,
+		Message:            "the policy is valid",
+	}
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = deschedulerv1alpha2.PolicyInvalidReason
+		condition.Message = err.Error()
+	}
+	meta.SetStatusCondition(&s.status.Conditions, condition)
+	s.status.ObservedGeneration = s.generation
+	s.updateStatus(ctx)
+}
+
+// reportLoop sets the time of the last descheduling loop and the pods evicted by each plugin
+func (s *resourcePolicySource) reportLoop(ctx context.Context, policy *api.DeschedulerPolicy, evicted map[string]map[string]uint) {
+	now := metav1.Now()
+	s.status.LastLoopTime = &now
+	s.status.Profiles = profileStatuses(policy, evicted)
+	s.updateStatus(ctx)
+}
+
+// profileStatuses lists the pods evicted by all the Deschedule and Balance plugins of all profiles
+func profileStatuses(policy *api.DeschedulerPolicy, evicted map[string]map[string]uint) []deschedulerv1alpha2.ProfileStatus {
+	var profiles []deschedulerv1alpha2.ProfileStatus
+	for _, profile := range policy.Profiles {
+		profileStatus := deschedulerv1alpha2.ProfileStatus{Name: profile.Name}
+		seen := map[string]bool{}
+		for _, pluginName := range append(append([]string{}, profile.Plugins.Deschedule.Enabled...), profile.Plugins.Balance.Enabled...) {
+			if seen[pluginName] {
+				continue
+			}
+			seen[pluginName] = true
+			count := int64(evicted[profile.Name][pluginName])
+			profileStatus.PodsEvicted += count
+			profileStatus.Plugins = append(profileStatus.Plugins, deschedulerv1alpha2.PluginStatus{Name: pluginName, PodsEvicted: count})
+		}
+		profiles = append(profiles, profileStatus)
+	}
+	return profiles
+}
+
+// updateStatus writes the status to the policy, retrying on conflicts.
+// Failures are only logged so they do not interrupt the descheduling.
+func (s *resourcePolicySource) updateStatus(ctx context.Context) {
+	status, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&s.status)
+	if err != nil {
+		klog.ErrorS(err, "Unable to convert the DeschedulerPolicy status", "policy", s.name)
+		return
+	}
+
+	for attempt := 0; attempt < statusUpdateAttempts; attempt++ {
+		var policy *unstructured.Unstructured
+		policy, err = s.client.Get(ctx, s.name, metav1.GetOptions{})
+		if err != nil {
+			break
+		}
+		if err = unstructured.SetNestedMap(policy.Object, status, "status"); err != nil {
+			break
+		}
+		_, err = s.client.UpdateStatus(ctx, policy, metav1.UpdateOptions{})
+		if !apierrors.IsConflict(err) {
+			break
+		}
+	}
+	if err != nil {
+		klog.ErrorS(err, "Unable to update the DeschedulerPolicy status", "policy", s.name)
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/events"
+
+	deschedulerv1alpha2 "sigs.k8s.io/descheduler/pkg/apis/descheduler/v1alpha2"
+	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removefailedpods"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatingnodetaints"
+)
+
+// fakePolicyClient keeps the status written to a DeschedulerPolicy
+type fakePolicyClient struct {
+	dynamic.ResourceInterface
+	policy *unstructured.Unstructured
+	// conflicts is the number of status updates failing with a conflict
+	conflicts int
+}
+
+func (c *fakePolicyClient) Get(ctx context.Context, name string, options metav1.GetOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	return c.policy.DeepCopy(), nil
+}
+
+func (c *fakePolicyClient) UpdateStatus(ctx context.Context, obj *unstructured.Unstructured, options metav1.UpdateOptions) (*unstructured.Unstructured, error) {
+	if c.conflicts > 0 {
+		c.conflicts--
+		return nil, apierrors.NewConflict(deschedulerv1alpha2.Resource("deschedulerpolicies"), obj.GetName(), nil)
+	}
+	c.policy = obj.DeepCopy()
+	return obj, nil
+}
+
+// status returns the status written, without the condition transition times
+func (c *fakePolicyClient) status(t *testing.T) deschedulerv1alpha2.DeschedulerPolicyStatus {
+	policy := &deschedulerv1alpha2.DeschedulerPolicy{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(c.policy.Object, policy); err != nil {
+		t.Fatalf("Unable to convert the policy: %v", err)
+	}
+	for i := range policy.Status.Conditions {
+		policy.Status.Conditions[i].LastTransitionTime = metav1.Time{}
+	}
+	return policy.Status
+}
+
+func testPolicyResource(generation int64, pluginName string) *unstructured.Unstructured {
+	policy := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"profiles": []interface{}{
+				map[string]interface{}{
+					"name": "ProfileName",
+					"pluginConfig": []interface{}{
+						map[string]interface{}{"name": "DefaultEvictor"},
+						map[string]interface{}{"name": pluginName},
+					},
+					"plugins": map[string]interface{}{
+						"deschedule": map[string]interface{}{
+							"enabled": []interface{}{pluginName},
+						},
+					},
+				},
+			},
+		},
+	}}
+	policy.SetGroupVersionKind(deschedulerv1alpha2.SchemeGroupVersion.WithKind("DeschedulerPolicy"))
+	policy.SetName("policy")
+	policy.SetGeneration(generation)
+	return policy
+}
+
+func TestResourcePolicySource(t *testing.T) {
+	SetupPlugins()
+	ctx := context.Background()
+
+	policy := testPolicyResource(1, removefailedpods.PluginName)
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	if err := indexer.Add(policy); err != nil {
+		t.Fatalf("Unable to add the policy: %v", err)
+	}
+	client := &fakePolicyClient{policy: policy, conflicts: 1}
+	source := &resourcePolicySource{
+		name:   policy.GetName(),
+		lister: cache.NewGenericLister(indexer, deschedulerv1alpha2.Resource("deschedulerpolicies")),
+		client: client,
+	}
+
+	validCondition := metav1.Condition{
+		Type:               deschedulerv1alpha2.PolicyValidCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: 1,
+		Reason:             deschedulerv1alpha2.PolicyValidReason,
+		Message:            "the policy is valid",
+	}
+
+	reloader, err := newPolicyReloader(ctx, source, fakeclientset.NewSimpleClientset(), pluginregistry.PluginRegistry)
+	if err != nil {
+		t.Fatalf("Unable to load the initial policy: %v", err)
+	}
+	if diff := cmp.Diff(deschedulerv1alpha2.DeschedulerPolicyStatus{
+		ObservedGeneration: 1,
+		Conditions:         []metav1.Condition{validCondition},
+	}, client.status(t)); diff != "" {
+		t.Errorf("Unexpected status of the initial policy (-want +got):\n%s", diff)
+	}
+
+	source.reportLoop(ctx, reloader.policy, map[string]map[string]uint{
+		"ProfileName":  {removefailedpods.PluginName: 3},
+		"OtherProfile": {removefailedpods.PluginName: 5},
+	})
+	status := client.status(t)
+	if status.LastLoopTime == nil {
+		t.Errorf("Expected the last loop time to be set")
+	}
+	if diff := cmp.Diff([]deschedulerv1alpha2.ProfileStatus{
+		{
+			Name:        "ProfileName",
+			PodsEvicted: 3,
+			Plugins:     []deschedulerv1alpha2.PluginStatus{{Name: removefailedpods.PluginName, PodsEvicted: 3}},
+		},
+	}, status.Profiles); diff != "" {
+		t.Errorf("Unexpected profile statuses (-want +got):\n%s", diff)
+	}
+
+	invalid := testPolicyResource(2, "NonExistingPlugin")
+	if err := indexer.Update(invalid); err != nil {
+		t.Fatalf("Unable to update the policy: %v", err)
+	}
+	if got := reloader.reload(ctx, events.NewFakeRecorder(10)); got.Profiles[0].Plugins.Deschedule.Enabled[0] != removefailedpods.PluginName {
+		t.Errorf("Expected the last valid policy to be kept")
+	}
+	status = client.status(t)
+	if status.ObservedGeneration != 2 {
+		t.Errorf("Expected observed generation 2, got %v", status.ObservedGeneration)
+	}
+	if len(status.Conditions) != 1 || status.Conditions[0].Status != metav1.ConditionFalse || status.Conditions[0].Reason != deschedulerv1alpha2.PolicyInvalidReason || status.Conditions[0].Message == "" {
+		t.Errorf("Expected the policy to be reported invalid, got %v", status.Conditions)
+	}
+	if len(status.Profiles) != 1 {
+		t.Errorf("Expected the profile statuses of the last loop to be kept, got %v", status.Profiles)
+	}
+
+	if err := indexer.Update(testPolicyResource(3, removepodsviolatingnodetaints.PluginName)); err != nil {
+		t.Fatalf("Unable to update the policy: %v", err)
+	}
+	if got := reloader.reload(ctx, events.NewFakeRecorder(10)); got.Profiles[0].Plugins.Deschedule.Enabled[0] != removepodsviolatingnodetaints.PluginName {
+		t.Errorf("Expected the valid policy to be swapped in")
+	}
+	validCondition.ObservedGeneration = 3
+	if diff := cmp.Diff([]metav1.Condition{validCondition}, client.status(t).Conditions); diff != "" {
+		t.Errorf("Unexpected conditions of the valid policy (-want +got):\n%s", diff)
+	}
+}
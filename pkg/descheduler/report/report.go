@@ -0,0 +1,217 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package report provides a machine-readable report of the decisions
+// taken about every eviction candidate within a descheduling loop.
+// Only the pods rejected by a Filter, PreEvictionFilter or PreEvict plugin
+// and the pods whose eviction got requested are reported.
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Format is the format a report is written in
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+)
+
+// Result is the outcome of the decision about an eviction candidate
+type Result string
+
+const (
+	// Evicted means the pod got evicted, or would get evicted in the dry run mode
+	Evicted Result = "Evicted"
+	// Rejected means a Filter, PreEvictionFilter or PreEvict plugin rejected the eviction
+	Rejected Result = "Rejected"
+	// Failed means the eviction was requested but failed, e.g. because of an eviction limit
+	Failed Result = "Failed"
+)
+
+// Report lists the decisions taken within a descheduling loop
+type Report struct {
+	// StartTime is the time the descheduling loop started at
+	StartTime metav1.Time `json:"startTime"`
+	// DryRun tells whether the loop ran in the dry run mode
+	DryRun bool `json:"dryRun"`
+	// Decisions lists the decisions about the pods rejected by a plugin
+	// or whose eviction got requested
+	Decisions []Decision `json:"decisions"`
+	// NodeUtilization lists the node utilization before and after the loop,
+	// set only when a node utilization plugin is enabled
+	NodeUtilization []NodeUtilization `json:"nodeUtilization,omitempty"`
+}
+
+// Decision is the decision taken about an eviction candidate
+type Decision struct {
+	// Pod is the candidate, as namespace/name
+	Pod string `json:"pod"`
+	// Node is the node the candidate runs on
+	Node string `json:"node,omitempty"`
+	// Profile is the profile proposing the candidate
+	Profile string `json:"profile"`
+	// Plugin is the plugin proposing the candidate
+	Plugin string `json:"plugin,omitempty"`
+	// ExtensionPoint is the extension point the plugin proposed the candidate from
+	ExtensionPoint string `json:"extensionPoint,omitempty"`
+	Result         Result `json:"result"`
+	// RejectedBy is the plugin rejecting the candidate
+	RejectedBy string `json:"rejectedBy,omitempty"`
+	// RejectedAt is the extension point the candidate got rejected at, i.e. Filter, PreEvictionFilter or PreEvict
+	RejectedAt string `json:"rejectedAt,omitempty"`
+	// Reason explains the rejection or the failure, when known
+	Reason string `json:"reason,omitempty"`
+}
+
+// NodeUtilization is the utilization of a node, in percents of its allocatable resources
+type NodeUtilization struct {
+	Node   string                      `json:"node"`
+	Before map[v1.ResourceName]float64 `json:"before"`
+	After  map[v1.ResourceName]float64 `json:"after"`
+}
+
+// Recorder collects the decisions taken within a descheduling loop.
+// It is safe for concurrent use by multiple goroutines, and a nil Recorder records nothing.
+type Recorder struct {
+	lock      sync.Mutex
+	decisions []Decision
+	// seen dedups the decisions, as plugins may filter the same pod several times
+	seen map[Decision]bool
+}
+
+// NewRecorder creates an empty Recorder
+func NewRecorder() *Recorder {
+	return &Recorder{seen: map[Decision]bool{}}
+}
+
+// Record records a decision
+func (r *Recorder) Record(decision Decision) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.seen[decision] {
+		return
+	}
+	r.seen[decision] = true
+	r.decisions = append(r.decisions, decision)
+}
+
+// Decisions lists the decisions recorded, sorted so the reports of distinct loops can be diffed
+// regardless of the order the plugins processed the nodes in
+func (r *Recorder) Decisions() []Decision {
+	if r == nil {
+		return nil
+	}
+	r.lock.Lock()
+	decisions := append([]Decision{}, r.decisions...)
+	r.lock.Unlock()
+
+	sort.SliceStable(decisions, func(i, j int) bool {
+		a, b := decisions[i], decisions[j]
+		switch {
+		case a.Profile != b.Profile:
+			return a.Profile < b.Profile
+		case a.ExtensionPoint != b.ExtensionPoint:
+			return a.ExtensionPoint > b.ExtensionPoint // Deschedule first, then Balance
+		case a.Plugin != b.Plugin:
+			return a.Plugin < b.Plugin
+		case a.Pod != b.Pod:
+			return a.Pod < b.Pod
+		}
+		return a.Result < b.Result
+	})
+	return decisions
+}
+
+// ValidateFormat checks the format is supported
+func ValidateFormat(format Format) error {
+	switch format {
+	case FormatJSON, FormatYAML:
+		return nil
+	}
+	return fmt.Errorf("unsupported report format %q, expected %q or %q", format, FormatJSON, FormatYAML)
+}
+
+// Encode encodes a report in the format
+func Encode(report *Report, format Format) ([]byte, error) {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case FormatJSON:
+		return append(data, '\n'), nil
+	case FormatYAML:
+		data, err = yaml.JSONToYAML(data)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte("---\n"), data...), nil
+	}
+	return nil, ValidateFormat(format)
+}
+
+// Writer appends the report of every descheduling loop to a file,
+// as a stream of JSON objects or YAML documents
+type Writer struct {
+	path   string
+	format Format
+}
+
+// NewWriter truncates the file the reports are appended to
+func NewWriter(path string, format Format) (*Writer, error) {
+	if err := ValidateFormat(format); err != nil {
+		return nil, err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the report file: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+	return &Writer{path: path, format: format}, nil
+}
+
+// Write appends a report to the file
+func (w *Writer) Write(report *Report) error {
+	data, err := Encode(report, w.format)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(w.path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("unable to open the report file: %v", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("unable to write the report: %v", err)
+	}
+	return f.Close()
+}
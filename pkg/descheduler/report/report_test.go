@@ -0,0 +1,150 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRecorder(t *testing.T) {
+	evicted := Decision{Pod: "default/p2", Profile: "profile", Plugin: "RemovePodsViolatingNodeTaints", ExtensionPoint: "Deschedule", Result: Evicted}
+	rejected := Decision{Pod: "default/p1", Profile: "profile", Plugin: "RemovePodsViolatingNodeTaints", ExtensionPoint: "Deschedule", Result: Rejected, RejectedBy: "DefaultEvictor", RejectedAt: "Filter"}
+	balanced := Decision{Pod: "default/p3", Profile: "profile", Plugin: "LowNodeUtilization", ExtensionPoint: "Balance", Result: Evicted}
+	other := Decision{Pod: "default/p4", Profile: "another", Plugin: "PodLifeTime", ExtensionPoint: "Deschedule", Result: Failed, Reason: "maximum number of evicted pods per node reached"}
+
+	recorder := NewRecorder()
+	for _, decision := range []Decision{balanced, evicted, rejected, rejected, other} {
+		recorder.Record(decision)
+	}
+	if diff := cmp.Diff([]Decision{other, rejected, evicted, balanced}, recorder.Decisions()); diff != "" {
+		t.Errorf("Unexpected decisions (-want +got):\n%s", diff)
+	}
+
+	var nilRecorder *Recorder
+	nilRecorder.Record(evicted)
+	if decisions := nilRecorder.Decisions(); decisions != nil {
+		t.Errorf("Expected a nil recorder to record nothing, got %v", decisions)
+	}
+}
+
+func TestWriter(t *testing.T) {
+	report := &Report{
+		StartTime: metav1.NewTime(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)),
+		DryRun:    true,
+		Decisions: []Decision{{Pod: "default/p1", Node: "n1", Profile: "profile", Plugin: "PodLifeTime", ExtensionPoint: "Deschedule", Result: Evicted}},
+		NodeUtilization: []NodeUtilization{{
+			Node:   "n1",
+			Before: map[v1.ResourceName]float64{v1.ResourceCPU: 50},
+			After:  map[v1.ResourceName]float64{v1.ResourceCPU: 25},
+		}},
+	}
+
+	tests := []struct {
+		name     string
+		format   Format
+		expected string
+	}{
+		{
+			name:   "json",
+			format: FormatJSON,
+			expected: `{
+  "startTime": "2023-01-01T00:00:00Z",
+  "dryRun": true,
+  "decisions": [
+    {
+      "pod": "default/p1",
+      "node": "n1",
+      "profile": "profile",
+      "plugin": "PodLifeTime",
+      "extensionPoint": "Deschedule",
+      "result": "Evicted"
+    }
+  ],
+  "nodeUtilization": [
+    {
+      "node": "n1",
+      "before": {
+        "cpu": 50
+      },
+      "after": {
+        "cpu": 25
+      }
+    }
+  ]
+}
+`,
+		},
+		{
+			name:   "yaml",
+			format: FormatYAML,
+			expected: `---
+decisions:
+- extensionPoint: Deschedule
+  node: n1
+  plugin: PodLifeTime
+  pod: default/p1
+  profile: profile
+  result: Evicted
+dryRun: true
+nodeUtilization:
+- after:
+    cpu: 25
+  before:
+    cpu: 50
+  node: n1
+startTime: "2023-01-01T00:00:00Z"
+`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "report")
+			if err := os.WriteFile(file, []byte("stale"), 0o600); err != nil {
+				t.Fatalf("Unable to write the file: %v", err)
+			}
+			writer, err := NewWriter(file, tc.format)
+			if err != nil {
+				t.Fatalf("Unable to create the writer: %v", err)
+			}
+			// every loop appends its report to the file truncated by the writer
+			for i := 0; i < 2; i++ {
+				if err := writer.Write(report); err != nil {
+					t.Fatalf("Unable to write the report: %v", err)
+				}
+			}
+			data, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatalf("Unable to read the report: %v", err)
+			}
+			if diff := cmp.Diff(strings.Repeat(tc.expected, 2), string(data)); diff != "" {
+				t.Errorf("Unexpected report (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	if _, err := NewWriter(filepath.Join(t.TempDir(), "report"), "xml"); err == nil {
+		t.Errorf("Expected an unsupported format to be rejected")
+	}
+}
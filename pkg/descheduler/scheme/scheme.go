@@ -28,6 +28,8 @@ import (
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/nodeutilization"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/podlifetime"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/podsorting"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/remoteplugin"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removeduplicates"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removefailedpods"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodshavingtoomanyrestarts"
@@ -47,6 +49,8 @@ func init() {
 	utilruntime.Must(defaultevictor.AddToScheme(Scheme))
 	utilruntime.Must(nodeutilization.AddToScheme(Scheme))
 	utilruntime.Must(podlifetime.AddToScheme(Scheme))
+	utilruntime.Must(podsorting.AddToScheme(Scheme))
+	utilruntime.Must(remoteplugin.AddToScheme(Scheme))
 	utilruntime.Must(removeduplicates.AddToScheme(Scheme))
 	utilruntime.Must(removefailedpods.AddToScheme(Scheme))
 	utilruntime.Must(removepodshavingtoomanyrestarts.AddToScheme(Scheme))
@@ -21,6 +21,8 @@ import (
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/nodeutilization"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/podlifetime"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/podsorting"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/remoteplugin"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removeduplicates"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removefailedpods"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodshavingtoomanyrestarts"
@@ -40,6 +42,11 @@ func RegisterDefaultPlugins(registry pluginregistry.Registry) {
 	pluginregistry.Register(nodeutilization.LowNodeUtilizationPluginName, nodeutilization.NewLowNodeUtilization, &nodeutilization.LowNodeUtilization{}, &nodeutilization.LowNodeUtilizationArgs{}, nodeutilization.ValidateLowNodeUtilizationArgs, nodeutilization.SetDefaults_LowNodeUtilizationArgs, registry)
 	pluginregistry.Register(nodeutilization.HighNodeUtilizationPluginName, nodeutilization.NewHighNodeUtilization, &nodeutilization.HighNodeUtilization{}, &nodeutilization.HighNodeUtilizationArgs{}, nodeutilization.ValidateHighNodeUtilizationArgs, nodeutilization.SetDefaults_HighNodeUtilizationArgs, registry)
 	pluginregistry.Register(podlifetime.PluginName, podlifetime.New, &podlifetime.PodLifeTime{}, &podlifetime.PodLifeTimeArgs{}, podlifetime.ValidatePodLifeTimeArgs, podlifetime.SetDefaults_PodLifeTimeArgs, registry)
+	pluginregistry.Register(podsorting.PrioritySortPluginName, podsorting.NewPrioritySort, &podsorting.PrioritySort{}, &podsorting.PrioritySortArgs{}, podsorting.ValidatePrioritySortArgs, podsorting.SetDefaults_PrioritySortArgs, registry)
+	pluginregistry.Register(podsorting.QoSSortPluginName, podsorting.NewQoSSort, &podsorting.QoSSort{}, &podsorting.QoSSortArgs{}, podsorting.ValidateQoSSortArgs, podsorting.SetDefaults_QoSSortArgs, registry)
+	pluginregistry.Register(podsorting.AgeSortPluginName, podsorting.NewAgeSort, &podsorting.AgeSort{}, &podsorting.AgeSortArgs{}, podsorting.ValidateAgeSortArgs, podsorting.SetDefaults_AgeSortArgs, registry)
+	pluginregistry.Register(podsorting.PodDeletionCostSortPluginName, podsorting.NewPodDeletionCostSort, &podsorting.PodDeletionCostSort{}, &podsorting.PodDeletionCostSortArgs{}, podsorting.ValidatePodDeletionCostSortArgs, podsorting.SetDefaults_PodDeletionCostSortArgs, registry)
+	pluginregistry.Register(remoteplugin.PluginName, remoteplugin.New, &remoteplugin.RemotePlugin{}, &remoteplugin.RemotePluginArgs{}, remoteplugin.ValidateRemotePluginArgs, remoteplugin.SetDefaults_RemotePluginArgs, registry)
 	pluginregistry.Register(removeduplicates.PluginName, removeduplicates.New, &removeduplicates.RemoveDuplicates{}, &removeduplicates.RemoveDuplicatesArgs{}, removeduplicates.ValidateRemoveDuplicatesArgs, removeduplicates.SetDefaults_RemoveDuplicatesArgs, registry)
 	pluginregistry.Register(removefailedpods.PluginName, removefailedpods.New, &removefailedpods.RemoveFailedPods{}, &removefailedpods.RemoveFailedPodsArgs{}, removefailedpods.ValidateRemoveFailedPodsArgs, removefailedpods.SetDefaults_RemoveFailedPodsArgs, registry)
 	pluginregistry.Register(removepodshavingtoomanyrestarts.PluginName, removepodshavingtoomanyrestarts.New, &removepodshavingtoomanyrestarts.RemovePodsHavingTooManyRestarts{}, &removepodshavingtoomanyrestarts.RemovePodsHavingTooManyRestartsArgs{}, removepodshavingtoomanyrestarts.ValidateRemovePodsHavingTooManyRestartsArgs, removepodshavingtoomanyrestarts.SetDefaults_RemovePodsHavingTooManyRestartsArgs, registry)
@@ -0,0 +1,71 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+
+	policy "k8s.io/api/policy/v1"
+	"k8s.io/client-go/informers"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/events"
+
+	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+)
+
+// Simulate runs a single descheduling loop of the policy in the dry run mode against
+// the objects served by the client, e.g. a fake clientset loaded from a snapshot.
+// It returns the pods that would get evicted, in the eviction order.
+func Simulate(ctx context.Context, client clientset.Interface, deschedulerPolicy *api.DeschedulerPolicy) ([]evictions.Eviction, error) {
+	rs, err := options.NewDeschedulerServer()
+	if err != nil {
+		return nil, err
+	}
+	rs.Client = client
+	rs.DryRun = true
+	rs.DisableMetrics = true
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sharedInformerFactory := informers.NewSharedInformerFactory(client, 0)
+	nodeLister := sharedInformerFactory.Core().V1().Nodes().Lister()
+	descheduler, err := newDescheduler(ctx, rs, deschedulerPolicy, policy.SchemeGroupVersion.String(), &events.FakeRecorder{}, sharedInformerFactory)
+	if err != nil {
+		return nil, err
+	}
+	sharedInformerFactory.Start(ctx.Done())
+	sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+	var nodeSelector string
+	if deschedulerPolicy.NodeSelector != nil {
+		nodeSelector = *deschedulerPolicy.NodeSelector
+	}
+	nodes, err := nodeutil.ReadyNodes(ctx, client, nodeLister, nodeSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := descheduler.runDeschedulerLoop(ctx, nodes)
+	if err != nil {
+		return nil, err
+	}
+	return summary.evictions, nil
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatingnodetaints"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const simulateTestSnapshot = `
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Node
+  metadata:
+    name: tainted
+  spec:
+    taints:
+    - key: dedicated
+      value: infra
+      effect: NoSchedule
+  status:
+    conditions:
+    - type: Ready
+      status: "True"
+- apiVersion: v1
+  kind: Node
+  metadata:
+    name: untainted
+  status:
+    conditions:
+    - type: Ready
+      status: "True"
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: default
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: intolerant
+  namespace: default
+  ownerReferences:
+  - apiVersion: apps/v1
+    kind: ReplicaSet
+    name: rs
+    uid: rs-uid
+spec:
+  nodeName: tainted
+  containers:
+  - name: c
+    image: image
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: tolerant
+  namespace: default
+  ownerReferences:
+  - apiVersion: apps/v1
+    kind: ReplicaSet
+    name: rs
+    uid: rs-uid
+spec:
+  nodeName: tainted
+  tolerations:
+  - key: dedicated
+    operator: Exists
+  containers:
+  - name: c
+    image: image
+---
+apiVersion: policy/v1
+kind: PodDisruptionBudget
+metadata:
+  name: pdb
+  namespace: default
+spec:
+  minAvailable: 1
+`
+
+func TestSimulate(t *testing.T) {
+	SetupPlugins()
+	dir := t.TempDir()
+	snapshotFile := filepath.Join(dir, "snapshot.yaml")
+	if err := os.WriteFile(snapshotFile, []byte(simulateTestSnapshot), 0o600); err != nil {
+		t.Fatalf("Unable to write the snapshot: %v", err)
+	}
+	policyFile := filepath.Join(dir, "policy.yaml")
+	if err := os.WriteFile(policyFile, []byte(reloaderTestPolicyFor(removepodsviolatingnodetaints.PluginName)), 0o600); err != nil {
+		t.Fatalf("Unable to write the policy: %v", err)
+	}
+
+	objects, err := LoadSnapshot(snapshotFile)
+	if err != nil {
+		t.Fatalf("Unable to load the snapshot: %v", err)
+	}
+	client := fakeclientset.NewSimpleClientset(objects...)
+	policy, err := LoadPolicyConfig(policyFile, client, pluginregistry.PluginRegistry)
+	if err != nil {
+		t.Fatalf("Unable to load the policy: %v", err)
+	}
+
+	evicted, err := Simulate(context.Background(), client, policy)
+	if err != nil {
+		t.Fatalf("Unable to simulate the policy: %v", err)
+	}
+	if len(evicted) != 1 {
+		t.Fatalf("Expected a single pod to be evicted, got %v", len(evicted))
+	}
+	if evicted[0].Pod.Name != "intolerant" {
+		t.Errorf("Expected the intolerant pod to be evicted, got %v", evicted[0].Pod.Name)
+	}
+	if evicted[0].Opts.ProfileName != "ProfileName" || evicted[0].Opts.PluginName != removepodsviolatingnodetaints.PluginName || evicted[0].Opts.ExtensionPoint != string(frameworktypes.DescheduleExtensionPoint) {
+		t.Errorf("Unexpected eviction options: %+v", evicted[0].Opts)
+	}
+
+	// the snapshot is left untouched, only the dry run copy of it gets the pods evicted
+	pods, err := client.CoreV1().Pods("default").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Unable to list pods: %v", err)
+	}
+	if len(pods.Items) != 2 {
+		t.Errorf("Expected the snapshot to keep both pods, got %v", len(pods.Items))
+	}
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+)
+
+// LoadSnapshot reads the objects of a cluster snapshot file, e.g. nodes, pods, namespaces,
+// priority classes and pod disruption budgets. The file holds YAML or JSON objects,
+// either one per document or as the items of a v1 List.
+func LoadSnapshot(snapshotFile string) ([]runtime.Object, error) {
+	data, err := os.ReadFile(snapshotFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot file %q: %v", snapshotFile, err)
+	}
+	objects, err := decodeSnapshot(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot file %q: %v", snapshotFile, err)
+	}
+	return objects, nil
+}
+
+func decodeSnapshot(data []byte) ([]runtime.Object, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	deserializer := scheme.Codecs.UniversalDeserializer()
+
+	var objects []runtime.Object
+	for {
+		raw := runtime.RawExtension{}
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return objects, nil
+			}
+			return nil, err
+		}
+		raw.Raw = bytes.TrimSpace(raw.Raw)
+		if len(raw.Raw) == 0 || bytes.Equal(raw.Raw, []byte("null")) {
+			continue
+		}
+
+		obj, _, err := deserializer.Decode(raw.Raw, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		list, ok := obj.(*v1.List)
+		if !ok {
+			objects = append(objects, obj)
+			continue
+		}
+		for _, item := range list.Items {
+			obj, _, err := deserializer.Decode(item.Raw, nil, nil)
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, obj)
+		}
+	}
+}
+
+// TakeSnapshot lists the objects the descheduler consumes, i.e. the nodes, pods, namespaces
+// and priority classes, along with the pod disruption budgets and the owners of the pods,
+// including the owners of the owners, e.g. the deployments of the replica sets.
+func TakeSnapshot(ctx context.Context, client clientset.Interface) ([]runtime.Object, error) {
+	var objects []runtime.Object
+	for _, list := range []func() (runtime.Object, error){
+		func() (runtime.Object, error) { return client.CoreV1().Nodes().List(ctx, metav1.ListOptions{}) },
+		func() (runtime.Object, error) { return client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{}) },
+		func() (runtime.Object, error) {
+			return client.SchedulingV1().PriorityClasses().List(ctx, metav1.ListOptions{})
+		},
+		func() (runtime.Object, error) {
+			return client.PolicyV1().PodDisruptionBudgets(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+		},
+	} {
+		items, err := listItems(list())
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, items...)
+	}
+
+	pods, err := listItems(client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+	owners, err := listPodOwners(ctx, client, pods)
+	if err != nil {
+		return nil, err
+	}
+	objects = append(objects, pods...)
+	objects = append(objects, owners...)
+
+	for _, obj := range objects {
+		if err := setObjectKind(obj); err != nil {
+			return nil, err
+		}
+		// managed fields are of no use to the descheduler, they only make the snapshot larger
+		obj.(metav1.Object).SetManagedFields(nil)
+	}
+	return objects, nil
+}
+
+// listPodOwners lists the workloads owning the pods, directly or through other workloads
+func listPodOwners(ctx context.Context, client clientset.Interface, pods []runtime.Object) ([]runtime.Object, error) {
+	var candidates []runtime.Object
+	for _, list := range []func() (runtime.Object, error){
+		func() (runtime.Object, error) {
+			return client.AppsV1().ReplicaSets(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+		},
+		func() (runtime.Object, error) {
+			return client.AppsV1().Deployments(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+		},
+		func() (runtime.Object, error) {
+			return client.AppsV1().StatefulSets(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+		},
+		func() (runtime.Object, error) {
+			return client.AppsV1().DaemonSets(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+		},
+		func() (runtime.Object, error) {
+			return client.CoreV1().ReplicationControllers(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+		},
+		func() (runtime.Object, error) {
+			return client.BatchV1().Jobs(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+		},
+		func() (runtime.Object, error) {
+			return client.BatchV1().CronJobs(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+		},
+	} {
+		items, err := listItems(list())
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, items...)
+	}
+
+	byUID := map[types.UID]metav1.Object{}
+	for _, candidate := range candidates {
+		obj := candidate.(metav1.Object)
+		byUID[obj.GetUID()] = obj
+	}
+	referenced := map[types.UID]bool{}
+	var queue []metav1.Object
+	for _, pod := range pods {
+		queue = append(queue, pod.(metav1.Object))
+	}
+	for len(queue) > 0 {
+		obj := queue[0]
+		queue = queue[1:]
+		for _, ownerRef := range obj.GetOwnerReferences() {
+			owner, ok := byUID[ownerRef.UID]
+			if !ok || referenced[ownerRef.UID] {
+				continue
+			}
+			referenced[ownerRef.UID] = true
+			queue = append(queue, owner)
+		}
+	}
+
+	var owners []runtime.Object
+	for _, candidate := range candidates {
+		if referenced[candidate.(metav1.Object).GetUID()] {
+			owners = append(owners, candidate)
+		}
+	}
+	return owners, nil
+}
+
+// listItems returns the items of a list
+func listItems(list runtime.Object, err error) ([]runtime.Object, error) {
+	if err != nil {
+		return nil, fmt.Errorf("unable to take the snapshot: %v", err)
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, fmt.Errorf("unable to extract the list items: %v", err)
+	}
+	return items, nil
+}
+
+// setObjectKind sets the apiVersion and kind of a typed object, the clients leave them empty
+func setObjectKind(obj runtime.Object) error {
+	gvks, _, err := scheme.Scheme.ObjectKinds(obj)
+	if err != nil {
+		return err
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvks[0])
+	return nil
+}
+
+// WriteSnapshot writes the objects of a snapshot as the items of a v1 List in the YAML format,
+// as read by LoadSnapshot
+func WriteSnapshot(w io.Writer, objects []runtime.Object) error {
+	list := &v1.List{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "List"},
+	}
+	for _, obj := range objects {
+		if obj.GetObjectKind().GroupVersionKind().Empty() {
+			if err := setObjectKind(obj); err != nil {
+				return err
+			}
+		}
+		raw, err := json.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		list.Items = append(list.Items, runtime.RawExtension{Raw: raw})
+	}
+	data, err := json.Marshal(list)
+	if err != nil {
+		return err
+	}
+	data, err = yaml.JSONToYAML(data)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
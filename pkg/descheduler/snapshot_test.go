@@ -0,0 +1,167 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatingnodetaints"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func snapshotKinds(objects []runtime.Object) []string {
+	var kinds []string
+	for _, obj := range objects {
+		kinds = append(kinds, obj.GetObjectKind().GroupVersionKind().Kind+"/"+obj.(metav1.Object).GetName())
+	}
+	return kinds
+}
+
+func TestLoadSnapshot(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "snapshot.yaml")
+	if err := os.WriteFile(file, []byte(simulateTestSnapshot), 0o600); err != nil {
+		t.Fatalf("Unable to write the snapshot: %v", err)
+	}
+	objects, err := LoadSnapshot(file)
+	if err != nil {
+		t.Fatalf("Unable to load the snapshot: %v", err)
+	}
+	expected := []string{"Node/tainted", "Node/untainted", "Namespace/default", "Pod/intolerant", "Pod/tolerant", "PodDisruptionBudget/pdb"}
+	if diff := cmp.Diff(expected, snapshotKinds(objects)); diff != "" {
+		t.Errorf("Unexpected snapshot objects (-want +got):\n%s", diff)
+	}
+
+	if err := os.WriteFile(file, []byte("kind: Unknown\napiVersion: v1\n"), 0o600); err != nil {
+		t.Fatalf("Unable to write the snapshot: %v", err)
+	}
+	if _, err := LoadSnapshot(file); err == nil {
+		t.Errorf("Expected a snapshot with an unknown kind to fail loading")
+	}
+}
+
+func TestTakeSnapshot(t *testing.T) {
+	ctx := context.Background()
+	node := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	node.ManagedFields = []metav1.ManagedFieldsEntry{{Manager: "kubelet"}}
+
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "deployment", Namespace: "default", UID: "deployment-uid"}}
+	replicaSet := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+		Name:            "replicaset",
+		Namespace:       "default",
+		UID:             "replicaset-uid",
+		OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "Deployment", Name: deployment.Name, UID: deployment.UID}},
+	}}
+	unrelated := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "default", UID: "unrelated-uid"}}
+	pod := test.BuildTestPod("pod", 100, 0, node.Name, func(pod *v1.Pod) {
+		pod.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: replicaSet.Name, UID: replicaSet.UID}}
+	})
+	namespace := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+
+	client := fakeclientset.NewSimpleClientset(node, namespace, pod, deployment, replicaSet, unrelated)
+	objects, err := TakeSnapshot(ctx, client)
+	if err != nil {
+		t.Fatalf("Unable to take the snapshot: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSnapshot(&buf, objects); err != nil {
+		t.Fatalf("Unable to write the snapshot: %v", err)
+	}
+	loaded, err := decodeSnapshot(buf.Bytes())
+	if err != nil {
+		t.Fatalf("Unable to decode the snapshot written: %v", err)
+	}
+	expected := []string{"Node/n1", "Namespace/default", "Pod/pod", "ReplicaSet/replicaset", "Deployment/deployment"}
+	if diff := cmp.Diff(expected, snapshotKinds(loaded)); diff != "" {
+		t.Errorf("Unexpected snapshot objects (-want +got):\n%s", diff)
+	}
+	if managedFields := loaded[0].(*v1.Node).ManagedFields; managedFields != nil {
+		t.Errorf("Expected the managed fields to be dropped, got %v", managedFields)
+	}
+}
+
+func TestAnonymizeSnapshot(t *testing.T) {
+	SetupPlugins()
+	objects, err := decodeSnapshot([]byte(simulateTestSnapshot))
+	if err != nil {
+		t.Fatalf("Unable to decode the snapshot: %v", err)
+	}
+	pod := objects[3].(*v1.Pod)
+	pod.Labels = map[string]string{"app": "frontend", "app.kubernetes.io/name": "frontend"}
+	pod.Annotations = map[string]string{"owner": "team", "descheduler.alpha.kubernetes.io/evict": "true", v1.LastAppliedConfigAnnotation: "{}"}
+	pod.Spec.Containers[0].Env = []v1.EnvVar{{Name: "PASSWORD", Value: "secret"}}
+	pod.Spec.Containers[0].Resources.Requests = v1.ResourceList{v1.ResourceCPU: resource.MustParse("100m")}
+	objects[0].(*v1.Node).Labels = map[string]string{v1.LabelHostname: "tainted"}
+
+	anonymized, err := AnonymizeSnapshot(objects, []byte("salt"))
+	if err != nil {
+		t.Fatalf("Unable to anonymize the snapshot: %v", err)
+	}
+	if objects[3].(*v1.Pod).Name != "intolerant" {
+		t.Errorf("Expected the snapshot objects to be left untouched")
+	}
+
+	a := &snapshotAnonymizer{salt: []byte("salt")}
+	node := anonymized[0].(*v1.Node)
+	if node.Name != a.name("tainted") || node.Labels[v1.LabelHostname] != node.Name {
+		t.Errorf("Expected the node name and hostname label to match, got %v and %v", node.Name, node.Labels)
+	}
+	if namespace := anonymized[2].(*v1.Namespace); namespace.Name != "default" {
+		t.Errorf("Expected the default namespace to be kept, got %v", namespace.Name)
+	}
+	anonymizedPod := anonymized[3].(*v1.Pod)
+	if diff := cmp.Diff(map[string]string{a.name("app"): a.name("frontend"), "app.kubernetes.io/name": a.name("frontend")}, anonymizedPod.Labels); diff != "" {
+		t.Errorf("Unexpected pod labels (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(map[string]string{"descheduler.alpha.kubernetes.io/evict": "true"}, anonymizedPod.Annotations); diff != "" {
+		t.Errorf("Unexpected pod annotations (-want +got):\n%s", diff)
+	}
+	if anonymizedPod.Spec.NodeName != node.Name || anonymizedPod.Spec.Containers[0].Env != nil || anonymizedPod.Spec.Containers[0].Resources.Requests.Cpu().MilliValue() != 100 {
+		t.Errorf("Unexpected pod spec: %+v", anonymizedPod.Spec)
+	}
+
+	// the anonymized snapshot is descheduled just like the original one
+	client := fakeclientset.NewSimpleClientset(anonymized...)
+	policyFile := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(policyFile, []byte(reloaderTestPolicyFor(removepodsviolatingnodetaints.PluginName)), 0o600); err != nil {
+		t.Fatalf("Unable to write the policy: %v", err)
+	}
+	policy, err := LoadPolicyConfig(policyFile, client, pluginregistry.PluginRegistry)
+	if err != nil {
+		t.Fatalf("Unable to load the policy: %v", err)
+	}
+	evicted, err := Simulate(context.Background(), client, policy)
+	if err != nil {
+		t.Fatalf("Unable to simulate the policy: %v", err)
+	}
+	if len(evicted) != 1 || evicted[0].Pod.Name != a.name("intolerant") {
+		t.Errorf("Expected the anonymized intolerant pod to be evicted, got %v", evicted)
+	}
+}
@@ -0,0 +1,405 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// AnonymizeSnapshot replaces the names, labels and other identifying values of the snapshot objects
+// with salted hashes. The same value is always replaced with the same hash, so the objects keep
+// referencing each other, e.g. a pod keeps matching the node it runs on, the selectors of its pod
+// disruption budgets and its owners, and the pods keep tolerating the same taints.
+// Well-known label, annotation and taint keys, i.e. the kubernetes.io and k8s.io ones, are kept.
+// The container commands, arguments and environment are dropped, and so are the volume sources
+// other than host paths, empty dirs and persistent volume claims.
+func AnonymizeSnapshot(objects []runtime.Object, salt []byte) ([]runtime.Object, error) {
+	a := &snapshotAnonymizer{salt: salt}
+	anonymized := make([]runtime.Object, 0, len(objects))
+	for _, obj := range objects {
+		obj = obj.DeepCopyObject()
+		switch o := obj.(type) {
+		case *v1.Node:
+			a.objectMeta(&o.ObjectMeta)
+			a.node(o)
+		case *v1.Namespace:
+			a.objectMeta(&o.ObjectMeta)
+		case *schedulingv1.PriorityClass:
+			a.objectMeta(&o.ObjectMeta)
+		case *v1.Pod:
+			a.objectMeta(&o.ObjectMeta)
+			a.podSpec(&o.Spec)
+			a.podStatus(&o.Status)
+		case *policy.PodDisruptionBudget:
+			a.objectMeta(&o.ObjectMeta)
+			o.Spec.Selector = a.labelSelector(o.Spec.Selector)
+			disruptedPods := make(map[string]metav1.Time, len(o.Status.DisruptedPods))
+			for name, time := range o.Status.DisruptedPods {
+				disruptedPods[a.name(name)] = time
+			}
+			o.Status.DisruptedPods = disruptedPods
+			a.conditions(o.Status.Conditions)
+		case *appsv1.ReplicaSet:
+			a.objectMeta(&o.ObjectMeta)
+			o.Spec.Selector = a.labelSelector(o.Spec.Selector)
+			a.podTemplate(&o.Spec.Template)
+			o.Status = appsv1.ReplicaSetStatus{}
+		case *appsv1.Deployment:
+			a.objectMeta(&o.ObjectMeta)
+			o.Spec.Selector = a.labelSelector(o.Spec.Selector)
+			a.podTemplate(&o.Spec.Template)
+			o.Status = appsv1.DeploymentStatus{}
+		case *appsv1.StatefulSet:
+			a.objectMeta(&o.ObjectMeta)
+			o.Spec.Selector = a.labelSelector(o.Spec.Selector)
+			a.podTemplate(&o.Spec.Template)
+			o.Spec.ServiceName = a.name(o.Spec.ServiceName)
+			o.Spec.VolumeClaimTemplates = nil
+			o.Status = appsv1.StatefulSetStatus{}
+		case *appsv1.DaemonSet:
+			a.objectMeta(&o.ObjectMeta)
+			o.Spec.Selector = a.labelSelector(o.Spec.Selector)
+			a.podTemplate(&o.Spec.Template)
+			o.Status = appsv1.DaemonSetStatus{}
+		case *v1.ReplicationController:
+			a.objectMeta(&o.ObjectMeta)
+			o.Spec.Selector = a.labels(o.Spec.Selector)
+			if o.Spec.Template != nil {
+				a.podTemplate(o.Spec.Template)
+			}
+			o.Status = v1.ReplicationControllerStatus{}
+		case *batchv1.Job:
+			a.objectMeta(&o.ObjectMeta)
+			o.Spec.Selector = a.labelSelector(o.Spec.Selector)
+			a.podTemplate(&o.Spec.Template)
+			o.Status = batchv1.JobStatus{}
+		case *batchv1.CronJob:
+			a.objectMeta(&o.ObjectMeta)
+			a.objectMeta(&o.Spec.JobTemplate.ObjectMeta)
+			o.Spec.JobTemplate.Spec.Selector = a.labelSelector(o.Spec.JobTemplate.Spec.Selector)
+			a.podTemplate(&o.Spec.JobTemplate.Spec.Template)
+			o.Status = batchv1.CronJobStatus{}
+		default:
+			return nil, fmt.Errorf("unable to anonymize %v objects", obj.GetObjectKind().GroupVersionKind().Kind)
+		}
+		anonymized = append(anonymized, obj)
+	}
+	return anonymized, nil
+}
+
+// snapshotAnonymizer replaces identifying values with their salted hashes
+type snapshotAnonymizer struct {
+	salt []byte
+}
+
+// hash replaces a value with its salted hash, which is a valid name as well as a valid label key and value
+func (a *snapshotAnonymizer) hash(value string) string {
+	if value == "" {
+		return value
+	}
+	h := sha256.New()
+	h.Write(a.salt)
+	h.Write([]byte(value))
+	return "anon-" + hex.EncodeToString(h.Sum(nil))[:10]
+}
+
+// name replaces an object name or a label value, keeping the well-known names of the system namespaces
+// and priority classes, so the label values referencing the names, e.g. kubernetes.io/hostname, keep matching
+func (a *snapshotAnonymizer) name(name string) string {
+	if name == metav1.NamespaceDefault || strings.HasPrefix(name, "kube-") || strings.HasPrefix(name, "system-") {
+		return name
+	}
+	return a.hash(name)
+}
+
+// key replaces a label, annotation or taint key unless it is a well-known one
+func (a *snapshotAnonymizer) key(key string) string {
+	if isWellKnownKey(key) {
+		return key
+	}
+	return a.hash(key)
+}
+
+func isWellKnownKey(key string) bool {
+	prefix, _, found := strings.Cut(key, "/")
+	if !found {
+		return false
+	}
+	for _, domain := range []string{"kubernetes.io", "k8s.io"} {
+		if prefix == domain || strings.HasSuffix(prefix, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *snapshotAnonymizer) labels(labels map[string]string) map[string]string {
+	if labels == nil {
+		return nil
+	}
+	anonymized := make(map[string]string, len(labels))
+	for key, value := range labels {
+		anonymized[a.key(key)] = a.name(value)
+	}
+	return anonymized
+}
+
+func (a *snapshotAnonymizer) values(values []string) []string {
+	for i := range values {
+		values[i] = a.name(values[i])
+	}
+	return values
+}
+
+func (a *snapshotAnonymizer) keys(keys []string) []string {
+	for i := range keys {
+		keys[i] = a.key(keys[i])
+	}
+	return keys
+}
+
+func (a *snapshotAnonymizer) objectMeta(meta *metav1.ObjectMeta) {
+	meta.Name = a.name(meta.Name)
+	meta.Namespace = a.name(meta.Namespace)
+	meta.GenerateName = ""
+	meta.Labels = a.labels(meta.Labels)
+	var annotations map[string]string
+	for key, value := range meta.Annotations {
+		// the last applied configuration holds the whole object
+		if !isWellKnownKey(key) || key == v1.LastAppliedConfigAnnotation {
+			continue
+		}
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[key] = value
+	}
+	meta.Annotations = annotations
+	for i := range meta.OwnerReferences {
+		meta.OwnerReferences[i].Name = a.name(meta.OwnerReferences[i].Name)
+	}
+	meta.ManagedFields = nil
+}
+
+func (a *snapshotAnonymizer) labelSelector(selector *metav1.LabelSelector) *metav1.LabelSelector {
+	if selector == nil {
+		return nil
+	}
+	selector.MatchLabels = a.labels(selector.MatchLabels)
+	for i := range selector.MatchExpressions {
+		selector.MatchExpressions[i].Key = a.key(selector.MatchExpressions[i].Key)
+		selector.MatchExpressions[i].Values = a.values(selector.MatchExpressions[i].Values)
+	}
+	return selector
+}
+
+func (a *snapshotAnonymizer) nodeSelectorTerm(term *v1.NodeSelectorTerm) {
+	for i := range term.MatchExpressions {
+		term.MatchExpressions[i].Key = a.key(term.MatchExpressions[i].Key)
+		term.MatchExpressions[i].Values = a.values(term.MatchExpressions[i].Values)
+	}
+	// the only supported field is the node name
+	for i := range term.MatchFields {
+		for j := range term.MatchFields[i].Values {
+			term.MatchFields[i].Values[j] = a.name(term.MatchFields[i].Values[j])
+		}
+	}
+}
+
+func (a *snapshotAnonymizer) podAffinityTerm(term *v1.PodAffinityTerm) {
+	term.LabelSelector = a.labelSelector(term.LabelSelector)
+	term.NamespaceSelector = a.labelSelector(term.NamespaceSelector)
+	for i := range term.Namespaces {
+		term.Namespaces[i] = a.name(term.Namespaces[i])
+	}
+	term.TopologyKey = a.key(term.TopologyKey)
+}
+
+func (a *snapshotAnonymizer) affinity(affinity *v1.Affinity) {
+	if affinity == nil {
+		return
+	}
+	if nodeAffinity := affinity.NodeAffinity; nodeAffinity != nil {
+		if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+			for i := range nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+				a.nodeSelectorTerm(&nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[i])
+			}
+		}
+		for i := range nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+			a.nodeSelectorTerm(&nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution[i].Preference)
+		}
+	}
+	if podAffinity := affinity.PodAffinity; podAffinity != nil {
+		for i := range podAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+			a.podAffinityTerm(&podAffinity.RequiredDuringSchedulingIgnoredDuringExecution[i])
+		}
+		for i := range podAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+			a.podAffinityTerm(&podAffinity.PreferredDuringSchedulingIgnoredDuringExecution[i].PodAffinityTerm)
+		}
+	}
+	if podAntiAffinity := affinity.PodAntiAffinity; podAntiAffinity != nil {
+		for i := range podAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+			a.podAffinityTerm(&podAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution[i])
+		}
+		for i := range podAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+			a.podAffinityTerm(&podAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution[i].PodAffinityTerm)
+		}
+	}
+}
+
+func (a *snapshotAnonymizer) podTemplate(template *v1.PodTemplateSpec) {
+	a.objectMeta(&template.ObjectMeta)
+	a.podSpec(&template.Spec)
+}
+
+func (a *snapshotAnonymizer) podSpec(spec *v1.PodSpec) {
+	spec.NodeName = a.name(spec.NodeName)
+	spec.NodeSelector = a.labels(spec.NodeSelector)
+	a.affinity(spec.Affinity)
+	for i := range spec.Tolerations {
+		spec.Tolerations[i].Key = a.key(spec.Tolerations[i].Key)
+		spec.Tolerations[i].Value = a.name(spec.Tolerations[i].Value)
+	}
+	for i := range spec.TopologySpreadConstraints {
+		constraint := &spec.TopologySpreadConstraints[i]
+		constraint.TopologyKey = a.key(constraint.TopologyKey)
+		constraint.LabelSelector = a.labelSelector(constraint.LabelSelector)
+		constraint.MatchLabelKeys = a.keys(constraint.MatchLabelKeys)
+	}
+	spec.PriorityClassName = a.name(spec.PriorityClassName)
+	spec.ServiceAccountName = a.name(spec.ServiceAccountName)
+	spec.DeprecatedServiceAccount = a.name(spec.DeprecatedServiceAccount)
+	spec.Hostname = a.name(spec.Hostname)
+	spec.Subdomain = a.name(spec.Subdomain)
+	spec.ImagePullSecrets = nil
+	spec.HostAliases = nil
+	for i := range spec.InitContainers {
+		a.container(&spec.InitContainers[i])
+	}
+	for i := range spec.Containers {
+		a.container(&spec.Containers[i])
+	}
+	spec.EphemeralContainers = nil
+
+	for i := range spec.Volumes {
+		volume := &spec.Volumes[i]
+		volume.Name = a.name(volume.Name)
+		switch {
+		case volume.HostPath != nil:
+			volume.VolumeSource = v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: "/" + a.hash(volume.HostPath.Path), Type: volume.HostPath.Type}}
+		case volume.EmptyDir != nil:
+			volume.VolumeSource = v1.VolumeSource{EmptyDir: volume.EmptyDir}
+		case volume.PersistentVolumeClaim != nil:
+			volume.VolumeSource = v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: a.name(volume.PersistentVolumeClaim.ClaimName), ReadOnly: volume.PersistentVolumeClaim.ReadOnly}}
+		case volume.Ephemeral != nil:
+			volume.VolumeSource = v1.VolumeSource{Ephemeral: &v1.EphemeralVolumeSource{}}
+		default:
+			// the descheduler tells apart just the local storage and the persistent volume claims
+			volume.VolumeSource = v1.VolumeSource{Projected: &v1.ProjectedVolumeSource{}}
+		}
+	}
+}
+
+func (a *snapshotAnonymizer) container(container *v1.Container) {
+	*container = v1.Container{
+		Name:            a.name(container.Name),
+		Image:           a.name(container.Image),
+		Ports:           container.Ports,
+		Resources:       container.Resources,
+		ResizePolicy:    container.ResizePolicy,
+		RestartPolicy:   container.RestartPolicy,
+		ImagePullPolicy: container.ImagePullPolicy,
+	}
+	for i := range container.Ports {
+		container.Ports[i].Name = ""
+		container.Ports[i].HostIP = ""
+	}
+}
+
+func (a *snapshotAnonymizer) podStatus(status *v1.PodStatus) {
+	status.Message = ""
+	status.NominatedNodeName = a.name(status.NominatedNodeName)
+	status.HostIP = ""
+	status.HostIPs = nil
+	status.PodIP = ""
+	status.PodIPs = nil
+	for i := range status.Conditions {
+		status.Conditions[i].Message = ""
+	}
+	for _, statuses := range [][]v1.ContainerStatus{status.InitContainerStatuses, status.ContainerStatuses} {
+		for i := range statuses {
+			a.containerStatus(&statuses[i])
+		}
+	}
+	status.EphemeralContainerStatuses = nil
+	status.ResourceClaimStatuses = nil
+}
+
+func (a *snapshotAnonymizer) containerStatus(status *v1.ContainerStatus) {
+	status.Name = a.name(status.Name)
+	status.Image = a.name(status.Image)
+	status.ImageID = ""
+	status.ContainerID = ""
+	for _, state := range []*v1.ContainerState{&status.State, &status.LastTerminationState} {
+		if state.Waiting != nil {
+			state.Waiting.Message = ""
+		}
+		if state.Terminated != nil {
+			state.Terminated.Message = ""
+			state.Terminated.ContainerID = ""
+		}
+	}
+}
+
+func (a *snapshotAnonymizer) node(node *v1.Node) {
+	node.Spec.PodCIDR = ""
+	node.Spec.PodCIDRs = nil
+	node.Spec.ProviderID = ""
+	node.Spec.ConfigSource = nil
+	for i := range node.Spec.Taints {
+		node.Spec.Taints[i].Key = a.key(node.Spec.Taints[i].Key)
+		node.Spec.Taints[i].Value = a.name(node.Spec.Taints[i].Value)
+	}
+	node.Status.Addresses = nil
+	node.Status.Images = nil
+	node.Status.VolumesInUse = nil
+	node.Status.VolumesAttached = nil
+	node.Status.Config = nil
+	node.Status.NodeInfo.MachineID = ""
+	node.Status.NodeInfo.SystemUUID = ""
+	node.Status.NodeInfo.BootID = ""
+	for i := range node.Status.Conditions {
+		node.Status.Conditions[i].Message = ""
+	}
+}
+
+func (a *snapshotAnonymizer) conditions(conditions []metav1.Condition) {
+	for i := range conditions {
+		conditions[i].Message = ""
+	}
+}
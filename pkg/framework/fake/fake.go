@@ -9,6 +9,7 @@ import (
 
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/framework/parallelize"
 	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
 )
 
@@ -17,10 +18,12 @@ type HandleImpl struct {
 	GetPodsAssignedToNodeFuncImpl podutil.GetPodsAssignedToNodeFunc
 	SharedInformerFactoryImpl     informers.SharedInformerFactory
 	EvictorFilterImpl             frameworktypes.EvictorPlugin
+	SortPluginImpl                frameworktypes.SortPlugin
 	PodEvictorImpl                *evictions.PodEvictor
+	ParallelizerImpl              parallelize.Parallelizer
 }
 
-var _ frameworktypes.Handle = &HandleImpl{}
+var _ frameworktypes.ParallelizerHandle = &HandleImpl{}
 
 func (hi *HandleImpl) ClientSet() clientset.Interface {
 	return hi.ClientsetImpl
@@ -34,6 +37,10 @@ func (hi *HandleImpl) SharedInformerFactory() informers.SharedInformerFactory {
 	return hi.SharedInformerFactoryImpl
 }
 
+func (hi *HandleImpl) Parallelizer() parallelize.Parallelizer {
+	return hi.ParallelizerImpl
+}
+
 func (hi *HandleImpl) Evictor() frameworktypes.Evictor {
 	return hi
 }
@@ -53,3 +60,15 @@ func (hi *HandleImpl) Evict(ctx context.Context, pod *v1.Pod, opts evictions.Evi
 func (hi *HandleImpl) NodeLimitExceeded(node *v1.Node) bool {
 	return hi.PodEvictorImpl.NodeLimitExceeded(node)
 }
+
+func (hi *HandleImpl) Sort(pods []*v1.Pod) {
+	if hi.SortPluginImpl != nil {
+		podutil.SortPods(pods, hi.SortPluginImpl.Less)
+	}
+}
+
+func (hi *HandleImpl) SortReversed(pods []*v1.Pod) {
+	if hi.SortPluginImpl != nil {
+		podutil.SortPodsReversed(pods, hi.SortPluginImpl.Less)
+	}
+}
@@ -130,6 +130,7 @@ func (d *FakePlugin) Deschedule(ctx context.Context, nodes []*v1.Node) *framewor
 			handle:         d.handle,
 			extensionPoint: string(frameworktypes.DescheduleExtensionPoint),
 		},
+		ctx:   ctx,
 		nodes: nodes,
 	})
 }
@@ -140,6 +141,7 @@ func (d *FakePlugin) Balance(ctx context.Context, nodes []*v1.Node) *frameworkty
 			handle:         d.handle,
 			extensionPoint: string(frameworktypes.BalanceExtensionPoint),
 		},
+		ctx:   ctx,
 		nodes: nodes,
 	})
 }
@@ -207,6 +209,7 @@ func (d *FakeDeschedulePlugin) Deschedule(ctx context.Context, nodes []*v1.Node)
 			handle:         d.handle,
 			extensionPoint: string(frameworktypes.DescheduleExtensionPoint),
 		},
+		ctx:   ctx,
 		nodes: nodes,
 	})
 }
@@ -294,6 +297,7 @@ func (d *FakeBalancePlugin) Balance(ctx context.Context, nodes []*v1.Node) *fram
 			handle:         d.handle,
 			extensionPoint: string(frameworktypes.BalanceExtensionPoint),
 		},
+		ctx:   ctx,
 		nodes: nodes,
 	})
 }
@@ -14,6 +14,8 @@ limitations under the License.
 package plugin
 
 import (
+	"context"
+
 	v1 "k8s.io/api/core/v1"
 	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
 )
@@ -55,12 +57,16 @@ type DescheduleAction interface {
 	Action
 	CanDeschedule() bool
 	Nodes() []*v1.Node
+	// Context is the context the plugin got called with
+	Context() context.Context
 }
 
 type BalanceAction interface {
 	Action
 	CanBalance() bool
 	Nodes() []*v1.Node
+	// Context is the context the plugin got called with
+	Context() context.Context
 }
 
 type FilterAction interface {
@@ -95,6 +101,7 @@ func (a ActionImpl) DeepCopy() Action {
 
 type DescheduleActionImpl struct {
 	ActionImpl
+	ctx   context.Context
 	nodes []*v1.Node
 }
 
@@ -106,6 +113,10 @@ func (d DescheduleActionImpl) Nodes() []*v1.Node {
 	return d.nodes
 }
 
+func (d DescheduleActionImpl) Context() context.Context {
+	return d.ctx
+}
+
 func (a DescheduleActionImpl) DeepCopy() Action {
 	nodesCopy := []*v1.Node{}
 	for _, node := range a.nodes {
@@ -113,12 +124,14 @@ func (a DescheduleActionImpl) DeepCopy() Action {
 	}
 	return DescheduleActionImpl{
 		ActionImpl: a.ActionImpl.DeepCopy().(ActionImpl),
+		ctx:        a.ctx,
 		nodes:      nodesCopy,
 	}
 }
 
 type BalanceActionImpl struct {
 	ActionImpl
+	ctx   context.Context
 	nodes []*v1.Node
 }
 
@@ -130,6 +143,10 @@ func (d BalanceActionImpl) Nodes() []*v1.Node {
 	return d.nodes
 }
 
+func (d BalanceActionImpl) Context() context.Context {
+	return d.ctx
+}
+
 func (a BalanceActionImpl) DeepCopy() Action {
 	nodesCopy := []*v1.Node{}
 	for _, node := range a.nodes {
@@ -137,6 +154,7 @@ func (a BalanceActionImpl) DeepCopy() Action {
 	}
 	return BalanceActionImpl{
 		ActionImpl: a.ActionImpl.DeepCopy().(ActionImpl),
+		ctx:        a.ctx,
 		nodes:      nodesCopy,
 	}
 }
@@ -0,0 +1,72 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parallelize
+
+import (
+	"context"
+	"math"
+
+	"k8s.io/client-go/util/workqueue"
+)
+
+// DefaultParallelism is the number of workers used when no parallelism is configured.
+// A single worker processes all the pieces one after another in their order.
+const DefaultParallelism int = 1
+
+// Parallelizer runs pieces of work with a bounded number of workers
+type Parallelizer struct {
+	parallelism int
+}
+
+// NewParallelizer returns a Parallelizer running at most parallelism workers.
+// A parallelism lower than one falls back to DefaultParallelism.
+func NewParallelizer(parallelism int) Parallelizer {
+	if parallelism < 1 {
+		parallelism = DefaultParallelism
+	}
+	return Parallelizer{parallelism: parallelism}
+}
+
+// Parallelism returns the maximum number of workers
+func (p Parallelizer) Parallelism() int {
+	if p.parallelism < 1 {
+		return DefaultParallelism
+	}
+	return p.parallelism
+}
+
+// chunkSizeFor returns a chunk size for the given number of items to use for
+// parallel work. The size aims to produce good CPU utilization.
+// returns max(1, min(sqrt(n), n/Parallelism))
+func chunkSizeFor(n, parallelism int) int {
+	s := int(math.Sqrt(float64(n)))
+
+	if r := n/parallelism + 1; s > r {
+		s = r
+	}
+	if s < 1 {
+		s = 1
+	}
+	return s
+}
+
+// Until is a wrapper around workqueue.ParallelizeUntil to use in descheduling plugins.
+// It returns once all the pieces are processed or the context is done.
+func (p Parallelizer) Until(ctx context.Context, pieces int, doWorkPiece workqueue.DoWorkPieceFunc) {
+	parallelism := p.Parallelism()
+	workqueue.ParallelizeUntil(ctx, parallelism, pieces, doWorkPiece, workqueue.WithChunkSize(chunkSizeFor(pieces, parallelism)))
+}
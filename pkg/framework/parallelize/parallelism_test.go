@@ -0,0 +1,101 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parallelize
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+)
+
+func TestChunkSize(t *testing.T) {
+	tests := []struct {
+		input       int
+		parallelism int
+		wantOutput  int
+	}{
+		{
+			input:       32,
+			parallelism: 16,
+			wantOutput:  3,
+		},
+		{
+			input:       16,
+			parallelism: 16,
+			wantOutput:  2,
+		},
+		{
+			input:       1,
+			parallelism: 16,
+			wantOutput:  1,
+		},
+		{
+			input:       0,
+			parallelism: 16,
+			wantOutput:  1,
+		},
+		{
+			input:       3000,
+			parallelism: 1,
+			wantOutput:  54,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%d with parallelism %d", test.input, test.parallelism), func(t *testing.T) {
+			if chunkSizeFor(test.input, test.parallelism) != test.wantOutput {
+				t.Errorf("Expected: %d, got: %d", test.wantOutput, chunkSizeFor(test.input, test.parallelism))
+			}
+		})
+	}
+}
+
+func TestUntil(t *testing.T) {
+	tests := []struct {
+		name        string
+		parallelism int
+	}{
+		{
+			name:        "default parallelism",
+			parallelism: 0,
+		},
+		{
+			name:        "several workers",
+			parallelism: 4,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var processed int32
+			pieces := make([]int32, 100)
+			NewParallelizer(test.parallelism).Until(context.Background(), len(pieces), func(i int) {
+				atomic.AddInt32(&pieces[i], 1)
+				atomic.AddInt32(&processed, 1)
+			})
+			if processed != int32(len(pieces)) {
+				t.Errorf("Expected %d processed pieces, got %d", len(pieces), processed)
+			}
+			for i, count := range pieces {
+				if count != 1 {
+					t.Errorf("Expected piece %d to be processed once, got %d", i, count)
+				}
+			}
+		})
+	}
+}
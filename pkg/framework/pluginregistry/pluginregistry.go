@@ -69,3 +69,17 @@ func Register(
 		}
 	}
 }
+
+// InstanceNamer is implemented by the args of the plugins which can be configured several times within a profile.
+// A profile refers to every instance by the name its args give it rather than by the name the plugin is registered with.
+type InstanceNamer interface {
+	InstanceName() string
+}
+
+// InstanceName returns the name the args give to their plugin instance, empty when they give none
+func InstanceName(args runtime.Object) string {
+	if namer, ok := args.(InstanceNamer); ok {
+		return namer.InstanceName()
+	}
+	return ""
+}
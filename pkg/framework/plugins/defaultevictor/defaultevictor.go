@@ -35,7 +35,11 @@ const (
 	evictPodAnnotationKey = "descheduler.alpha.kubernetes.io/evict"
 )
 
-var _ frameworktypes.EvictorPlugin = &DefaultEvictor{}
+var (
+	_ frameworktypes.EvictorPlugin                  = &DefaultEvictor{}
+	_ frameworktypes.FilterReasonsPlugin            = &DefaultEvictor{}
+	_ frameworktypes.PreEvictionFilterReasonsPlugin = &DefaultEvictor{}
+)
 
 type constraint func(pod *v1.Pod) error
 
@@ -152,27 +156,36 @@ func (d *DefaultEvictor) Name() string {
 }
 
 func (d *DefaultEvictor) PreEvictionFilter(pod *v1.Pod) bool {
+	return len(d.PreEvictionFilterReasons(pod)) == 0
+}
+
+// PreEvictionFilterReasons checks if a pod can be evicted right before eviction and tells why not
+func (d *DefaultEvictor) PreEvictionFilterReasons(pod *v1.Pod) []string {
 	defaultEvictorArgs := d.args.(*DefaultEvictorArgs)
 	if defaultEvictorArgs.NodeFit {
 		nodes, err := nodeutil.ReadyNodes(context.TODO(), d.handle.ClientSet(), d.handle.SharedInformerFactory().Core().V1().Nodes().Lister(), defaultEvictorArgs.NodeSelector)
 		if err != nil {
 			klog.ErrorS(err, "unable to list ready nodes", "pod", klog.KObj(pod))
-			return false
+			return []string{"unable to list ready nodes"}
 		}
 		if !nodeutil.PodFitsAnyOtherNode(d.handle.GetPodsAssignedToNodeFunc(), pod, nodes) {
 			klog.InfoS("pod does not fit on any other node because of nodeSelector(s), Taint(s), or nodes marked as unschedulable", "pod", klog.KObj(pod))
-			return false
+			return []string{"pod does not fit on any other node"}
 		}
-		return true
 	}
-	return true
+	return nil
 }
 
 func (d *DefaultEvictor) Filter(pod *v1.Pod) bool {
+	return len(d.FilterReasons(pod)) == 0
+}
+
+// FilterReasons checks if a pod can be evicted and tells why not, a reason per failed check
+func (d *DefaultEvictor) FilterReasons(pod *v1.Pod) []string {
 	checkErrs := []error{}
 
 	if HaveEvictAnnotation(pod) {
-		return true
+		return nil
 	}
 
 	ownerRefList := podutil.OwnerRef(pod)
@@ -198,10 +211,13 @@ func (d *DefaultEvictor) Filter(pod *v1.Pod) bool {
 		}
 	}
 
-	if len(checkErrs) > 0 {
-		klog.V(4).InfoS("Pod fails the following checks", "pod", klog.KObj(pod), "checks", errors.NewAggregate(checkErrs).Error())
-		return false
+	if len(checkErrs) == 0 {
+		return nil
 	}
-
-	return true
+	klog.V(4).InfoS("Pod fails the following checks", "pod", klog.KObj(pod), "checks", errors.NewAggregate(checkErrs).Error())
+	reasons := make([]string, 0, len(checkErrs))
+	for _, err := range checkErrs {
+		reasons = append(reasons, err.Error())
+	}
+	return reasons
 }
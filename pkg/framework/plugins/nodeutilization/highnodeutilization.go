@@ -109,19 +109,19 @@ func (h *HighNodeUtilization) Balance(ctx context.Context, nodes []*v1.Node) *fr
 
 	if len(sourceNodes) == 0 {
 		klog.V(1).InfoS("No node is underutilized, nothing to do here, you might tune your thresholds further")
-		return nil
+		return frameworktypes.NewStatus(frameworktypes.Skip, "no node is underutilized")
 	}
 	if len(sourceNodes) <= h.args.NumberOfNodes {
 		klog.V(1).InfoS("Number of nodes underutilized is less or equal than NumberOfNodes, nothing to do here", "underutilizedNodes", len(sourceNodes), "numberOfNodes", h.args.NumberOfNodes)
-		return nil
+		return frameworktypes.NewStatus(frameworktypes.Skip, "number of nodes underutilized is less or equal than NumberOfNodes")
 	}
 	if len(sourceNodes) == len(nodes) {
 		klog.V(1).InfoS("All nodes are underutilized, nothing to do here")
-		return nil
+		return frameworktypes.NewStatus(frameworktypes.Skip, "all nodes are underutilized")
 	}
 	if len(highNodes) == 0 {
 		klog.V(1).InfoS("No node is available to schedule the pods, nothing to do here")
-		return nil
+		return frameworktypes.NewStatus(frameworktypes.Skip, "no node is available to schedule the pods")
 	}
 
 	// stop if the total available usage has dropped to zero - no more pods can be scheduled
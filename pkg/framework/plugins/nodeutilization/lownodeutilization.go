@@ -150,22 +150,22 @@ func (l *LowNodeUtilization) Balance(ctx context.Context, nodes []*v1.Node) *fra
 
 	if len(lowNodes) == 0 {
 		klog.V(1).InfoS("No node is underutilized, nothing to do here, you might tune your thresholds further")
-		return nil
+		return frameworktypes.NewStatus(frameworktypes.Skip, "no node is underutilized")
 	}
 
 	if len(lowNodes) <= l.args.NumberOfNodes {
 		klog.V(1).InfoS("Number of nodes underutilized is less or equal than NumberOfNodes, nothing to do here", "underutilizedNodes", len(lowNodes), "numberOfNodes", l.args.NumberOfNodes)
-		return nil
+		return frameworktypes.NewStatus(frameworktypes.Skip, "number of nodes underutilized is less or equal than NumberOfNodes")
 	}
 
 	if len(lowNodes) == len(nodes) {
 		klog.V(1).InfoS("All nodes are underutilized, nothing to do here")
-		return nil
+		return frameworktypes.NewStatus(frameworktypes.Skip, "all nodes are underutilized")
 	}
 
 	if len(sourceNodes) == 0 {
 		klog.V(1).InfoS("All nodes are under target utilization, nothing to do here")
-		return nil
+		return frameworktypes.NewStatus(frameworktypes.Skip, "all nodes are under target utilization")
 	}
 
 	// stop if node utilization drops below target threshold or any of required capacity (cpu, memory, pods) is moved
@@ -29,17 +29,23 @@ import (
 
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/framework/parallelize"
 )
 
 const PluginName = "PodLifeTime"
 
-var _ frameworktypes.DeschedulePlugin = &PodLifeTime{}
+var (
+	_ frameworktypes.DeschedulePlugin = &PodLifeTime{}
+	_ frameworktypes.ExplainPlugin    = &PodLifeTime{}
+)
 
 // PodLifeTime evicts pods on the node that violate the max pod lifetime threshold
 type PodLifeTime struct {
 	handle    frameworktypes.Handle
 	args      *PodLifeTimeArgs
 	podFilter podutil.FilterFunc
+	// explainSelection tells why the namespaces and the label selector exclude a pod
+	explainSelection func(*v1.Pod) error
 }
 
 // New builds plugin from its arguments while passing a handle
@@ -55,46 +61,60 @@ func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plug
 		excludedNamespaces = sets.New(podLifeTimeArgs.Namespaces.Exclude...)
 	}
 
-	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
-	podFilter, err := podutil.NewOptions().
-		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+	podSelection := podutil.NewOptions().
 		WithNamespaces(includedNamespaces).
 		WithoutNamespaces(excludedNamespaces).
-		WithLabelSelector(podLifeTimeArgs.LabelSelector).
+		WithLabelSelector(podLifeTimeArgs.LabelSelector)
+	explainSelection, err := podSelection.BuildExplainFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podSelection.
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
 		BuildFilterFunc()
 	if err != nil {
 		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
 	}
 
 	podFilter = podutil.WrapFilterFuncs(podFilter, func(pod *v1.Pod) bool {
-		podAgeSeconds := uint(metav1.Now().Sub(pod.GetCreationTimestamp().Local()).Seconds())
-		return podAgeSeconds > *podLifeTimeArgs.MaxPodLifeTimeSeconds
+		return podAgeSeconds(pod) > *podLifeTimeArgs.MaxPodLifeTimeSeconds
 	})
 
 	if len(podLifeTimeArgs.States) > 0 {
 		states := sets.New(podLifeTimeArgs.States...)
 		podFilter = podutil.WrapFilterFuncs(podFilter, func(pod *v1.Pod) bool {
-			if states.Has(string(pod.Status.Phase)) {
-				return true
-			}
-
-			for _, containerStatus := range pod.Status.ContainerStatuses {
-				if containerStatus.State.Waiting != nil && states.Has(containerStatus.State.Waiting.Reason) {
-					return true
-				}
-			}
-
-			return false
+			return podInStates(pod, states)
 		})
 	}
 
 	return &PodLifeTime{
-		handle:    handle,
-		podFilter: podFilter,
-		args:      podLifeTimeArgs,
+		handle:           handle,
+		podFilter:        podFilter,
+		args:             podLifeTimeArgs,
+		explainSelection: explainSelection,
 	}, nil
 }
 
+func podAgeSeconds(pod *v1.Pod) uint {
+	return uint(metav1.Now().Sub(pod.GetCreationTimestamp().Local()).Seconds())
+}
+
+// podInStates checks whether the pod phase or the reason a container of the pod is waiting for is among the states
+func podInStates(pod *v1.Pod, states sets.Set[string]) bool {
+	if states.Has(string(pod.Status.Phase)) {
+		return true
+	}
+
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if containerStatus.State.Waiting != nil && states.Has(containerStatus.State.Waiting.Reason) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Name retrieves the plugin name
 func (d *PodLifeTime) Name() string {
 	return PluginName
@@ -104,24 +124,39 @@ func (d *PodLifeTime) Name() string {
 func (d *PodLifeTime) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
 	podsToEvict := make([]*v1.Pod, 0)
 	nodeMap := make(map[string]*v1.Node, len(nodes))
-
-	for _, node := range nodes {
-		klog.V(2).InfoS("Processing node", "node", klog.KObj(node))
-		pods, err := podutil.ListAllPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
+	podsOnNodes := make([][]*v1.Pod, len(nodes))
+
+	// Pods are listed concurrently but evicted in a single pass
+	// so the oldest pods across all nodes are evicted first
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	errCh := parallelize.NewErrorChannel()
+	frameworktypes.GetParallelizer(d.handle).Until(ctx, len(nodes), func(i int) {
+		klog.V(2).InfoS("Processing node", "node", klog.KObj(nodes[i]))
+		pods, err := podutil.ListAllPodsOnANode(nodes[i].Name, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
 		if err != nil {
-			// no pods evicted as error encountered retrieving evictable Pods
-			return &frameworktypes.Status{
-				Err: fmt.Errorf("error listing pods on a node: %v", err),
-			}
+			errCh.SendErrorWithCancel(err, cancel)
+			return
+		}
+		podsOnNodes[i] = pods
+	})
+	if err := errCh.ReceiveError(); err != nil {
+		// no pods evicted as error encountered retrieving evictable Pods
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error listing pods on a node: %v", err),
 		}
+	}
 
+	for i, node := range nodes {
 		nodeMap[node.Name] = node
-		podsToEvict = append(podsToEvict, pods...)
+		podsToEvict = append(podsToEvict, podsOnNodes[i]...)
 	}
 
 	// Should sort Pods so that the oldest can be evicted first
 	// in the event that PDB or settings such maxNoOfPodsToEvictPer* prevent too much eviction
 	podutil.SortPodsBasedOnAge(podsToEvict)
+	// Pods the configured sort plugins can tell apart are ordered accordingly
+	d.handle.Evictor().Sort(podsToEvict)
 
 	for _, pod := range podsToEvict {
 		if !d.handle.Evictor().NodeLimitExceeded(nodeMap[pod.Spec.NodeName]) {
@@ -131,3 +166,18 @@ func (d *PodLifeTime) Deschedule(ctx context.Context, nodes []*v1.Node) *framewo
 
 	return nil
 }
+
+// Explain tells whether the pod is selected for eviction, regardless of the evictor
+func (d *PodLifeTime) Explain(pod *v1.Pod, nodes []*v1.Node) (bool, string) {
+	if err := d.explainSelection(pod); err != nil {
+		return false, err.Error()
+	}
+	age := podAgeSeconds(pod)
+	if age <= *d.args.MaxPodLifeTimeSeconds {
+		return false, fmt.Sprintf("pod age %ds <= maxPodLifeTimeSeconds %d", age, *d.args.MaxPodLifeTimeSeconds)
+	}
+	if len(d.args.States) > 0 && !podInStates(pod, sets.New(d.args.States...)) {
+		return false, fmt.Sprintf("pod phase %s and waiting reasons match none of the states %v", pod.Status.Phase, d.args.States)
+	}
+	return true, fmt.Sprintf("pod age %ds > maxPodLifeTimeSeconds %d", age, *d.args.MaxPodLifeTimeSeconds)
+}
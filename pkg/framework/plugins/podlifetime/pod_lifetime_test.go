@@ -31,6 +31,7 @@ import (
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	frameworkfake "sigs.k8s.io/descheduler/pkg/framework/fake"
+	"sigs.k8s.io/descheduler/pkg/framework/parallelize"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
 	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
 	"sigs.k8s.io/descheduler/test"
@@ -378,6 +379,7 @@ func TestPodLifeTime(t *testing.T) {
 			plugin, err := New(tc.args, &frameworkfake.HandleImpl{
 				ClientsetImpl:                 fakeClient,
 				PodEvictorImpl:                podEvictor,
+				ParallelizerImpl:              parallelize.NewParallelizer(4),
 				EvictorFilterImpl:             evictorFilter.(frameworktypes.EvictorPlugin),
 				GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
 			})
@@ -0,0 +1,183 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podsorting
+
+import (
+	"fmt"
+	"strconv"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+const (
+	PrioritySortPluginName        = "PrioritySort"
+	QoSSortPluginName             = "QoSSort"
+	AgeSortPluginName             = "AgeSort"
+	PodDeletionCostSortPluginName = "PodDeletionCostSort"
+)
+
+var (
+	_ frameworktypes.PreSortPlugin = &PrioritySort{}
+	_ frameworktypes.SortPlugin    = &PrioritySort{}
+	_ frameworktypes.PreSortPlugin = &QoSSort{}
+	_ frameworktypes.SortPlugin    = &QoSSort{}
+	_ frameworktypes.PreSortPlugin = &AgeSort{}
+	_ frameworktypes.SortPlugin    = &AgeSort{}
+	_ frameworktypes.PreSortPlugin = &PodDeletionCostSort{}
+	_ frameworktypes.SortPlugin    = &PodDeletionCostSort{}
+)
+
+// PrioritySort orders pods by their priority from low to high.
+// Pods without priority are ordered first.
+type PrioritySort struct{}
+
+// NewPrioritySort builds plugin from its arguments while passing a handle
+func NewPrioritySort(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	if _, ok := args.(*PrioritySortArgs); !ok {
+		return nil, fmt.Errorf("want args to be of type PrioritySortArgs, got %T", args)
+	}
+	return &PrioritySort{}, nil
+}
+
+// Name retrieves the plugin name
+func (ps *PrioritySort) Name() string {
+	return PrioritySortPluginName
+}
+
+// PreLess reports whether pod1 should be evicted before pod2
+func (ps *PrioritySort) PreLess(pod1, pod2 *v1.Pod) bool {
+	return ps.Less(pod1, pod2)
+}
+
+// Less reports whether pod1 should be evicted before pod2
+func (ps *PrioritySort) Less(pod1, pod2 *v1.Pod) bool {
+	if pod1.Spec.Priority == nil {
+		return pod2.Spec.Priority != nil
+	}
+	if pod2.Spec.Priority == nil {
+		return false
+	}
+	return *pod1.Spec.Priority < *pod2.Spec.Priority
+}
+
+// QoSSort orders pods by their QoS class in the following order:
+// BestEffort, Burstable, Guaranteed
+type QoSSort struct{}
+
+// NewQoSSort builds plugin from its arguments while passing a handle
+func NewQoSSort(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	if _, ok := args.(*QoSSortArgs); !ok {
+		return nil, fmt.Errorf("want args to be of type QoSSortArgs, got %T", args)
+	}
+	return &QoSSort{}, nil
+}
+
+// Name retrieves the plugin name
+func (qs *QoSSort) Name() string {
+	return QoSSortPluginName
+}
+
+// PreLess reports whether pod1 should be evicted before pod2
+func (qs *QoSSort) PreLess(pod1, pod2 *v1.Pod) bool {
+	return qs.Less(pod1, pod2)
+}
+
+// Less reports whether pod1 should be evicted before pod2
+func (qs *QoSSort) Less(pod1, pod2 *v1.Pod) bool {
+	return qosRank(pod1) < qosRank(pod2)
+}
+
+func qosRank(pod *v1.Pod) int {
+	switch utils.GetPodQOS(pod) {
+	case v1.PodQOSBestEffort:
+		return 0
+	case v1.PodQOSBurstable:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// AgeSort orders pods from the oldest to the most recent one
+type AgeSort struct{}
+
+// NewAgeSort builds plugin from its arguments while passing a handle
+func NewAgeSort(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	if _, ok := args.(*AgeSortArgs); !ok {
+		return nil, fmt.Errorf("want args to be of type AgeSortArgs, got %T", args)
+	}
+	return &AgeSort{}, nil
+}
+
+// Name retrieves the plugin name
+func (as *AgeSort) Name() string {
+	return AgeSortPluginName
+}
+
+// PreLess reports whether pod1 should be evicted before pod2
+func (as *AgeSort) PreLess(pod1, pod2 *v1.Pod) bool {
+	return as.Less(pod1, pod2)
+}
+
+// Less reports whether pod1 should be evicted before pod2
+func (as *AgeSort) Less(pod1, pod2 *v1.Pod) bool {
+	return pod1.CreationTimestamp.Before(&pod2.CreationTimestamp)
+}
+
+// PodDeletionCostSort orders pods by the value of their
+// controller.kubernetes.io/pod-deletion-cost annotation from low to high,
+// the same way the ReplicaSet controller picks pods to delete when scaling down.
+// Pods without the annotation, or with an invalid value, have a cost of 0.
+type PodDeletionCostSort struct{}
+
+// NewPodDeletionCostSort builds plugin from its arguments while passing a handle
+func NewPodDeletionCostSort(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	if _, ok := args.(*PodDeletionCostSortArgs); !ok {
+		return nil, fmt.Errorf("want args to be of type PodDeletionCostSortArgs, got %T", args)
+	}
+	return &PodDeletionCostSort{}, nil
+}
+
+// Name retrieves the plugin name
+func (pdcs *PodDeletionCostSort) Name() string {
+	return PodDeletionCostSortPluginName
+}
+
+// PreLess reports whether pod1 should be evicted before pod2
+func (pdcs *PodDeletionCostSort) PreLess(pod1, pod2 *v1.Pod) bool {
+	return pdcs.Less(pod1, pod2)
+}
+
+// Less reports whether pod1 should be evicted before pod2
+func (pdcs *PodDeletionCostSort) Less(pod1, pod2 *v1.Pod) bool {
+	return podDeletionCost(pod1) < podDeletionCost(pod2)
+}
+
+func podDeletionCost(pod *v1.Pod) int32 {
+	value, found := pod.Annotations[v1.PodDeletionCost]
+	if !found {
+		return 0
+	}
+	cost, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		klog.V(4).InfoS("Ignoring invalid pod deletion cost", "pod", klog.KObj(pod), "value", value)
+		return 0
+	}
+	return int32(cost)
+}
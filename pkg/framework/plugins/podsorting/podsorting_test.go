@@ -0,0 +1,133 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podsorting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func podNames(pods []*v1.Pod) []string {
+	names := []string{}
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+	return names
+}
+
+func TestSortPlugins(t *testing.T) {
+	now := metav1.Now()
+
+	withPriority := func(priority int32) func(*v1.Pod) {
+		return func(pod *v1.Pod) {
+			test.SetPodPriority(pod, priority)
+		}
+	}
+	withAge := func(age time.Duration) func(*v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.CreationTimestamp = metav1.NewTime(now.Add(-age))
+		}
+	}
+	withDeletionCost := func(cost string) func(*v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.Annotations = map[string]string{v1.PodDeletionCost: cost}
+		}
+	}
+
+	tests := []struct {
+		name     string
+		less     podutil.LessFunc
+		pods     []*v1.Pod
+		expected []string
+	}{
+		{
+			name: "PrioritySort orders pods from low to high priority, pods without priority first",
+			less: (&PrioritySort{}).Less,
+			pods: []*v1.Pod{
+				test.BuildTestPod("p1", 100, 0, "n1", withPriority(100)),
+				test.BuildTestPod("p2", 100, 0, "n1", withPriority(10)),
+				test.BuildTestPod("p3", 100, 0, "n1", nil),
+				test.BuildTestPod("p4", 100, 0, "n1", withPriority(1000)),
+			},
+			expected: []string{"p3", "p2", "p1", "p4"},
+		},
+		{
+			name: "QoSSort orders BestEffort, Burstable and Guaranteed pods",
+			less: (&QoSSort{}).Less,
+			pods: []*v1.Pod{
+				test.BuildTestPod("p1", 100, 100, "n1", test.MakeGuaranteedPod),
+				test.BuildTestPod("p2", 100, 100, "n1", test.MakeBurstablePod),
+				test.BuildTestPod("p3", 100, 100, "n1", test.MakeBestEffortPod),
+			},
+			expected: []string{"p3", "p2", "p1"},
+		},
+		{
+			name: "AgeSort orders pods from the oldest",
+			less: (&AgeSort{}).Less,
+			pods: []*v1.Pod{
+				test.BuildTestPod("p1", 100, 0, "n1", withAge(time.Minute)),
+				test.BuildTestPod("p2", 100, 0, "n1", withAge(time.Hour)),
+				test.BuildTestPod("p3", 100, 0, "n1", withAge(time.Second)),
+			},
+			expected: []string{"p2", "p1", "p3"},
+		},
+		{
+			name: "PodDeletionCostSort orders pods from the lowest cost, missing and invalid costs are 0",
+			less: (&PodDeletionCostSort{}).Less,
+			pods: []*v1.Pod{
+				test.BuildTestPod("p1", 100, 0, "n1", withDeletionCost("100")),
+				test.BuildTestPod("p2", 100, 0, "n1", nil),
+				test.BuildTestPod("p3", 100, 0, "n1", withDeletionCost("-100")),
+				test.BuildTestPod("p4", 100, 0, "n1", withDeletionCost("invalid")),
+				test.BuildTestPod("p5", 100, 0, "n1", withDeletionCost("10")),
+			},
+			expected: []string{"p3", "p2", "p4", "p5", "p1"},
+		},
+		{
+			name: "PrioritySort followed by PodDeletionCostSort breaks priority ties by cost",
+			less: podutil.WrapLessFuncs((&PrioritySort{}).Less, (&PodDeletionCostSort{}).Less),
+			pods: []*v1.Pod{
+				test.BuildTestPod("p1", 100, 0, "n1", func(pod *v1.Pod) {
+					withPriority(10)(pod)
+					withDeletionCost("5")(pod)
+				}),
+				test.BuildTestPod("p2", 100, 0, "n1", func(pod *v1.Pod) {
+					withPriority(10)(pod)
+					withDeletionCost("-5")(pod)
+				}),
+				test.BuildTestPod("p3", 100, 0, "n1", func(pod *v1.Pod) {
+					withPriority(100)(pod)
+					withDeletionCost("-50")(pod)
+				}),
+			},
+			expected: []string{"p2", "p1", "p3"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			podutil.SortPods(tc.pods, tc.less)
+			if diff := cmp.Diff(tc.expected, podNames(tc.pods)); diff != "" {
+				t.Errorf("unexpected pod order (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podsorting
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ValidatePrioritySortArgs validates PrioritySort arguments
+func ValidatePrioritySortArgs(obj runtime.Object) error {
+	if _, ok := obj.(*PrioritySortArgs); !ok {
+		return fmt.Errorf("want args to be of type PrioritySortArgs, got %T", obj)
+	}
+	return nil
+}
+
+// ValidateQoSSortArgs validates QoSSort arguments
+func ValidateQoSSortArgs(obj runtime.Object) error {
+	if _, ok := obj.(*QoSSortArgs); !ok {
+		return fmt.Errorf("want args to be of type QoSSortArgs, got %T", obj)
+	}
+	return nil
+}
+
+// ValidateAgeSortArgs validates AgeSort arguments
+func ValidateAgeSortArgs(obj runtime.Object) error {
+	if _, ok := obj.(*AgeSortArgs); !ok {
+		return fmt.Errorf("want args to be of type AgeSortArgs, got %T", obj)
+	}
+	return nil
+}
+
+// ValidatePodDeletionCostSortArgs validates PodDeletionCostSort arguments
+func ValidatePodDeletionCostSortArgs(obj runtime.Object) error {
+	if _, ok := obj.(*PodDeletionCostSortArgs); !ok {
+		return fmt.Errorf("want args to be of type PodDeletionCostSortArgs, got %T", obj)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remoteplugin
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	return RegisterDefaults(scheme)
+}
+
+// SetDefaults_RemotePluginArgs
+// TODO: the final default values would be discussed in community
+func SetDefaults_RemotePluginArgs(obj runtime.Object) {
+	args := obj.(*RemotePluginArgs)
+	if args.Timeout == nil {
+		args.Timeout = &metav1.Duration{Duration: 5 * time.Second}
+	}
+	if args.FailurePolicy == "" {
+		args.FailurePolicy = Fail
+	}
+	if args.MaxPodsPerRequest == nil {
+		maxPodsPerRequest := uint(DefaultMaxPodsPerRequest)
+		args.MaxPodsPerRequest = &maxPodsPerRequest
+	}
+}
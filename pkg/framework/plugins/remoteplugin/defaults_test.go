@@ -0,0 +1,67 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remoteplugin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestSetDefaults_RemotePluginArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   runtime.Object
+		want runtime.Object
+	}{
+		{
+			name: "RemotePluginArgs empty",
+			in:   &RemotePluginArgs{},
+			want: &RemotePluginArgs{
+				Timeout:           &metav1.Duration{Duration: 5 * time.Second},
+				FailurePolicy:     Fail,
+				MaxPodsPerRequest: func(i uint) *uint { return &i }(DefaultMaxPodsPerRequest),
+			},
+		},
+		{
+			name: "RemotePluginArgs with value",
+			in: &RemotePluginArgs{
+				Endpoint:          "http://localhost:8080",
+				Timeout:           &metav1.Duration{Duration: time.Second},
+				FailurePolicy:     Ignore,
+				MaxPodsPerRequest: func(i uint) *uint { return &i }(10),
+			},
+			want: &RemotePluginArgs{
+				Endpoint:          "http://localhost:8080",
+				Timeout:           &metav1.Duration{Duration: time.Second},
+				FailurePolicy:     Ignore,
+				MaxPodsPerRequest: func(i uint) *uint { return &i }(10),
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetDefaults_RemotePluginArgs(tc.in)
+			if diff := cmp.Diff(tc.in, tc.want); diff != "" {
+				t.Errorf("Got unexpected defaults (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
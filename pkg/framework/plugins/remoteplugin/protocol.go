@@ -0,0 +1,65 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remoteplugin
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// Paths served by an out-of-process plugin server, relative to its endpoint.
+// Every call is a POST request with a JSON encoded body answered with a JSON encoded body.
+const (
+	DeschedulePath        = "/deschedule"
+	BalancePath           = "/balance"
+	FilterPath            = "/filter"
+	PreEvictionFilterPath = "/preevictionfilter"
+)
+
+// EvictionRequest is sent on the Deschedule and Balance extension points.
+// It carries a snapshot of the processed nodes and all the pods assigned to them.
+type EvictionRequest struct {
+	Nodes []v1.Node `json:"nodes"`
+	Pods  []v1.Pod  `json:"pods"`
+}
+
+// EvictionResponse lists the pods the out-of-process plugin wants to evict, in eviction order.
+// Candidates are still subject to the Filter and PreEvictionFilter extension points and to
+// the eviction limits before they get evicted.
+type EvictionResponse struct {
+	Candidates []EvictionCandidate `json:"candidates"`
+}
+
+// EvictionCandidate references a pod from the EvictionRequest to be evicted
+type EvictionCandidate struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Reason allows for passing details about the specific eviction for logging.
+	Reason string `json:"reason,omitempty"`
+}
+
+// FilterRequest is sent on the Filter and PreEvictionFilter extension points
+type FilterRequest struct {
+	Pod v1.Pod `json:"pod"`
+}
+
+// FilterResponse carries the verdict of the out-of-process plugin for a pod
+type FilterResponse struct {
+	Allowed bool `json:"allowed"`
+	// Reason explains why a pod is not allowed to be evicted. It labels the pods_filtered
+	// metric, so it should not vary from pod to pod.
+	Reason string `json:"reason,omitempty"`
+}
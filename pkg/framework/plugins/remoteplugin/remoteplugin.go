@@ -0,0 +1,367 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remoteplugin
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const PluginName = "RemotePlugin"
+
+// DefaultMaxPodsPerRequest is the default number of pods sent within a single Deschedule or Balance call
+const DefaultMaxPodsPerRequest = 1000
+
+// maxErrorBodySize limits how much of an error response body gets reported
+const maxErrorBodySize = 1024
+
+// RemotePlugin delegates the Deschedule, Balance, Filter and PreEvictionFilter
+// extension points to an out-of-process plugin server speaking JSON over HTTP.
+type RemotePlugin struct {
+	handle            frameworktypes.Handle
+	args              *RemotePluginArgs
+	name              string
+	endpoint          string
+	timeout           time.Duration
+	maxPodsPerRequest int
+	client            *http.Client
+	// bearerToken authenticates the calls to the plugin server when set
+	bearerToken string
+	// ctx is the context of the descheduling loop the Filter and PreEvictionFilter calls are bound to
+	ctx context.Context
+
+	// verdicts caches the verdicts of the plugin server about every pod revision, so a pod filtered
+	// by several plugins within a loop gets sent once. The plugin is built for every loop, so is the cache.
+	verdictsLock sync.Mutex
+	verdicts     map[verdictKey][]string
+}
+
+// verdictKey identifies the verdict of the plugin server about a pod revision at an extension point
+type verdictKey struct {
+	path            string
+	pod             types.NamespacedName
+	uid             types.UID
+	resourceVersion string
+}
+
+var (
+	_ frameworktypes.DeschedulePlugin = &RemotePlugin{}
+	_ frameworktypes.BalancePlugin    = &RemotePlugin{}
+	_ frameworktypes.EvictorPlugin    = &RemotePlugin{}
+
+	_ frameworktypes.FilterReasonsPlugin            = &RemotePlugin{}
+	_ frameworktypes.PreEvictionFilterReasonsPlugin = &RemotePlugin{}
+
+	_ pluginregistry.InstanceNamer = &RemotePluginArgs{}
+)
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	remotePluginArgs, ok := args.(*RemotePluginArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type RemotePluginArgs, got %T", args)
+	}
+
+	name := PluginName
+	if remotePluginArgs.Name != "" {
+		name = remotePluginArgs.Name
+	}
+	timeout := 5 * time.Second
+	if remotePluginArgs.Timeout != nil {
+		timeout = remotePluginArgs.Timeout.Duration
+	}
+	maxPodsPerRequest := DefaultMaxPodsPerRequest
+	if remotePluginArgs.MaxPodsPerRequest != nil {
+		maxPodsPerRequest = int(*remotePluginArgs.MaxPodsPerRequest)
+	}
+	ctx := context.Background()
+	if contextHandle, ok := handle.(frameworktypes.ContextHandle); ok {
+		ctx = contextHandle.Context()
+	}
+	client, err := newClient(remotePluginArgs.TLS)
+	if err != nil {
+		return nil, err
+	}
+	var bearerToken string
+	if remotePluginArgs.BearerTokenFile != "" {
+		token, err := os.ReadFile(remotePluginArgs.BearerTokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the bearer token: %v", err)
+		}
+		bearerToken = strings.TrimSpace(string(token))
+	}
+
+	return &RemotePlugin{
+		handle:            handle,
+		args:              remotePluginArgs,
+		name:              name,
+		endpoint:          strings.TrimSuffix(remotePluginArgs.Endpoint, "/"),
+		timeout:           timeout,
+		maxPodsPerRequest: maxPodsPerRequest,
+		client:            client,
+		bearerToken:       bearerToken,
+		ctx:               ctx,
+		verdicts:          map[verdictKey][]string{},
+	}, nil
+}
+
+// newClient builds the client calling the plugin server, verifying its certificate against the CA bundle
+// and presenting the client certificate of the TLS config when set
+func newClient(config *TLSConfig) (*http.Client, error) {
+	if config == nil {
+		return &http.Client{}, nil
+	}
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12, ServerName: config.ServerName}
+	if config.CAFile != "" {
+		ca, err := os.ReadFile(config.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the CA bundle: %v", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificate found in the CA bundle %q", config.CAFile)
+		}
+	}
+	if config.CertFile != "" {
+		certificate, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load the client certificate: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Transport: transport}, nil
+}
+
+// Name retrieves the plugin name, given by its args when set
+func (rp *RemotePlugin) Name() string {
+	return rp.name
+}
+
+// Deschedule extension point implementation for the plugin
+func (rp *RemotePlugin) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	return rp.evictCandidates(ctx, DeschedulePath, nodes)
+}
+
+// Balance extension point implementation for the plugin
+func (rp *RemotePlugin) Balance(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	return rp.evictCandidates(ctx, BalancePath, nodes)
+}
+
+// Filter extension point implementation for the plugin
+func (rp *RemotePlugin) Filter(pod *v1.Pod) bool {
+	return len(rp.FilterReasons(pod)) == 0
+}
+
+// FilterReasons tells why the plugin server rejects the pod on the Filter extension point
+func (rp *RemotePlugin) FilterReasons(pod *v1.Pod) []string {
+	return rp.filter(FilterPath, pod)
+}
+
+// PreEvictionFilter extension point implementation for the plugin
+func (rp *RemotePlugin) PreEvictionFilter(pod *v1.Pod) bool {
+	return len(rp.PreEvictionFilterReasons(pod)) == 0
+}
+
+// PreEvictionFilterReasons tells why the plugin server rejects the pod on the PreEvictionFilter extension point
+func (rp *RemotePlugin) PreEvictionFilterReasons(pod *v1.Pod) []string {
+	return rp.filter(PreEvictionFilterPath, pod)
+}
+
+func (rp *RemotePlugin) evictCandidates(ctx context.Context, path string, nodes []*v1.Node) *frameworktypes.Status {
+	nodesByName := make(map[string]*v1.Node, len(nodes))
+	for _, node := range nodes {
+		nodesByName[node.Name] = node
+	}
+
+	requests, err := rp.evictionRequests(nodes)
+	if err != nil {
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error listing pods on nodes: %v", err),
+		}
+	}
+
+	for _, request := range requests {
+		response := &EvictionResponse{}
+		if err := rp.call(ctx, path, request.EvictionRequest, response); err != nil {
+			if rp.args.FailurePolicy == Ignore {
+				klog.ErrorS(err, "Ignoring failed remote plugin call", "plugin", rp.name, "endpoint", rp.endpoint, "path", path, "nodes", len(request.Nodes), "pods", len(request.Pods))
+				continue
+			}
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("remote plugin call failed: %v", err),
+			}
+		}
+
+		for _, candidate := range response.Candidates {
+			pod, ok := request.pods[types.NamespacedName{Namespace: candidate.Namespace, Name: candidate.Name}]
+			if !ok {
+				klog.V(2).InfoS("Ignoring unknown eviction candidate returned by remote plugin", "pod", klog.KRef(candidate.Namespace, candidate.Name), "plugin", rp.name, "endpoint", rp.endpoint)
+				continue
+			}
+			if rp.handle.Evictor().NodeLimitExceeded(nodesByName[pod.Spec.NodeName]) {
+				continue
+			}
+			if !rp.handle.Evictor().Filter(pod) || !rp.handle.Evictor().PreEvictionFilter(pod) {
+				continue
+			}
+			rp.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{Reason: candidate.Reason})
+		}
+	}
+	return nil
+}
+
+// evictionRequest is a Deschedule or Balance request along with the pods it carries
+type evictionRequest struct {
+	*EvictionRequest
+	pods map[types.NamespacedName]*v1.Pod
+}
+
+// evictionRequests splits the nodes and the pods assigned to them among requests carrying at most
+// maxPodsPerRequest pods each, unless a single node has more. Every request carries whole nodes.
+func (rp *RemotePlugin) evictionRequests(nodes []*v1.Node) ([]evictionRequest, error) {
+	var requests []evictionRequest
+	var request evictionRequest
+	for _, node := range nodes {
+		pods, err := podutil.ListAllPodsOnANode(node.Name, rp.handle.GetPodsAssignedToNodeFunc(), func(*v1.Pod) bool { return true })
+		if err != nil {
+			return nil, err
+		}
+		if request.EvictionRequest != nil && len(request.Pods)+len(pods) > rp.maxPodsPerRequest {
+			requests = append(requests, request)
+			request = evictionRequest{}
+		}
+		if request.EvictionRequest == nil {
+			request = evictionRequest{EvictionRequest: &EvictionRequest{}, pods: map[types.NamespacedName]*v1.Pod{}}
+		}
+		request.Nodes = append(request.Nodes, *node)
+		for _, pod := range pods {
+			// The managed fields are of no use to the plugin server, while they take most of the pod size
+			sent := *pod
+			sent.ManagedFields = nil
+			request.Pods = append(request.Pods, sent)
+			request.pods[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}] = pod
+		}
+	}
+	if request.EvictionRequest != nil {
+		requests = append(requests, request)
+	}
+	return requests, nil
+}
+
+// filter asks the plugin server whether the pod can be evicted, once per pod revision.
+// Only the verdicts of the server are cached, a failed call is made again the next time the pod is filtered.
+func (rp *RemotePlugin) filter(path string, pod *v1.Pod) []string {
+	key := verdictKey{
+		path:            path,
+		pod:             types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name},
+		uid:             pod.UID,
+		resourceVersion: pod.ResourceVersion,
+	}
+	rp.verdictsLock.Lock()
+	reasons, ok := rp.verdicts[key]
+	rp.verdictsLock.Unlock()
+	if ok {
+		return reasons
+	}
+
+	reasons, err := rp.callFilter(path, pod)
+	if err != nil {
+		klog.ErrorS(err, "Remote plugin call failed", "plugin", rp.name, "endpoint", rp.endpoint, "path", path, "pod", klog.KObj(pod), "failurePolicy", rp.args.FailurePolicy)
+		if rp.args.FailurePolicy == Ignore {
+			return nil
+		}
+		return []string{"remote plugin call failed"}
+	}
+	rp.verdictsLock.Lock()
+	rp.verdicts[key] = reasons
+	rp.verdictsLock.Unlock()
+	return reasons
+}
+
+// callFilter returns the reasons the plugin server rejects the pod for, none when it allows it
+func (rp *RemotePlugin) callFilter(path string, pod *v1.Pod) ([]string, error) {
+	response := &FilterResponse{}
+	if err := rp.call(rp.ctx, path, &FilterRequest{Pod: *pod}, response); err != nil {
+		return nil, err
+	}
+	if !response.Allowed {
+		klog.V(4).InfoS("Pod rejected by remote plugin", "pod", klog.KObj(pod), "plugin", rp.name, "reason", response.Reason)
+		if response.Reason == "" {
+			return []string{podutil.UnknownFilterReason}, nil
+		}
+		return []string{response.Reason}, nil
+	}
+	return nil, nil
+}
+
+// call sends the request to the given path of the plugin server and decodes its response
+func (rp *RemotePlugin) call(ctx context.Context, path string, request, response interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, rp.timeout)
+	defer cancel()
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("unable to encode request: %v", err)
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, rp.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("unable to build request: %v", err)
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	if rp.bearerToken != "" {
+		httpRequest.Header.Set("Authorization", "Bearer "+rp.bearerToken)
+	}
+
+	httpResponse, err := rp.client.Do(httpRequest)
+	if err != nil {
+		return err
+	}
+	defer httpResponse.Body.Close()
+
+	if httpResponse.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(httpResponse.Body, maxErrorBodySize))
+		return fmt.Errorf("unexpected response status %q: %s", httpResponse.Status, strings.TrimSpace(string(message)))
+	}
+
+	if err := json.NewDecoder(httpResponse.Body).Decode(response); err != nil {
+		return fmt.Errorf("unable to decode response: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,478 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remoteplugin
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/events"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworkfake "sigs.k8s.io/descheduler/pkg/framework/fake"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestRemotePluginEvictions(t *testing.T) {
+	node1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	node2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+
+	p1 := test.BuildTestPod("p1", 100, 0, node1.Name, test.SetRSOwnerRef)
+	p2 := test.BuildTestPod("p2", 100, 0, node1.Name, test.SetRSOwnerRef)
+	p3 := test.BuildTestPod("p3", 100, 0, node2.Name, test.SetRSOwnerRef)
+	// not evictable by the default evictor
+	p4 := test.BuildTestPod("p4", 100, 0, node2.Name, test.SetDSOwnerRef)
+
+	candidates := func(names ...string) func(context.Context, *EvictionRequest) (*EvictionResponse, error) {
+		return func(ctx context.Context, request *EvictionRequest) (*EvictionResponse, error) {
+			if len(request.Nodes) != 2 || len(request.Pods) != 4 {
+				return nil, fmt.Errorf("unexpected snapshot of %d nodes and %d pods", len(request.Nodes), len(request.Pods))
+			}
+			response := &EvictionResponse{}
+			for _, name := range names {
+				response.Candidates = append(response.Candidates, EvictionCandidate{Namespace: "default", Name: name})
+			}
+			return response, nil
+		}
+	}
+	// perNode evicts the given pods, expecting a request for every node
+	perNode := func(names ...string) func(context.Context, *EvictionRequest) (*EvictionResponse, error) {
+		return func(ctx context.Context, request *EvictionRequest) (*EvictionResponse, error) {
+			if len(request.Nodes) != 1 || len(request.Pods) != 2 {
+				return nil, fmt.Errorf("unexpected snapshot of %d nodes and %d pods", len(request.Nodes), len(request.Pods))
+			}
+			response := &EvictionResponse{}
+			for _, pod := range request.Pods {
+				for _, name := range names {
+					if pod.Name == name {
+						response.Candidates = append(response.Candidates, EvictionCandidate{Namespace: pod.Namespace, Name: pod.Name})
+					}
+				}
+			}
+			return response, nil
+		}
+	}
+	failing := func(ctx context.Context, request *EvictionRequest) (*EvictionResponse, error) {
+		return nil, fmt.Errorf("remote plugin failure")
+	}
+	slow := func(ctx context.Context, request *EvictionRequest) (*EvictionResponse, error) {
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+		}
+		return candidates("p1")(ctx, request)
+	}
+
+	testCases := []struct {
+		description             string
+		server                  *Server
+		balance                 bool
+		timeout                 time.Duration
+		failurePolicy           FailurePolicy
+		maxPodsPerRequest       *uint
+		maxPodsToEvictPerNode   *uint
+		expectedEvictedPodCount uint
+		expectError             bool
+	}{
+		{
+			description:             "evict all evictable candidates",
+			server:                  &Server{Deschedule: candidates("p1", "p3", "p4")},
+			expectedEvictedPodCount: 2,
+		},
+		{
+			description:             "balance extension point",
+			server:                  &Server{Balance: candidates("p2")},
+			balance:                 true,
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description:             "nodes split among requests",
+			server:                  &Server{Deschedule: perNode("p1", "p3")},
+			maxPodsPerRequest:       func(i uint) *uint { return &i }(3),
+			expectedEvictedPodCount: 2,
+		},
+		{
+			description:             "unknown candidates are ignored",
+			server:                  &Server{Deschedule: candidates("p1", "unknown")},
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description:             "node limit is respected",
+			server:                  &Server{Deschedule: candidates("p1", "p2", "p3")},
+			maxPodsToEvictPerNode:   func(i uint) *uint { return &i }(1),
+			expectedEvictedPodCount: 2,
+		},
+		{
+			description:             "server error with Fail policy",
+			server:                  &Server{Deschedule: failing},
+			failurePolicy:           Fail,
+			expectedEvictedPodCount: 0,
+			expectError:             true,
+		},
+		{
+			description:             "server error with Ignore policy",
+			server:                  &Server{Deschedule: failing},
+			failurePolicy:           Ignore,
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description:             "extension point not implemented by the server",
+			server:                  &Server{Balance: candidates("p1")},
+			failurePolicy:           Fail,
+			expectedEvictedPodCount: 0,
+			expectError:             true,
+		},
+		{
+			description:             "timeout with Fail policy",
+			server:                  &Server{Deschedule: slow},
+			timeout:                 50 * time.Millisecond,
+			failurePolicy:           Fail,
+			expectedEvictedPodCount: 0,
+			expectError:             true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			server := httptest.NewServer(tc.server)
+			defer server.Close()
+
+			nodes := []*v1.Node{node1, node2}
+			fakeClient := fake.NewSimpleClientset(node1, node2, p1, p2, p3, p4)
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+			podInformer := sharedInformerFactory.Core().V1().Pods().Informer()
+
+			getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(podInformer)
+			if err != nil {
+				t.Errorf("Build get pods assigned to node function error: %v", err)
+			}
+
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			podEvictor := evictions.NewPodEvictor(
+				fakeClient,
+				policyv1.SchemeGroupVersion.String(),
+				false,
+				tc.maxPodsToEvictPerNode,
+				nil,
+				nodes,
+				false,
+				&events.FakeRecorder{},
+			)
+
+			evictorFilter, err := defaultevictor.New(
+				&defaultevictor.DefaultEvictorArgs{},
+				&frameworkfake.HandleImpl{
+					ClientsetImpl:                 fakeClient,
+					GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
+					SharedInformerFactoryImpl:     sharedInformerFactory,
+				},
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			args := &RemotePluginArgs{
+				Endpoint:          server.URL,
+				FailurePolicy:     tc.failurePolicy,
+				MaxPodsPerRequest: tc.maxPodsPerRequest,
+			}
+			if tc.timeout > 0 {
+				args.Timeout = &metav1.Duration{Duration: tc.timeout}
+			}
+			plugin, err := New(args, &frameworkfake.HandleImpl{
+				ClientsetImpl:                 fakeClient,
+				PodEvictorImpl:                podEvictor,
+				EvictorFilterImpl:             evictorFilter.(frameworktypes.EvictorPlugin),
+				SharedInformerFactoryImpl:     sharedInformerFactory,
+				GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
+			})
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			var status *frameworktypes.Status
+			if tc.balance {
+				status = plugin.(frameworktypes.BalancePlugin).Balance(ctx, nodes)
+			} else {
+				status = plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, nodes)
+			}
+			hasError := status != nil && status.Err != nil
+			if hasError != tc.expectError {
+				t.Errorf("Unexpected status: %v", status)
+			}
+			if actual := podEvictor.TotalEvicted(); actual != tc.expectedEvictedPodCount {
+				t.Errorf("Expected %v pod evictions, but got %v pod evictions", tc.expectedEvictedPodCount, actual)
+			}
+		})
+	}
+}
+
+func TestRemotePluginFilters(t *testing.T) {
+	allowed := test.BuildTestPod("allowed", 100, 0, "n1", nil)
+	rejected := test.BuildTestPod("rejected", 100, 0, "n1", nil)
+
+	verdict := func(ctx context.Context, request *FilterRequest) (*FilterResponse, error) {
+		if request.Pod.Name == rejected.Name {
+			return &FilterResponse{Allowed: false, Reason: "rejected by test"}, nil
+		}
+		return &FilterResponse{Allowed: true}, nil
+	}
+
+	testCases := []struct {
+		description               string
+		server                    *Server
+		failurePolicy             FailurePolicy
+		pod                       *v1.Pod
+		expectedFilter            bool
+		expectedPreEvictionFilter bool
+		expectedFilterReason      string
+	}{
+		{
+			description:               "pod allowed by the server",
+			server:                    &Server{Filter: verdict, PreEvictionFilter: verdict},
+			pod:                       allowed,
+			expectedFilter:            true,
+			expectedPreEvictionFilter: true,
+		},
+		{
+			description:               "pod rejected by the server",
+			server:                    &Server{Filter: verdict, PreEvictionFilter: verdict},
+			pod:                       rejected,
+			expectedFilter:            false,
+			expectedPreEvictionFilter: false,
+			expectedFilterReason:      "rejected by test",
+		},
+		{
+			description:               "extension points not implemented, fail closed",
+			server:                    &Server{},
+			failurePolicy:             Fail,
+			pod:                       allowed,
+			expectedFilter:            false,
+			expectedPreEvictionFilter: false,
+			expectedFilterReason:      "remote plugin call failed",
+		},
+		{
+			description:               "preEvictionFilter not implemented, fail open",
+			server:                    &Server{Filter: verdict},
+			failurePolicy:             Ignore,
+			pod:                       rejected,
+			expectedFilter:            false,
+			expectedPreEvictionFilter: true,
+			expectedFilterReason:      "rejected by test",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			server := httptest.NewServer(tc.server)
+			defer server.Close()
+
+			plugin, err := New(&RemotePluginArgs{
+				Endpoint:      server.URL + "/",
+				FailurePolicy: tc.failurePolicy,
+			}, &frameworkfake.HandleImpl{})
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			evictorPlugin := plugin.(frameworktypes.EvictorPlugin)
+			if actual := evictorPlugin.Filter(tc.pod); actual != tc.expectedFilter {
+				t.Errorf("Expected Filter to return %v, got %v", tc.expectedFilter, actual)
+			}
+			if actual := evictorPlugin.PreEvictionFilter(tc.pod); actual != tc.expectedPreEvictionFilter {
+				t.Errorf("Expected PreEvictionFilter to return %v, got %v", tc.expectedPreEvictionFilter, actual)
+			}
+			if actual := strings.Join(plugin.(frameworktypes.FilterReasonsPlugin).FilterReasons(tc.pod), ", "); actual != tc.expectedFilterReason {
+				t.Errorf("Expected FilterReasons to return the %q reason, got %q", tc.expectedFilterReason, actual)
+			}
+		})
+	}
+}
+
+func TestRemotePluginFilterVerdictsCached(t *testing.T) {
+	pod := test.BuildTestPod("p1", 100, 0, "n1", func(pod *v1.Pod) {
+		pod.UID = "uid"
+		pod.ResourceVersion = "1"
+	})
+
+	var calls int32
+	server := httptest.NewServer(&Server{
+		Filter: func(ctx context.Context, request *FilterRequest) (*FilterResponse, error) {
+			atomic.AddInt32(&calls, 1)
+			return &FilterResponse{Allowed: false, Reason: "rejected by test"}, nil
+		},
+	})
+	defer server.Close()
+
+	plugin, err := New(&RemotePluginArgs{Name: "team-a", Endpoint: server.URL}, &frameworkfake.HandleImpl{})
+	if err != nil {
+		t.Fatalf("Unable to initialize the plugin: %v", err)
+	}
+	if plugin.Name() != "team-a" {
+		t.Errorf("Expected the plugin to be named after its args, got %q", plugin.Name())
+	}
+
+	evictorPlugin := plugin.(frameworktypes.EvictorPlugin)
+	for i := 0; i < 2; i++ {
+		if evictorPlugin.Filter(pod) {
+			t.Errorf("Expected the pod to be rejected")
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected the verdict about the pod to be cached, got %d calls", calls)
+	}
+
+	updated := pod.DeepCopy()
+	updated.ResourceVersion = "2"
+	evictorPlugin.Filter(updated)
+	if calls != 2 {
+		t.Errorf("Expected the verdict about the updated pod to be asked for, got %d calls", calls)
+	}
+}
+
+func TestRemotePluginFilterFailuresNotCached(t *testing.T) {
+	pod := test.BuildTestPod("p1", 100, 0, "n1", func(pod *v1.Pod) {
+		pod.UID = "uid"
+		pod.ResourceVersion = "1"
+	})
+
+	testCases := []struct {
+		description   string
+		failurePolicy FailurePolicy
+		expected      []bool
+	}{
+		{
+			description:   "failed call rejecting the pod once",
+			failurePolicy: Fail,
+			expected:      []bool{false, true},
+		},
+		{
+			description:   "failed call accepting the pod once",
+			failurePolicy: Ignore,
+			expected:      []bool{true, true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			var calls int32
+			server := httptest.NewServer(&Server{
+				Filter: func(ctx context.Context, request *FilterRequest) (*FilterResponse, error) {
+					if atomic.AddInt32(&calls, 1) == 1 {
+						return nil, fmt.Errorf("timed out")
+					}
+					return &FilterResponse{Allowed: true}, nil
+				},
+			})
+			defer server.Close()
+
+			plugin, err := New(&RemotePluginArgs{Endpoint: server.URL, FailurePolicy: tc.failurePolicy}, &frameworkfake.HandleImpl{})
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+			evictorPlugin := plugin.(frameworktypes.EvictorPlugin)
+			for i, expected := range append(tc.expected, tc.expected[len(tc.expected)-1]) {
+				if actual := evictorPlugin.Filter(pod); actual != expected {
+					t.Errorf("Expected Filter call %d to return %v, got %v", i, expected, actual)
+				}
+			}
+			if calls != 2 {
+				t.Errorf("Expected the failed call only to be made again, got %d calls", calls)
+			}
+		})
+	}
+}
+
+func TestRemotePluginTLS(t *testing.T) {
+	pod := test.BuildTestPod("p1", 100, 0, "n1", nil)
+	remote := &Server{
+		Filter: func(ctx context.Context, request *FilterRequest) (*FilterResponse, error) {
+			return &FilterResponse{Allowed: true}, nil
+		},
+	}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		remote.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	caFile, tokenFile := filepath.Join(dir, "ca.crt"), filepath.Join(dir, "token")
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600); err != nil {
+		t.Fatalf("Unable to write the CA bundle: %v", err)
+	}
+	if err := os.WriteFile(tokenFile, []byte("secret\n"), 0o600); err != nil {
+		t.Fatalf("Unable to write the bearer token: %v", err)
+	}
+
+	testCases := []struct {
+		description string
+		args        *RemotePluginArgs
+		expected    bool
+	}{
+		{
+			description: "server verified and authenticated to",
+			args:        &RemotePluginArgs{Endpoint: server.URL, TLS: &TLSConfig{CAFile: caFile}, BearerTokenFile: tokenFile},
+			expected:    true,
+		},
+		{
+			description: "server not verified",
+			args:        &RemotePluginArgs{Endpoint: server.URL, BearerTokenFile: tokenFile},
+		},
+		{
+			description: "server not authenticated to",
+			args:        &RemotePluginArgs{Endpoint: server.URL, TLS: &TLSConfig{CAFile: caFile}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			plugin, err := New(tc.args, &frameworkfake.HandleImpl{})
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+			if actual := plugin.(frameworktypes.EvictorPlugin).Filter(pod); actual != tc.expected {
+				t.Errorf("Expected Filter to return %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
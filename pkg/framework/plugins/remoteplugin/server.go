@@ -0,0 +1,90 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remoteplugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"k8s.io/klog/v2"
+)
+
+// Server is an http.Handler implementing the server side of the RemotePlugin protocol.
+// It allows out-of-process plugins written in Go to only provide the extension points they support.
+// Requests sent to extension points without an implementation are answered with 404 Not Found.
+type Server struct {
+	Deschedule        func(ctx context.Context, request *EvictionRequest) (*EvictionResponse, error)
+	Balance           func(ctx context.Context, request *EvictionRequest) (*EvictionResponse, error)
+	Filter            func(ctx context.Context, request *FilterRequest) (*FilterResponse, error)
+	PreEvictionFilter func(ctx context.Context, request *FilterRequest) (*FilterResponse, error)
+}
+
+var _ http.Handler = &Server{}
+
+// ServeHTTP dispatches the request to the extension point implementation given by its path
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, fmt.Sprintf("method %s not allowed", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	switch {
+	case r.URL.Path == DeschedulePath && s.Deschedule != nil:
+		serveEviction(w, r, s.Deschedule)
+	case r.URL.Path == BalancePath && s.Balance != nil:
+		serveEviction(w, r, s.Balance)
+	case r.URL.Path == FilterPath && s.Filter != nil:
+		serveFilter(w, r, s.Filter)
+	case r.URL.Path == PreEvictionFilterPath && s.PreEvictionFilter != nil:
+		serveFilter(w, r, s.PreEvictionFilter)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func serveEviction(w http.ResponseWriter, r *http.Request, fnc func(context.Context, *EvictionRequest) (*EvictionResponse, error)) {
+	request := &EvictionRequest{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		http.Error(w, fmt.Sprintf("unable to decode request: %v", err), http.StatusBadRequest)
+		return
+	}
+	response, err := fnc(r.Context(), request)
+	writeResponse(w, r, response, err)
+}
+
+func serveFilter(w http.ResponseWriter, r *http.Request, fnc func(context.Context, *FilterRequest) (*FilterResponse, error)) {
+	request := &FilterRequest{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		http.Error(w, fmt.Sprintf("unable to decode request: %v", err), http.StatusBadRequest)
+		return
+	}
+	response, err := fnc(r.Context(), request)
+	writeResponse(w, r, response, err)
+}
+
+func writeResponse(w http.ResponseWriter, r *http.Request, response interface{}, err error) {
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		klog.ErrorS(err, "Unable to encode remote plugin response", "path", r.URL.Path)
+	}
+}
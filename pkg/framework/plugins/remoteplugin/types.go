@@ -0,0 +1,80 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remoteplugin
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FailurePolicy defines how errors calling the remote plugin are handled
+type FailurePolicy string
+
+const (
+	// Fail makes the extension point fail closed: Deschedule/Balance report an error
+	// and Filter/PreEvictionFilter reject the pod
+	Fail FailurePolicy = "Fail"
+	// Ignore makes the extension point fail open: Deschedule/Balance evict nothing
+	// without reporting an error and Filter/PreEvictionFilter accept the pod
+	Ignore FailurePolicy = "Ignore"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RemotePluginArgs holds arguments used to configure RemotePlugin plugin.
+type RemotePluginArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Name the profile refers to the plugin instance by instead of RemotePlugin,
+	// so several out-of-process plugins can be configured within a profile
+	Name string `json:"name"`
+	// Endpoint is the base URL of the out-of-process plugin server
+	Endpoint string `json:"endpoint"`
+	// Timeout of a single call to the out-of-process plugin server
+	Timeout *metav1.Duration `json:"timeout"`
+	// FailurePolicy defines how errors calling the out-of-process plugin server are handled
+	FailurePolicy FailurePolicy `json:"failurePolicy"`
+	// MaxPodsPerRequest bounds the number of pods sent within a single Deschedule or Balance call.
+	// The processed nodes get split among several calls, each carrying whole nodes.
+	MaxPodsPerRequest *uint `json:"maxPodsPerRequest"`
+	// TLS configures the connection to an https endpoint
+	TLS *TLSConfig `json:"tls"`
+	// BearerTokenFile is the path of a file holding the bearer token the descheduler authenticates with
+	// to the out-of-process plugin server. The file is read every descheduling loop, so the token can be rotated.
+	BearerTokenFile string `json:"bearerTokenFile"`
+}
+
+// TLSConfig configures the TLS connection to the out-of-process plugin server
+type TLSConfig struct {
+	// CAFile is the path of the PEM encoded CA bundle the certificate of the server is verified against,
+	// the system roots when not set
+	CAFile string `json:"caFile"`
+	// CertFile is the path of the PEM encoded client certificate the descheduler authenticates with
+	CertFile string `json:"certFile"`
+	// KeyFile is the path of the PEM encoded private key of the client certificate
+	KeyFile string `json:"keyFile"`
+	// ServerName overrides the name the certificate of the server is verified against, the endpoint host when not set
+	ServerName string `json:"serverName"`
+}
+
+// InstanceName returns the name the profile refers to the plugin instance by, empty when not set
+func (args *RemotePluginArgs) InstanceName() string {
+	if args == nil {
+		return ""
+	}
+	return args.Name
+}
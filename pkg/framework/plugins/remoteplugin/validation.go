@@ -0,0 +1,58 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remoteplugin
+
+import (
+	"fmt"
+	"net/url"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ValidateRemotePluginArgs validates RemotePlugin arguments
+func ValidateRemotePluginArgs(obj runtime.Object) error {
+	args := obj.(*RemotePluginArgs)
+	if args.Endpoint == "" {
+		return fmt.Errorf("endpoint must be set")
+	}
+	endpoint, err := url.Parse(args.Endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid endpoint %q: %v", args.Endpoint, err)
+	}
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+		return fmt.Errorf("endpoint %q must use either http or https scheme", args.Endpoint)
+	}
+	if endpoint.Host == "" {
+		return fmt.Errorf("endpoint %q must include a host", args.Endpoint)
+	}
+	if args.Timeout != nil && args.Timeout.Duration <= 0 {
+		return fmt.Errorf("timeout must be positive, got %v", args.Timeout.Duration)
+	}
+	if args.FailurePolicy != "" && args.FailurePolicy != Fail && args.FailurePolicy != Ignore {
+		return fmt.Errorf("failurePolicy must be either %q or %q, got %q", Fail, Ignore, args.FailurePolicy)
+	}
+	if args.MaxPodsPerRequest != nil && *args.MaxPodsPerRequest == 0 {
+		return fmt.Errorf("maxPodsPerRequest must be greater than 0")
+	}
+	if endpoint.Scheme != "https" && (args.TLS != nil || args.BearerTokenFile != "") {
+		return fmt.Errorf("endpoint %q must use the https scheme with tls or bearerTokenFile set", args.Endpoint)
+	}
+	if args.TLS != nil && (args.TLS.CertFile == "") != (args.TLS.KeyFile == "") {
+		return fmt.Errorf("tls.certFile and tls.keyFile must be set together")
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remoteplugin
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateRemotePluginArgs(t *testing.T) {
+	testCases := []struct {
+		description string
+		args        *RemotePluginArgs
+		expectError bool
+	}{
+		{
+			description: "valid arg, no errors",
+			args: &RemotePluginArgs{
+				Endpoint:      "https://remote-plugin.kube-system.svc:8443",
+				Timeout:       &metav1.Duration{Duration: 1},
+				FailurePolicy: Ignore,
+			},
+			expectError: false,
+		},
+		{
+			description: "missing endpoint, expects errors",
+			args:        &RemotePluginArgs{},
+			expectError: true,
+		},
+		{
+			description: "endpoint without http scheme, expects errors",
+			args: &RemotePluginArgs{
+				Endpoint: "unix:///var/run/remote-plugin.sock",
+			},
+			expectError: true,
+		},
+		{
+			description: "non positive timeout, expects errors",
+			args: &RemotePluginArgs{
+				Endpoint: "http://localhost:8080",
+				Timeout:  &metav1.Duration{Duration: 0},
+			},
+			expectError: true,
+		},
+		{
+			description: "unknown failure policy, expects errors",
+			args: &RemotePluginArgs{
+				Endpoint:      "http://localhost:8080",
+				FailurePolicy: "Retry",
+			},
+			expectError: true,
+		},
+		{
+			description: "no pod per request, expects errors",
+			args: &RemotePluginArgs{
+				Endpoint:          "http://localhost:8080",
+				MaxPodsPerRequest: func(i uint) *uint { return &i }(0),
+			},
+			expectError: true,
+		},
+		{
+			description: "tls and bearer token, no errors",
+			args: &RemotePluginArgs{
+				Endpoint:        "https://remote-plugin.kube-system.svc:8443",
+				TLS:             &TLSConfig{CAFile: "/etc/remote-plugin/ca.crt", CertFile: "/etc/remote-plugin/tls.crt", KeyFile: "/etc/remote-plugin/tls.key"},
+				BearerTokenFile: "/var/run/secrets/remote-plugin/token",
+			},
+			expectError: false,
+		},
+		{
+			description: "bearer token over http, expects errors",
+			args: &RemotePluginArgs{
+				Endpoint:        "http://localhost:8080",
+				BearerTokenFile: "/var/run/secrets/remote-plugin/token",
+			},
+			expectError: true,
+		},
+		{
+			description: "client certificate without key, expects errors",
+			args: &RemotePluginArgs{
+				Endpoint: "https://localhost:8443",
+				TLS:      &TLSConfig{CertFile: "/etc/remote-plugin/tls.crt"},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateRemotePluginArgs(tc.args)
+
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
@@ -205,6 +205,8 @@ func (r *RemoveDuplicates) Balance(ctx context.Context, nodes []*v1.Node) *frame
 			if len(pods)+1 > upperAvg {
 				// It's assumed all duplicated pods are in the same priority class
 				// TODO(jchaloup): check if the pod has a different node to lend to
+				// the duplicates are evicted from the tail
+				r.handle.Evictor().SortReversed(pods)
 				for _, pod := range pods[upperAvg-1:] {
 					r.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{})
 					if r.handle.Evictor().NodeLimitExceeded(nodeMap[nodeName]) {
@@ -18,11 +18,13 @@ package removeduplicates
 
 import (
 	"context"
+	"reflect"
 	"testing"
 
 	"k8s.io/client-go/tools/events"
 	frameworkfake "sigs.k8s.io/descheduler/pkg/framework/fake"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/podsorting"
 	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
 
 	v1 "k8s.io/api/core/v1"
@@ -815,3 +817,44 @@ func TestRemoveDuplicatesUniformly(t *testing.T) {
 		})
 	}
 }
+
+func TestSortForEviction(t *testing.T) {
+	lowPriority, highPriority := int32(10), int32(100)
+	buildPods := func() []*v1.Pod {
+		return []*v1.Pod{
+			test.BuildTestPod("p1", 100, 0, "n1", func(pod *v1.Pod) { pod.Spec.Priority = &highPriority }),
+			test.BuildTestPod("p2", 100, 0, "n1", func(pod *v1.Pod) { pod.Spec.Priority = &lowPriority }),
+			test.BuildTestPod("p3", 100, 0, "n1", func(pod *v1.Pod) { pod.Spec.Priority = &highPriority }),
+		}
+	}
+
+	tests := []struct {
+		description string
+		sortPlugin  frameworktypes.SortPlugin
+		expected    []string
+	}{
+		{
+			description: "pods keep their order without sort plugins",
+			expected:    []string{"p1", "p2", "p3"},
+		},
+		{
+			description: "pods to evict first come last",
+			sortPlugin:  &podsorting.PrioritySort{},
+			expected:    []string{"p1", "p3", "p2"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			pods := buildPods()
+			(&frameworkfake.HandleImpl{SortPluginImpl: tc.sortPlugin}).SortReversed(pods)
+			names := []string{}
+			for _, pod := range pods {
+				names = append(names, pod.Name)
+			}
+			if !reflect.DeepEqual(names, tc.expected) {
+				t.Errorf("Expected pods %v, got %v", tc.expected, names)
+			}
+		})
+	}
+}
@@ -29,6 +29,7 @@ import (
 
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/framework/parallelize"
 	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
 )
 
@@ -39,9 +40,14 @@ type RemoveFailedPods struct {
 	handle    frameworktypes.Handle
 	args      *RemoveFailedPodsArgs
 	podFilter podutil.FilterFunc
+	// explainSelection tells why the namespaces and the label selector exclude a pod
+	explainSelection func(*v1.Pod) error
 }
 
-var _ frameworktypes.DeschedulePlugin = &RemoveFailedPods{}
+var (
+	_ frameworktypes.DeschedulePlugin = &RemoveFailedPods{}
+	_ frameworktypes.ExplainPlugin    = &RemoveFailedPods{}
+)
 
 // New builds plugin from its arguments while passing a handle
 func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
@@ -56,12 +62,17 @@ func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plug
 		excludedNamespaces = sets.New(failedPodsArgs.Namespaces.Exclude...)
 	}
 
-	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
-	podFilter, err := podutil.NewOptions().
-		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+	podSelection := podutil.NewOptions().
 		WithNamespaces(includedNamespaces).
 		WithoutNamespaces(excludedNamespaces).
-		WithLabelSelector(failedPodsArgs.LabelSelector).
+		WithLabelSelector(failedPodsArgs.LabelSelector)
+	explainSelection, err := podSelection.BuildExplainFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podSelection.
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
 		BuildFilterFunc()
 	if err != nil {
 		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
@@ -79,9 +90,10 @@ func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plug
 	})
 
 	return &RemoveFailedPods{
-		handle:    handle,
-		podFilter: podFilter,
-		args:      failedPodsArgs,
+		handle:           handle,
+		podFilter:        podFilter,
+		args:             failedPodsArgs,
+		explainSelection: explainSelection,
 	}, nil
 }
 
@@ -92,15 +104,18 @@ func (d *RemoveFailedPods) Name() string {
 
 // Deschedule extension point implementation for the plugin
 func (d *RemoveFailedPods) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
-	for _, node := range nodes {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	errCh := parallelize.NewErrorChannel()
+	frameworktypes.GetParallelizer(d.handle).Until(ctx, len(nodes), func(idx int) {
+		node := nodes[idx]
 		klog.V(2).InfoS("Processing node", "node", klog.KObj(node))
 		pods, err := podutil.ListAllPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
 		if err != nil {
-			// no pods evicted as error encountered retrieving evictable Pods
-			return &frameworktypes.Status{
-				Err: fmt.Errorf("error listing pods on a node: %v", err),
-			}
+			errCh.SendErrorWithCancel(err, cancel)
+			return
 		}
+		d.handle.Evictor().Sort(pods)
 		totalPods := len(pods)
 		for i := 0; i < totalPods; i++ {
 			d.handle.Evictor().Evict(ctx, pods[i], evictions.EvictOptions{})
@@ -108,10 +123,30 @@ func (d *RemoveFailedPods) Deschedule(ctx context.Context, nodes []*v1.Node) *fr
 				break
 			}
 		}
+	})
+	if err := errCh.ReceiveError(); err != nil {
+		// no pods evicted as error encountered retrieving evictable Pods
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error listing pods on a node: %v", err),
+		}
 	}
 	return nil
 }
 
+// Explain tells whether the pod is selected for eviction, regardless of the evictor
+func (d *RemoveFailedPods) Explain(pod *v1.Pod, nodes []*v1.Node) (bool, string) {
+	if pod.Status.Phase != v1.PodFailed {
+		return false, fmt.Sprintf("pod phase %s is not %s", pod.Status.Phase, v1.PodFailed)
+	}
+	if err := d.explainSelection(pod); err != nil {
+		return false, err.Error()
+	}
+	if err := validateCanEvict(pod, d.args); err != nil {
+		return false, err.Error()
+	}
+	return true, fmt.Sprintf("pod phase is %s", v1.PodFailed)
+}
+
 // validateCanEvict looks at failedPodArgs to see if pod can be evicted given the args.
 func validateCanEvict(pod *v1.Pod, failedPodArgs *RemoveFailedPodsArgs) error {
 	var errs []error
@@ -144,7 +179,7 @@ func validateCanEvict(pod *v1.Pod, failedPodArgs *RemoveFailedPodsArgs) error {
 		}
 
 		if !sets.New(failedPodArgs.Reasons...).HasAny(reasons...) {
-			errs = append(errs, fmt.Errorf("pod does not match any of the reasons"))
+			errs = append(errs, fmt.Errorf("pod does not match any of the reasons %v", failedPodArgs.Reasons))
 		}
 	}
 
@@ -39,9 +39,14 @@ type RemovePodsHavingTooManyRestarts struct {
 	handle    frameworktypes.Handle
 	args      *RemovePodsHavingTooManyRestartsArgs
 	podFilter podutil.FilterFunc
+	// explainSelection tells why the namespaces and the label selector exclude a pod
+	explainSelection func(*v1.Pod) error
 }
 
-var _ frameworktypes.DeschedulePlugin = &RemovePodsHavingTooManyRestarts{}
+var (
+	_ frameworktypes.DeschedulePlugin = &RemovePodsHavingTooManyRestarts{}
+	_ frameworktypes.ExplainPlugin    = &RemovePodsHavingTooManyRestarts{}
+)
 
 // New builds plugin from its arguments while passing a handle
 func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
@@ -56,12 +61,17 @@ func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plug
 		excludedNamespaces = sets.New(tooManyRestartsArgs.Namespaces.Exclude...)
 	}
 
-	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
-	podFilter, err := podutil.NewOptions().
-		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+	podSelection := podutil.NewOptions().
 		WithNamespaces(includedNamespaces).
 		WithoutNamespaces(excludedNamespaces).
-		WithLabelSelector(tooManyRestartsArgs.LabelSelector).
+		WithLabelSelector(tooManyRestartsArgs.LabelSelector)
+	explainSelection, err := podSelection.BuildExplainFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podSelection.
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
 		BuildFilterFunc()
 	if err != nil {
 		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
@@ -78,27 +88,33 @@ func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plug
 	if len(tooManyRestartsArgs.States) > 0 {
 		states := sets.New(tooManyRestartsArgs.States...)
 		podFilter = podutil.WrapFilterFuncs(podFilter, func(pod *v1.Pod) bool {
-			if states.Has(string(pod.Status.Phase)) {
-				return true
-			}
-
-			for _, containerStatus := range pod.Status.ContainerStatuses {
-				if containerStatus.State.Waiting != nil && states.Has(containerStatus.State.Waiting.Reason) {
-					return true
-				}
-			}
-
-			return false
+			return podInStates(pod, states)
 		})
 	}
 
 	return &RemovePodsHavingTooManyRestarts{
-		handle:    handle,
-		args:      tooManyRestartsArgs,
-		podFilter: podFilter,
+		handle:           handle,
+		args:             tooManyRestartsArgs,
+		podFilter:        podFilter,
+		explainSelection: explainSelection,
 	}, nil
 }
 
+// podInStates checks whether the pod phase or the reason a container of the pod is waiting for is among the states
+func podInStates(pod *v1.Pod, states sets.Set[string]) bool {
+	if states.Has(string(pod.Status.Phase)) {
+		return true
+	}
+
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if containerStatus.State.Waiting != nil && states.Has(containerStatus.State.Waiting.Reason) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Name retrieves the plugin name
 func (d *RemovePodsHavingTooManyRestarts) Name() string {
 	return PluginName
@@ -115,6 +131,7 @@ func (d *RemovePodsHavingTooManyRestarts) Deschedule(ctx context.Context, nodes
 				Err: fmt.Errorf("error listing pods on a node: %v", err),
 			}
 		}
+		d.handle.Evictor().Sort(pods)
 		totalPods := len(pods)
 		for i := 0; i < totalPods; i++ {
 			d.handle.Evictor().Evict(ctx, pods[i], evictions.EvictOptions{})
@@ -126,22 +143,41 @@ func (d *RemovePodsHavingTooManyRestarts) Deschedule(ctx context.Context, nodes
 	return nil
 }
 
+// Explain tells whether the pod is selected for eviction, regardless of the evictor
+func (d *RemovePodsHavingTooManyRestarts) Explain(pod *v1.Pod, nodes []*v1.Node) (bool, string) {
+	if err := d.explainSelection(pod); err != nil {
+		return false, err.Error()
+	}
+	if err := validateCanEvict(pod, d.args); err != nil {
+		return false, err.Error()
+	}
+	if len(d.args.States) > 0 && !podInStates(pod, sets.New(d.args.States...)) {
+		return false, fmt.Sprintf("pod phase %s and waiting reasons match none of the states %v", pod.Status.Phase, d.args.States)
+	}
+	return true, fmt.Sprintf("number of container restarts (%d) exceeding the threshold (%d)", podRestarts(pod, d.args), d.args.PodRestartThreshold)
+}
+
 // validateCanEvict looks at tooManyRestartsArgs to see if pod can be evicted given the args.
 func validateCanEvict(pod *v1.Pod, tooManyRestartsArgs *RemovePodsHavingTooManyRestartsArgs) error {
 	var err error
 
-	restarts := calcContainerRestartsFromStatuses(pod.Status.ContainerStatuses)
-	if tooManyRestartsArgs.IncludingInitContainers {
-		restarts += calcContainerRestartsFromStatuses(pod.Status.InitContainerStatuses)
-	}
-
+	restarts := podRestarts(pod, tooManyRestartsArgs)
 	if restarts < tooManyRestartsArgs.PodRestartThreshold {
-		err = fmt.Errorf("number of container restarts (%v) not exceeding the threshold", restarts)
+		err = fmt.Errorf("number of container restarts (%v) not exceeding the threshold (%v)", restarts, tooManyRestartsArgs.PodRestartThreshold)
 	}
 
 	return err
 }
 
+// podRestarts counts the restarts of the containers of the pod, including the init containers if configured so
+func podRestarts(pod *v1.Pod, tooManyRestartsArgs *RemovePodsHavingTooManyRestartsArgs) int32 {
+	restarts := calcContainerRestartsFromStatuses(pod.Status.ContainerStatuses)
+	if tooManyRestartsArgs.IncludingInitContainers {
+		restarts += calcContainerRestartsFromStatuses(pod.Status.InitContainerStatuses)
+	}
+	return restarts
+}
+
 // calcContainerRestartsFromStatuses get container restarts from container statuses.
 func calcContainerRestartsFromStatuses(statuses []v1.ContainerStatus) int32 {
 	var restarts int32
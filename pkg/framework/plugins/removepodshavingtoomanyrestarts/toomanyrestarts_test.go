@@ -29,6 +29,7 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/events"
 
+	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	frameworkfake "sigs.k8s.io/descheduler/pkg/framework/fake"
@@ -360,3 +361,55 @@ func TestRemovePodsHavingTooManyRestarts(t *testing.T) {
 		})
 	}
 }
+
+func TestRemovePodsHavingTooManyRestartsExplain(t *testing.T) {
+	node := test.BuildTestNode("node1", 2000, 3000, 10, nil)
+	pods := initPods(node)
+	pods[1].Namespace = "kube-system"
+
+	tests := []struct {
+		description      string
+		pod              *v1.Pod
+		expectedSelected bool
+		expectedReason   string
+	}{
+		{
+			description:      "restarts below the threshold",
+			pod:              pods[0],
+			expectedSelected: false,
+			expectedReason:   "number of container restarts (0) not exceeding the threshold (10)",
+		},
+		{
+			description:      "excluded namespace",
+			pod:              pods[1],
+			expectedSelected: false,
+			expectedReason:   `namespace "kube-system" is excluded`,
+		},
+		{
+			description:      "restarts above the threshold",
+			pod:              pods[2],
+			expectedSelected: true,
+			expectedReason:   "number of container restarts (40) exceeding the threshold (10)",
+		},
+	}
+
+	plugin, err := New(
+		&RemovePodsHavingTooManyRestartsArgs{
+			PodRestartThreshold: 10,
+			Namespaces:          &api.Namespaces{Exclude: []string{"kube-system"}},
+		},
+		&frameworkfake.HandleImpl{},
+	)
+	if err != nil {
+		t.Fatalf("Unable to initialize the plugin: %v", err)
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			selected, reason := plugin.(frameworktypes.ExplainPlugin).Explain(tc.pod, []*v1.Node{node})
+			if selected != tc.expectedSelected || reason != tc.expectedReason {
+				t.Errorf("Expected selected=%v with reason %q, got selected=%v with reason %q", tc.expectedSelected, tc.expectedReason, selected, reason)
+			}
+		})
+	}
+}
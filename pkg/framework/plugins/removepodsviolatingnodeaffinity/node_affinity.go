@@ -16,6 +16,7 @@ package removepodsviolatingnodeaffinity
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -36,9 +37,14 @@ type RemovePodsViolatingNodeAffinity struct {
 	handle    frameworktypes.Handle
 	args      *RemovePodsViolatingNodeAffinityArgs
 	podFilter podutil.FilterFunc
+	// explainSelection tells why the namespaces and the label selector exclude a pod
+	explainSelection func(*v1.Pod) error
 }
 
-var _ frameworktypes.DeschedulePlugin = &RemovePodsViolatingNodeAffinity{}
+var (
+	_ frameworktypes.DeschedulePlugin = &RemovePodsViolatingNodeAffinity{}
+	_ frameworktypes.ExplainPlugin    = &RemovePodsViolatingNodeAffinity{}
+)
 
 // New builds plugin from its arguments while passing a handle
 func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
@@ -53,21 +59,27 @@ func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plug
 		excludedNamespaces = sets.New(nodeAffinityArgs.Namespaces.Exclude...)
 	}
 
-	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
-	podFilter, err := podutil.NewOptions().
-		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+	podSelection := podutil.NewOptions().
 		WithNamespaces(includedNamespaces).
 		WithoutNamespaces(excludedNamespaces).
-		WithLabelSelector(nodeAffinityArgs.LabelSelector).
+		WithLabelSelector(nodeAffinityArgs.LabelSelector)
+	explainSelection, err := podSelection.BuildExplainFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podSelection.
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
 		BuildFilterFunc()
 	if err != nil {
 		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
 	}
 
 	return &RemovePodsViolatingNodeAffinity{
-		handle:    handle,
-		podFilter: podFilter,
-		args:      nodeAffinityArgs,
+		handle:           handle,
+		podFilter:        podFilter,
+		args:             nodeAffinityArgs,
+		explainSelection: explainSelection,
 	}, nil
 }
 
@@ -134,6 +146,7 @@ func (d *RemovePodsViolatingNodeAffinity) processNodes(ctx context.Context, node
 			}
 		}
 
+		d.handle.Evictor().Sort(pods)
 		for _, pod := range pods {
 			klog.V(1).InfoS("Evicting pod", "pod", klog.KObj(pod))
 			d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{})
@@ -144,3 +157,55 @@ func (d *RemovePodsViolatingNodeAffinity) processNodes(ctx context.Context, node
 	}
 	return nil
 }
+
+// Explain tells whether the pod is selected for eviction, regardless of the evictor
+func (d *RemovePodsViolatingNodeAffinity) Explain(pod *v1.Pod, nodes []*v1.Node) (bool, string) {
+	if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+		return false, fmt.Sprintf("pod phase %s does not occupy any resources", pod.Status.Phase)
+	}
+	if err := d.explainSelection(pod); err != nil {
+		return false, err.Error()
+	}
+	var node *v1.Node
+	for _, n := range nodes {
+		if n.Name == pod.Spec.NodeName {
+			node = n
+			break
+		}
+	}
+	if node == nil {
+		return false, fmt.Sprintf("pod node %q is not among the processed nodes", pod.Spec.NodeName)
+	}
+
+	var reasons []string
+	for _, nodeAffinity := range d.args.NodeAffinityType {
+		switch nodeAffinity {
+		case "requiredDuringSchedulingIgnoredDuringExecution":
+			switch {
+			case !utils.PodHasNodeAffinity(pod, utils.RequiredDuringSchedulingIgnoredDuringExecution):
+				reasons = append(reasons, "pod has no required node affinity")
+			case nodeutil.PodMatchNodeSelector(pod, node):
+				reasons = append(reasons, fmt.Sprintf("pod matches the required node affinity on node %q", node.Name))
+			case !nodeutil.PodFitsAnyNode(d.handle.GetPodsAssignedToNodeFunc(), pod, nodes):
+				reasons = append(reasons, fmt.Sprintf("pod violates the required node affinity on node %q but does not fit any other node", node.Name))
+			default:
+				return true, fmt.Sprintf("pod violates the required node affinity on node %q", node.Name)
+			}
+		case "preferredDuringSchedulingIgnoredDuringExecution":
+			if !utils.PodHasNodeAffinity(pod, utils.PreferredDuringSchedulingIgnoredDuringExecution) {
+				reasons = append(reasons, "pod has no preferred node affinity")
+				continue
+			}
+			best, current := nodeutil.GetBestNodeWeightGivenPodPreferredAffinity(pod, nodes), nodeutil.GetNodeWeightGivenPodPreferredAffinity(pod, node)
+			switch {
+			case best <= current:
+				reasons = append(reasons, fmt.Sprintf("no node has a higher preferred node affinity weight than %d of node %q", current, node.Name))
+			case !nodeutil.PodFitsAnyNode(d.handle.GetPodsAssignedToNodeFunc(), pod, nodes):
+				reasons = append(reasons, fmt.Sprintf("a node has a higher preferred node affinity weight %d than %d of node %q but the pod does not fit any other node", best, current, node.Name))
+			default:
+				return true, fmt.Sprintf("a node has a higher preferred node affinity weight %d than %d of node %q", best, current, node.Name)
+			}
+		}
+	}
+	return false, strings.Join(reasons, "; ")
+}
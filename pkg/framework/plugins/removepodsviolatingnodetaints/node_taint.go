@@ -27,6 +27,7 @@ import (
 
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/framework/parallelize"
 	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
 	"sigs.k8s.io/descheduler/pkg/utils"
 )
@@ -39,9 +40,14 @@ type RemovePodsViolatingNodeTaints struct {
 	args           *RemovePodsViolatingNodeTaintsArgs
 	taintFilterFnc func(taint *v1.Taint) bool
 	podFilter      podutil.FilterFunc
+	// explainSelection tells why the namespaces and the label selector exclude a pod
+	explainSelection func(*v1.Pod) error
 }
 
-var _ frameworktypes.DeschedulePlugin = &RemovePodsViolatingNodeTaints{}
+var (
+	_ frameworktypes.DeschedulePlugin = &RemovePodsViolatingNodeTaints{}
+	_ frameworktypes.ExplainPlugin    = &RemovePodsViolatingNodeTaints{}
+)
 
 // New builds plugin from its arguments while passing a handle
 func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
@@ -56,12 +62,17 @@ func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plug
 		excludedNamespaces = sets.New(nodeTaintsArgs.Namespaces.Exclude...)
 	}
 
-	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
-	podFilter, err := podutil.NewOptions().
-		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+	podSelection := podutil.NewOptions().
 		WithNamespaces(includedNamespaces).
 		WithoutNamespaces(excludedNamespaces).
-		WithLabelSelector(nodeTaintsArgs.LabelSelector).
+		WithLabelSelector(nodeTaintsArgs.LabelSelector)
+	explainSelection, err := podSelection.BuildExplainFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podSelection.
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
 		BuildFilterFunc()
 	if err != nil {
 		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
@@ -81,10 +92,11 @@ func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plug
 	}
 
 	return &RemovePodsViolatingNodeTaints{
-		handle:         handle,
-		podFilter:      podFilter,
-		args:           nodeTaintsArgs,
-		taintFilterFnc: taintFilterFnc,
+		handle:           handle,
+		podFilter:        podFilter,
+		args:             nodeTaintsArgs,
+		taintFilterFnc:   taintFilterFnc,
+		explainSelection: explainSelection,
 	}, nil
 }
 
@@ -95,15 +107,18 @@ func (d *RemovePodsViolatingNodeTaints) Name() string {
 
 // Deschedule extension point implementation for the plugin
 func (d *RemovePodsViolatingNodeTaints) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
-	for _, node := range nodes {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	errCh := parallelize.NewErrorChannel()
+	frameworktypes.GetParallelizer(d.handle).Until(ctx, len(nodes), func(idx int) {
+		node := nodes[idx]
 		klog.V(1).InfoS("Processing node", "node", klog.KObj(node))
 		pods, err := podutil.ListAllPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
 		if err != nil {
-			// no pods evicted as error encountered retrieving evictable Pods
-			return &frameworktypes.Status{
-				Err: fmt.Errorf("error listing pods on a node: %v", err),
-			}
+			errCh.SendErrorWithCancel(err, cancel)
+			return
 		}
+		d.handle.Evictor().Sort(pods)
 		totalPods := len(pods)
 		for i := 0; i < totalPods; i++ {
 			if !utils.TolerationsTolerateTaintsWithFilter(
@@ -118,7 +133,41 @@ func (d *RemovePodsViolatingNodeTaints) Deschedule(ctx context.Context, nodes []
 				}
 			}
 		}
+	})
+	if err := errCh.ReceiveError(); err != nil {
+		// no pods evicted as error encountered retrieving evictable Pods
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error listing pods on a node: %v", err),
+		}
 	}
 
 	return nil
 }
+
+// Explain tells whether the pod is selected for eviction, regardless of the evictor
+func (d *RemovePodsViolatingNodeTaints) Explain(pod *v1.Pod, nodes []*v1.Node) (bool, string) {
+	if err := d.explainSelection(pod); err != nil {
+		return false, err.Error()
+	}
+	var node *v1.Node
+	for _, n := range nodes {
+		if n.Name == pod.Spec.NodeName {
+			node = n
+			break
+		}
+	}
+	if node == nil {
+		return false, fmt.Sprintf("pod node %q is not among the processed nodes", pod.Spec.NodeName)
+	}
+	var untolerated []string
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if d.taintFilterFnc(taint) && !utils.TolerationsTolerateTaint(pod.Spec.Tolerations, taint) {
+			untolerated = append(untolerated, taint.ToString())
+		}
+	}
+	if len(untolerated) == 0 {
+		return false, fmt.Sprintf("pod tolerates the taints of node %q", node.Name)
+	}
+	return true, fmt.Sprintf("pod does not tolerate the taints %v of node %q", untolerated, node.Name)
+}
@@ -32,6 +32,7 @@ import (
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	frameworkfake "sigs.k8s.io/descheduler/pkg/framework/fake"
+	"sigs.k8s.io/descheduler/pkg/framework/parallelize"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
 	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
 	"sigs.k8s.io/descheduler/pkg/utils"
@@ -386,6 +387,7 @@ func TestDeletePodsViolatingNodeTaints(t *testing.T) {
 				ClientsetImpl:                 fakeClient,
 				GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
 				PodEvictorImpl:                podEvictor,
+				ParallelizerImpl:              parallelize.NewParallelizer(4),
 				EvictorFilterImpl:             evictorFilter.(frameworktypes.EvictorPlugin),
 				SharedInformerFactoryImpl:     sharedInformerFactory,
 			}
@@ -509,3 +511,53 @@ func TestToleratesTaint(t *testing.T) {
 		}
 	}
 }
+
+func TestRemovePodsViolatingNodeTaintsExplain(t *testing.T) {
+	node1 := addTaintsToNode(test.BuildTestNode("n1", 2000, 3000, 10, nil), "testTaint", "test", []int{1})
+	node2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+	nodes := []*v1.Node{node1, node2}
+
+	intolerant := test.BuildTestPod("intolerant", 100, 0, node1.Name, nil)
+	tolerant := addTolerationToPod(test.BuildTestPod("tolerant", 100, 0, node1.Name, nil), "testTaint", "test", 1, v1.TaintEffectNoSchedule)
+	unprocessed := test.BuildTestPod("unprocessed", 100, 0, "n3", nil)
+
+	tests := []struct {
+		description      string
+		pod              *v1.Pod
+		expectedSelected bool
+		expectedReason   string
+	}{
+		{
+			description:      "pod not tolerating the node taint",
+			pod:              intolerant,
+			expectedSelected: true,
+			expectedReason:   `pod does not tolerate the taints [testTaint1=test1:NoSchedule] of node "n1"`,
+		},
+		{
+			description:      "pod tolerating the node taint",
+			pod:              tolerant,
+			expectedSelected: false,
+			expectedReason:   `pod tolerates the taints of node "n1"`,
+		},
+		{
+			description:      "pod on a node not processed",
+			pod:              unprocessed,
+			expectedSelected: false,
+			expectedReason:   `pod node "n3" is not among the processed nodes`,
+		},
+	}
+
+	plugin, err := New(&RemovePodsViolatingNodeTaintsArgs{}, &frameworkfake.HandleImpl{})
+	if err != nil {
+		t.Fatalf("Unable to initialize the plugin: %v", err)
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			selected, reason := plugin.(frameworktypes.ExplainPlugin).Explain(tc.pod, nodes)
+			if selected != tc.expectedSelected || reason != tc.expectedReason {
+				t.Errorf("Expected selected=%v with reason %q, got selected=%v with reason %q", tc.expectedSelected, tc.expectedReason, selected, reason)
+			}
+		})
+	}
+}
@@ -304,7 +304,7 @@ func (d *RemovePodsViolatingTopologySpreadConstraint) balanceDomains(
 	constraint := constraintSet.constraint
 	idealAvg := sumPods / float64(len(constraintTopologies))
 	isEvictable := d.handle.Evictor().Filter
-	sortedDomains := sortDomains(constraintTopologies, isEvictable)
+	sortedDomains := sortDomains(constraintTopologies, isEvictable, d.handle.Evictor().SortReversed)
 	getPodsAssignedToNode := d.handle.GetPodsAssignedToNodeFunc()
 	topologyBalanceNodeFit := utilpointer.BoolDeref(d.args.TopologyBalanceNodeFit, true)
 
@@ -399,8 +399,9 @@ func filterNodesBelowIdealAvg(nodes []*v1.Node, sortedDomains []topology, topolo
 // 2. pods with selectors or affinity
 // 3. pods in descending priority
 // 4. all other pods
+// Evictable pods are then ordered by sortPodsReversed, with pods to be evicted first at the back of the list.
 // We then pop pods off the back of the list for eviction
-func sortDomains(constraintTopologyPairs map[topologyPair][]*v1.Pod, isEvictable func(pod *v1.Pod) bool) []topology {
+func sortDomains(constraintTopologyPairs map[topologyPair][]*v1.Pod, isEvictable func(pod *v1.Pod) bool, sortPodsReversed func(pods []*v1.Pod)) []topology {
 	sortedTopologies := make([]topology, 0, len(constraintTopologyPairs))
 	// sort the topologies and return 2 lists: those <= the average and those > the average (> list inverted)
 	for pair, list := range constraintTopologyPairs {
@@ -423,6 +424,13 @@ func sortDomains(constraintTopologyPairs map[topologyPair][]*v1.Pod, isEvictable
 			}
 			return hasSelectorOrAffinity(*list[i]) && !hasSelectorOrAffinity(*list[j])
 		})
+		// non-evictable pods are at the front of the list
+		firstEvictable := 0
+		for firstEvictable < len(list) && !isEvictable(list[firstEvictable]) {
+			firstEvictable++
+		}
+		// the pods are popped off the back, so sort the evictable pods reversed
+		sortPodsReversed(list[firstEvictable:])
 		sortedTopologies = append(sortedTopologies, topology{pair: pair, pods: list})
 	}
 
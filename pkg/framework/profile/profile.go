@@ -15,7 +15,10 @@ package profile
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -24,12 +27,14 @@ import (
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/descheduler/report"
+	"sigs.k8s.io/descheduler/pkg/framework/parallelize"
 	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
 	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
 	"sigs.k8s.io/descheduler/pkg/tracing"
 
 	v1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/util/errors"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/informers"
 	clientset "k8s.io/client-go/kubernetes"
@@ -37,44 +42,332 @@ import (
 	"k8s.io/klog/v2"
 )
 
+// profileEvictor holds the state of the evictor shared by the plugins of a profile
+type profileEvictor struct {
+	podEvictor         *evictions.PodEvictor
+	filters            []reasonsFilter
+	preEvictionFilters []reasonsFilter
+	less               podutil.LessFunc
+	preEvictPlugins    []frameworktypes.PreEvictPlugin
+	postEvictPlugins   []frameworktypes.PostEvictPlugin
+	// recorder records the decision about every eviction candidate, when set
+	recorder *report.Recorder
+	// gracePeriodSeconds overrides the termination grace period of the pods evicted by a plugin, by plugin name
+	gracePeriodSeconds map[string]*int64
+	profileName        string
+}
+
 // evictorImpl implements the Evictor interface so plugins
-// can evict a pod without importing a specific pod evictor
+// can evict a pod without importing a specific pod evictor.
+// Every plugin gets its own evictorImpl, so the evictions are attributed
+// to the plugin requesting them even when plugins run concurrently.
 type evictorImpl struct {
-	podEvictor        *evictions.PodEvictor
-	filter            podutil.FilterFunc
-	preEvictionFilter podutil.FilterFunc
+	*profileEvictor
+	// pluginName is the name of the plugin the evictor is handed to
+	pluginName string
+
+	// counters of the pods evaluated, evicted, skipped and queued by the plugin, and the pods
+	// it proposed which got rejected by a Filter or PreEvictionFilter plugin, guarded
+	// by countsLock as the plugin may evict pods from several workers
+	countsLock sync.Mutex
+	counts     evictionCounts
+	rejections []rejection
+	// rejected tells the rejections buffered since the plugin started, so a pod the plugin
+	// checks several times is reported once
+	rejected sets.Set[rejectionKey]
+}
+
+// reasonsFilter is a Filter or PreEvictionFilter plugin along with the filter telling why it rejects a pod
+type reasonsFilter struct {
+	pluginName string
+	reasons    podutil.FilterReasonsFunc
+}
+
+// rejection is a pod rejected by a Filter or PreEvictionFilter plugin. The rejections
+// are reported on the span of the plugin proposing the pods once the plugin finishes.
+type rejection struct {
+	pod            *v1.Pod
+	pluginName     string
+	extensionPoint frameworktypes.ExtensionPoint
+	reasons        []string
+}
+
+// rejectionKey identifies the rejection of a pod by a plugin at an extension point
+type rejectionKey struct {
+	pod            string
+	pluginName     string
+	extensionPoint frameworktypes.ExtensionPoint
+}
+
+// extensionPointKey is the context key of the extension point a plugin runs at
+type extensionPointKey struct{}
+
+// withExtensionPoint returns a copy of ctx telling the extension point a plugin runs at,
+// which the evictions requested by the plugin within ctx are attributed to
+func withExtensionPoint(ctx context.Context, extensionPoint frameworktypes.ExtensionPoint) context.Context {
+	return context.WithValue(ctx, extensionPointKey{}, extensionPoint)
+}
+
+// extensionPointFrom returns the extension point ctx tells, if any
+func extensionPointFrom(ctx context.Context) frameworktypes.ExtensionPoint {
+	extensionPoint, _ := ctx.Value(extensionPointKey{}).(frameworktypes.ExtensionPoint)
+	return extensionPoint
+}
+
+// evictionCounts counts the eviction candidates by their outcome
+type evictionCounts struct {
+	evaluated uint
+	evicted   uint
+	skipped   uint
+	queued    uint
 }
 
 var _ frameworktypes.Evictor = &evictorImpl{}
 
 // Filter checks if a pod can be evicted
 func (ei *evictorImpl) Filter(pod *v1.Pod) bool {
-	return ei.filter(pod)
+	return ei.filter(pod).IsSuccess()
+}
+
+// filter runs the Filter plugins and returns the rejection of the pod by the first one rejecting it
+func (ei *evictorImpl) filter(pod *v1.Pod) *frameworktypes.Status {
+	for _, f := range ei.filters {
+		if reasons := f.reasons(pod); len(reasons) > 0 {
+			ei.reportRejection(pod, f.pluginName, frameworktypes.FilterExtensionPoint, reasons)
+			return rejectionStatus(frameworktypes.Rejected, f.pluginName, frameworktypes.FilterExtensionPoint, rejectionReason(reasons))
+		}
+	}
+	return nil
 }
 
 // PreEvictionFilter checks if pod can be evicted right before eviction
 func (ei *evictorImpl) PreEvictionFilter(pod *v1.Pod) bool {
-	return ei.preEvictionFilter(pod)
+	return ei.preEvictionFilter(pod).IsSuccess()
+}
+
+// preEvictionFilter runs the PreEvictionFilter plugins and returns the rejection of the pod by the first one rejecting it
+func (ei *evictorImpl) preEvictionFilter(pod *v1.Pod) *frameworktypes.Status {
+	for _, f := range ei.preEvictionFilters {
+		if reasons := f.reasons(pod); len(reasons) > 0 {
+			ei.reportRejection(pod, f.pluginName, frameworktypes.PreEvictionFilterExtensionPoint, reasons)
+			ei.countsLock.Lock()
+			defer ei.countsLock.Unlock()
+			ei.counts.evaluated++
+			ei.counts.skipped++
+			return rejectionStatus(frameworktypes.Rejected, f.pluginName, frameworktypes.PreEvictionFilterExtensionPoint, rejectionReason(reasons))
+		}
+	}
+	return nil
+}
+
+// rejectionStatus returns the status of a pod rejected by a plugin at an extension point, telling which one and why
+func rejectionStatus(code frameworktypes.Code, pluginName string, extensionPoint frameworktypes.ExtensionPoint, reason string) *frameworktypes.Status {
+	message := fmt.Sprintf("rejected by the %v plugin at the %v extension point", pluginName, extensionPoint)
+	if reason != "" {
+		message += ": " + reason
+	}
+	return frameworktypes.NewStatus(code, message)
+}
+
+// rejectionReason describes the reasons a Filter or PreEvictionFilter plugin rejects a pod for,
+// empty when the plugin does not tell
+func rejectionReason(reasons []string) string {
+	if len(reasons) == 1 && reasons[0] == podutil.UnknownFilterReason {
+		return ""
+	}
+	return strings.Join(reasons, ", ")
+}
+
+// newFilter returns the filter of a Filter plugin, asking for the reasons of a rejection when the plugin can tell
+func newFilter(pl filterPlugin) reasonsFilter {
+	if reasonsPlugin, ok := pl.(frameworktypes.FilterReasonsPlugin); ok {
+		return reasonsFilter{pluginName: pl.Name(), reasons: reasonsPlugin.FilterReasons}
+	}
+	return reasonsFilter{pluginName: pl.Name(), reasons: podutil.AsFilterReasonsFunc(pl.Filter)}
+}
+
+// newPreEvictionFilter returns the filter of a PreEvictionFilter plugin, asking for the reasons of a rejection
+// when the plugin can tell
+func newPreEvictionFilter(pl preEvictionFilterPlugin) reasonsFilter {
+	if reasonsPlugin, ok := pl.(frameworktypes.PreEvictionFilterReasonsPlugin); ok {
+		return reasonsFilter{pluginName: pl.Name(), reasons: reasonsPlugin.PreEvictionFilterReasons}
+	}
+	return reasonsFilter{pluginName: pl.Name(), reasons: podutil.AsFilterReasonsFunc(pl.PreEvictionFilter)}
+}
+
+// reportRejection buffers a pod rejected by a Filter or PreEvictionFilter plugin. The rejection is reported
+// through metrics, tracing and the decisions once the plugin proposing the pod finishes, as Filter and
+// PreEvictionFilter do not tell the extension point and the span the plugin runs at. A pod rejected
+// several times by the same plugin at the same extension point is reported once.
+func (ei *evictorImpl) reportRejection(pod *v1.Pod, pluginName string, extensionPoint frameworktypes.ExtensionPoint, reasons []string) {
+	key := rejectionKey{pod: klog.KObj(pod).String(), pluginName: pluginName, extensionPoint: extensionPoint}
+	ei.countsLock.Lock()
+	defer ei.countsLock.Unlock()
+	if ei.rejected.Has(key) {
+		return
+	}
+	if ei.rejected == nil {
+		ei.rejected = sets.New[rejectionKey]()
+	}
+	ei.rejected.Insert(key)
+	ei.rejections = append(ei.rejections, rejection{pod: pod, pluginName: pluginName, extensionPoint: extensionPoint, reasons: reasons})
+}
+
+// flushRejections reports the pods rejected since the plugin started through metrics, on the span
+// of the plugin and through the decisions, as proposed by the plugin at the given extension point
+func (ei *evictorImpl) flushRejections(span trace.Span, extensionPoint frameworktypes.ExtensionPoint) {
+	ei.countsLock.Lock()
+	rejections := ei.rejections
+	ei.rejections = nil
+	ei.rejected = nil
+	ei.countsLock.Unlock()
+
+	for _, r := range rejections {
+		for _, reason := range r.reasons {
+			metrics.PodsFiltered.With(map[string]string{"reason": reason, "plugin": r.pluginName, "extension_point": string(r.extensionPoint), "strategy": ei.pluginName, "profile": ei.profileName}).Inc()
+		}
+		reason := rejectionReason(r.reasons)
+		span.AddEvent("Pod Filtered", trace.WithAttributes(attribute.String("pod", klog.KObj(r.pod).String()), attribute.String("plugin", r.pluginName), attribute.String("extension point", string(r.extensionPoint)), attribute.String("reason", reason)))
+		ei.recordRejection(r.pod, extensionPoint, r.pluginName, r.extensionPoint, reason)
+	}
+}
+
+// decision returns the decision about a pod proposed by the plugin at an extension point
+func (ei *evictorImpl) decision(pod *v1.Pod, extensionPoint frameworktypes.ExtensionPoint, result report.Result) report.Decision {
+	return report.Decision{
+		Pod:            klog.KObj(pod).String(),
+		Node:           pod.Spec.NodeName,
+		Profile:        ei.profileName,
+		Plugin:         ei.pluginName,
+		ExtensionPoint: string(extensionPoint),
+		Result:         result,
+	}
+}
+
+// recordRejection records a pod proposed at an extension point and rejected by a plugin at another extension point
+func (ei *evictorImpl) recordRejection(pod *v1.Pod, proposedAt frameworktypes.ExtensionPoint, pluginName string, extensionPoint frameworktypes.ExtensionPoint, reason string) {
+	if ei.recorder == nil {
+		return
+	}
+	decision := ei.decision(pod, proposedAt, report.Rejected)
+	decision.RejectedBy = pluginName
+	decision.RejectedAt = string(extensionPoint)
+	decision.Reason = reason
+	ei.recorder.Record(decision)
 }
 
 // Evict evicts a pod (no pre-check performed)
 func (ei *evictorImpl) Evict(ctx context.Context, pod *v1.Pod, opts evictions.EvictOptions) bool {
-	return ei.podEvictor.EvictPod(ctx, pod, opts)
+	status, err := ei.evict(ctx, pod, opts)
+	return status.IsSuccess() && err == nil
+}
+
+// evict runs the PreEvict plugins, evicts the pod and runs the PostEvict plugins.
+// It returns the rejection of the pod by the PreEvict plugin vetoing the eviction, or the error evicting the pod.
+func (ei *evictorImpl) evict(ctx context.Context, pod *v1.Pod, opts evictions.EvictOptions) (*frameworktypes.Status, error) {
+	if opts.ProfileName == "" {
+		opts.ProfileName = ei.profileName
+	}
+	if opts.PluginName == "" {
+		opts.PluginName = ei.pluginName
+	}
+	if opts.ExtensionPoint == "" {
+		opts.ExtensionPoint = string(extensionPointFrom(ctx))
+	}
+	if opts.GracePeriodSeconds == nil {
+		opts.GracePeriodSeconds = ei.gracePeriodSeconds[opts.PluginName]
+	}
+
+	for _, pl := range ei.preEvictPlugins {
+		if status := pl.PreEvict(ctx, pod, &opts); !status.IsSuccess() {
+			klog.V(2).InfoS("Eviction vetoed", "pod", klog.KObj(pod), "plugin", pl.Name(), "reason", status.GetReason(), "profile", opts.ProfileName, "strategy", opts.PluginName)
+			trace.SpanFromContext(ctx).AddEvent("Eviction Vetoed", trace.WithAttributes(attribute.String("pod", klog.KObj(pod).String()), attribute.String("plugin", pl.Name()), attribute.String("reason", status.GetReason())))
+			ei.recordRejection(pod, frameworktypes.ExtensionPoint(opts.ExtensionPoint), pl.Name(), frameworktypes.PreEvictExtensionPoint, status.GetReason())
+			ei.countsLock.Lock()
+			defer ei.countsLock.Unlock()
+			ei.counts.evaluated++
+			ei.counts.skipped++
+			return rejectionStatus(status.GetCode(), pl.Name(), frameworktypes.PreEvictExtensionPoint, status.GetReason()), nil
+		}
+	}
+
+	// An eviction blocked by a pod disruption budget gets queued and counts against the target of the plugin,
+	// its final outcome is only known once the descheduling loop retried it
+	err := ei.podEvictor.TryEvictPod(evictions.WithRetryFinished(ctx, func(ctx context.Context, err error) {
+		ei.evictionFinished(ctx, pod, opts, err)
+	}), pod, opts)
+	if errors.Is(err, evictions.ErrEvictionQueued) {
+		ei.countsLock.Lock()
+		defer ei.countsLock.Unlock()
+		ei.counts.evaluated++
+		ei.counts.queued++
+		return nil, nil
+	}
+	ei.evictionFinished(ctx, pod, opts, err)
+
+	ei.countsLock.Lock()
+	defer ei.countsLock.Unlock()
+	ei.counts.evaluated++
+	if err != nil {
+		ei.counts.skipped++
+		return nil, err
+	}
+	ei.counts.evicted++
+	return nil, nil
+}
+
+// evictionFinished records the final outcome of the eviction of a pod and runs the PostEvict plugins
+func (ei *evictorImpl) evictionFinished(ctx context.Context, pod *v1.Pod, opts evictions.EvictOptions, err error) {
+	if ei.recorder != nil {
+		decision := ei.decision(pod, frameworktypes.ExtensionPoint(opts.ExtensionPoint), report.Evicted)
+		decision.Profile, decision.Plugin = opts.ProfileName, opts.PluginName
+		if err != nil {
+			decision.Result = report.Failed
+			decision.Reason = err.Error()
+		}
+		ei.recorder.Record(decision)
+	}
+
+	for _, pl := range ei.postEvictPlugins {
+		pl.PostEvict(ctx, pod, opts, err == nil)
+	}
+}
+
+// getCounts returns a snapshot of the evictor counts
+func (ei *evictorImpl) getCounts() evictionCounts {
+	ei.countsLock.Lock()
+	defer ei.countsLock.Unlock()
+	return ei.counts
 }
 
 func (ei *evictorImpl) NodeLimitExceeded(node *v1.Node) bool {
 	return ei.podEvictor.NodeLimitExceeded(node)
 }
 
+// Sort sorts pods in the order given by the PreSort and Sort plugins
+func (ei *evictorImpl) Sort(pods []*v1.Pod) {
+	podutil.SortPods(pods, ei.less)
+}
+
+// SortReversed sorts pods in the reverse order given by the PreSort and Sort plugins
+func (ei *evictorImpl) SortReversed(pods []*v1.Pod) {
+	podutil.SortPodsReversed(pods, ei.less)
+}
+
 // handleImpl implements the framework handle which gets passed to plugins
 type handleImpl struct {
 	clientSet                 clientset.Interface
 	getPodsAssignedToNodeFunc podutil.GetPodsAssignedToNodeFunc
 	sharedInformerFactory     informers.SharedInformerFactory
 	evictor                   *evictorImpl
+	parallelizer              parallelize.Parallelizer
+	ctx                       context.Context
 }
 
-var _ frameworktypes.Handle = &handleImpl{}
+var (
+	_ frameworktypes.ContextHandle      = &handleImpl{}
+	_ frameworktypes.ParallelizerHandle = &handleImpl{}
+)
 
 // ClientSet retrieves kube client set
 func (hi *handleImpl) ClientSet() clientset.Interface {
@@ -96,6 +389,16 @@ func (hi *handleImpl) Evictor() frameworktypes.Evictor {
 	return hi.evictor
 }
 
+// Context retrieves the context of the descheduling loop the profile runs within
+func (hi *handleImpl) Context() context.Context {
+	return hi.ctx
+}
+
+// Parallelizer retrieves parallelizer so plugins can process nodes concurrently
+func (hi *handleImpl) Parallelizer() parallelize.Parallelizer {
+	return hi.parallelizer
+}
+
 type filterPlugin interface {
 	frameworktypes.Plugin
 	Filter(pod *v1.Pod) bool
@@ -109,17 +412,31 @@ type preEvictionFilterPlugin interface {
 type profileImpl struct {
 	profileName string
 	podEvictor  *evictions.PodEvictor
+	// evictors of the Deschedule and Balance plugins, in the order of the plugins
+	descheduleEvictors []*evictorImpl
+	balanceEvictors    []*evictorImpl
 
+	preSortPlugins           []frameworktypes.PreSortPlugin
+	sortPlugins              []frameworktypes.SortPlugin
 	deschedulePlugins        []frameworktypes.DeschedulePlugin
 	balancePlugins           []frameworktypes.BalancePlugin
 	filterPlugins            []filterPlugin
 	preEvictionFilterPlugins []preEvictionFilterPlugin
+	preEvictPlugins          []frameworktypes.PreEvictPlugin
+	postEvictPlugins         []frameworktypes.PostEvictPlugin
+	// filters and preEvictionFilters are the Filter and PreEvictionFilter plugins with their reasons
+	filters            []reasonsFilter
+	preEvictionFilters []reasonsFilter
 
 	// Each extension point with a list of plugins implementing the extension point.
+	preSort           sets.Set[string]
+	sort              sets.Set[string]
 	deschedule        sets.Set[string]
 	balance           sets.Set[string]
 	filter            sets.Set[string]
 	preEvictionFilter sets.Set[string]
+	preEvict          sets.Set[string]
+	postEvict         sets.Set[string]
 }
 
 // Option for the handleImpl.
@@ -130,6 +447,9 @@ type handleImplOpts struct {
 	sharedInformerFactory     informers.SharedInformerFactory
 	getPodsAssignedToNodeFunc podutil.GetPodsAssignedToNodeFunc
 	podEvictor                *evictions.PodEvictor
+	parallelism               int
+	recorder                  *report.Recorder
+	ctx                       context.Context
 }
 
 // WithClientSet sets clientSet for the scheduling frameworkImpl.
@@ -157,15 +477,46 @@ func WithGetPodsAssignedToNodeFnc(getPodsAssignedToNodeFunc podutil.GetPodsAssig
 	}
 }
 
+// WithParallelism sets the maximum number of workers plugins process nodes with.
+func WithParallelism(parallelism int) Option {
+	return func(o *handleImplOpts) {
+		o.parallelism = parallelism
+	}
+}
+
+// WithReportRecorder sets the recorder of the decisions about every eviction candidate.
+func WithReportRecorder(recorder *report.Recorder) Option {
+	return func(o *handleImplOpts) {
+		o.recorder = recorder
+	}
+}
+
+// WithContext sets the context of the descheduling loop the profile runs within.
+func WithContext(ctx context.Context) Option {
+	return func(o *handleImplOpts) {
+		o.ctx = ctx
+	}
+}
+
+// getPluginConfig returns the config of the plugin instance the profile refers to by the given name.
+// An instance named by its args is referred to by that name only.
 func getPluginConfig(pluginName string, pluginConfigs []api.PluginConfig) (*api.PluginConfig, int) {
 	for idx, pluginConfig := range pluginConfigs {
-		if pluginConfig.Name == pluginName {
+		if pluginInstanceName(pluginConfig) == pluginName {
 			return &pluginConfig, idx
 		}
 	}
 	return nil, 0
 }
 
+// pluginInstanceName returns the name the profile refers to the plugin instance configured by the config with
+func pluginInstanceName(pluginConfig api.PluginConfig) string {
+	if instanceName := pluginregistry.InstanceName(pluginConfig.Args); instanceName != "" {
+		return instanceName
+	}
+	return pluginConfig.Name
+}
+
 func buildPlugin(config api.DeschedulerProfile, pluginName string, handle *handleImpl, reg pluginregistry.Registry) (frameworktypes.Plugin, error) {
 	pc, _ := getPluginConfig(pluginName, config.PluginConfigs)
 	if pc == nil {
@@ -173,10 +524,10 @@ func buildPlugin(config api.DeschedulerProfile, pluginName string, handle *handl
 		return nil, fmt.Errorf("unable to find %q plugin config", pluginName)
 	}
 
-	registryPlugin, ok := reg[pluginName]
+	registryPlugin, ok := reg[pc.Name]
 	if !ok {
-		klog.ErrorS(fmt.Errorf("unable to find plugin in the pluginsMap"), "skipping plugin", "plugin", pluginName)
-		return nil, fmt.Errorf("unable to find %q plugin in the pluginsMap", pluginName)
+		klog.ErrorS(fmt.Errorf("unable to find plugin in the pluginsMap"), "skipping plugin", "plugin", pc.Name)
+		return nil, fmt.Errorf("unable to find %q plugin in the pluginsMap", pc.Name)
 	}
 	pg, err := registryPlugin.PluginBuilder(pc.Args, handle)
 	if err != nil {
@@ -186,26 +537,54 @@ func buildPlugin(config api.DeschedulerProfile, pluginName string, handle *handl
 	return pg, nil
 }
 
-func (p *profileImpl) registryToExtensionPoints(registry pluginregistry.Registry) {
+func (p *profileImpl) registryToExtensionPoints(registry pluginregistry.Registry, pluginConfigs []api.PluginConfig) {
+	p.preSort = sets.New[string]()
+	p.sort = sets.New[string]()
 	p.deschedule = sets.New[string]()
 	p.balance = sets.New[string]()
 	p.filter = sets.New[string]()
 	p.preEvictionFilter = sets.New[string]()
+	p.preEvict = sets.New[string]()
+	p.postEvict = sets.New[string]()
 
 	for plugin, pluginUtilities := range registry {
-		if _, ok := pluginUtilities.PluginType.(frameworktypes.DeschedulePlugin); ok {
-			p.deschedule.Insert(plugin)
-		}
-		if _, ok := pluginUtilities.PluginType.(frameworktypes.BalancePlugin); ok {
-			p.balance.Insert(plugin)
-		}
-		if _, ok := pluginUtilities.PluginType.(frameworktypes.EvictorPlugin); ok {
-			p.filter.Insert(plugin)
-			p.preEvictionFilter.Insert(plugin)
+		p.addExtensionPoints(plugin, pluginUtilities.PluginType)
+	}
+	// The plugin instances named by their args implement the extension points of their plugin
+	for _, pluginConfig := range pluginConfigs {
+		instanceName := pluginregistry.InstanceName(pluginConfig.Args)
+		if pluginUtilities, ok := registry[pluginConfig.Name]; ok && instanceName != "" {
+			p.addExtensionPoints(instanceName, pluginUtilities.PluginType)
 		}
 	}
 }
 
+// addExtensionPoints adds the plugin to the extension points its type implements
+func (p *profileImpl) addExtensionPoints(plugin string, pluginType interface{}) {
+	if _, ok := pluginType.(frameworktypes.PreSortPlugin); ok {
+		p.preSort.Insert(plugin)
+	}
+	if _, ok := pluginType.(frameworktypes.SortPlugin); ok {
+		p.sort.Insert(plugin)
+	}
+	if _, ok := pluginType.(frameworktypes.DeschedulePlugin); ok {
+		p.deschedule.Insert(plugin)
+	}
+	if _, ok := pluginType.(frameworktypes.BalancePlugin); ok {
+		p.balance.Insert(plugin)
+	}
+	if _, ok := pluginType.(frameworktypes.EvictorPlugin); ok {
+		p.filter.Insert(plugin)
+		p.preEvictionFilter.Insert(plugin)
+	}
+	if _, ok := pluginType.(frameworktypes.PreEvictPlugin); ok {
+		p.preEvict.Insert(plugin)
+	}
+	if _, ok := pluginType.(frameworktypes.PostEvictPlugin); ok {
+		p.postEvict.Insert(plugin)
+	}
+}
+
 func NewProfile(config api.DeschedulerProfile, reg pluginregistry.Registry, opts ...Option) (*profileImpl, error) {
 	hOpts := &handleImplOpts{}
 	for _, optFnc := range opts {
@@ -227,13 +606,23 @@ func NewProfile(config api.DeschedulerProfile, reg pluginregistry.Registry, opts
 	pi := &profileImpl{
 		profileName:              config.Name,
 		podEvictor:               hOpts.podEvictor,
+		preSortPlugins:           []frameworktypes.PreSortPlugin{},
+		sortPlugins:              []frameworktypes.SortPlugin{},
 		deschedulePlugins:        []frameworktypes.DeschedulePlugin{},
 		balancePlugins:           []frameworktypes.BalancePlugin{},
 		filterPlugins:            []filterPlugin{},
 		preEvictionFilterPlugins: []preEvictionFilterPlugin{},
+		preEvictPlugins:          []frameworktypes.PreEvictPlugin{},
+		postEvictPlugins:         []frameworktypes.PostEvictPlugin{},
 	}
-	pi.registryToExtensionPoints(reg)
+	pi.registryToExtensionPoints(reg, config.PluginConfigs)
 
+	if !pi.preSort.HasAll(config.Plugins.PreSort.Enabled...) {
+		return nil, fmt.Errorf("profile %q configures preSort extension point of non-existing plugins: %v", config.Name, sets.New(config.Plugins.PreSort.Enabled...).Difference(pi.preSort))
+	}
+	if !pi.sort.HasAll(config.Plugins.Sort.Enabled...) {
+		return nil, fmt.Errorf("profile %q configures sort extension point of non-existing plugins: %v", config.Name, sets.New(config.Plugins.Sort.Enabled...).Difference(pi.sort))
+	}
 	if !pi.deschedule.HasAll(config.Plugins.Deschedule.Enabled...) {
 		return nil, fmt.Errorf("profile %q configures deschedule extension point of non-existing plugins: %v", config.Name, sets.New(config.Plugins.Deschedule.Enabled...).Difference(pi.deschedule))
 	}
@@ -246,23 +635,52 @@ func NewProfile(config api.DeschedulerProfile, reg pluginregistry.Registry, opts
 	if !pi.preEvictionFilter.HasAll(config.Plugins.PreEvictionFilter.Enabled...) {
 		return nil, fmt.Errorf("profile %q configures preEvictionFilter extension point of non-existing plugins: %v", config.Name, sets.New(config.Plugins.PreEvictionFilter.Enabled...).Difference(pi.preEvictionFilter))
 	}
+	if !pi.preEvict.HasAll(config.Plugins.PreEvict.Enabled...) {
+		return nil, fmt.Errorf("profile %q configures preEvict extension point of non-existing plugins: %v", config.Name, sets.New(config.Plugins.PreEvict.Enabled...).Difference(pi.preEvict))
+	}
+	if !pi.postEvict.HasAll(config.Plugins.PostEvict.Enabled...) {
+		return nil, fmt.Errorf("profile %q configures postEvict extension point of non-existing plugins: %v", config.Name, sets.New(config.Plugins.PostEvict.Enabled...).Difference(pi.postEvict))
+	}
 
+	sharedEvictor := &profileEvictor{
+		podEvictor:         hOpts.podEvictor,
+		profileName:        config.Name,
+		recorder:           hOpts.recorder,
+		gracePeriodSeconds: map[string]*int64{},
+	}
 	handle := &handleImpl{
 		clientSet:                 hOpts.clientSet,
 		getPodsAssignedToNodeFunc: hOpts.getPodsAssignedToNodeFunc,
 		sharedInformerFactory:     hOpts.sharedInformerFactory,
-		evictor: &evictorImpl{
-			podEvictor: hOpts.podEvictor,
-		},
+		parallelizer:              parallelize.NewParallelizer(hOpts.parallelism),
+		ctx:                       hOpts.ctx,
+	}
+	if handle.ctx == nil {
+		handle.ctx = context.Background()
+	}
+
+	for _, pluginConfig := range config.PluginConfigs {
+		if pluginConfig.GracePeriodSeconds != nil {
+			sharedEvictor.gracePeriodSeconds[pluginInstanceName(pluginConfig)] = pluginConfig.GracePeriodSeconds
+		}
 	}
 
-	pluginNames := append(config.Plugins.Deschedule.Enabled, config.Plugins.Balance.Enabled...)
+	pluginNames := append([]string{}, config.Plugins.PreSort.Enabled...)
+	pluginNames = append(pluginNames, config.Plugins.Sort.Enabled...)
+	pluginNames = append(pluginNames, config.Plugins.Deschedule.Enabled...)
+	pluginNames = append(pluginNames, config.Plugins.Balance.Enabled...)
 	pluginNames = append(pluginNames, config.Plugins.Filter.Enabled...)
 	pluginNames = append(pluginNames, config.Plugins.PreEvictionFilter.Enabled...)
+	pluginNames = append(pluginNames, config.Plugins.PreEvict.Enabled...)
+	pluginNames = append(pluginNames, config.Plugins.PostEvict.Enabled...)
 
 	plugins := make(map[string]frameworktypes.Plugin)
+	evictors := make(map[string]*evictorImpl)
 	for _, plugin := range sets.New(pluginNames...).UnsortedList() {
-		pg, err := buildPlugin(config, plugin, handle, reg)
+		// Every plugin gets its own handle, with an evictor attributing the evictions to the plugin
+		pluginHandle := *handle
+		pluginHandle.evictor = &evictorImpl{profileEvictor: sharedEvictor, pluginName: plugin}
+		pg, err := buildPlugin(config, plugin, &pluginHandle, reg)
 		if err != nil {
 			return nil, fmt.Errorf("unable to build %v plugin: %v", plugin, err)
 		}
@@ -270,98 +688,301 @@ func NewProfile(config api.DeschedulerProfile, reg pluginregistry.Registry, opts
 			return nil, fmt.Errorf("got empty %v plugin build", plugin)
 		}
 		plugins[plugin] = pg
+		evictors[plugin] = pluginHandle.evictor
+	}
+
+	// The enabled lists are expected to already account for the default plugins
+	// and the disabled ones, i.e. to be computed as (DefaultEnabled + Enabled - Disabled).
+	// PreSort plugins are consulted before Sort plugins, so their ordering takes precedence
+	lessFuncs := []podutil.LessFunc{}
+	for _, pluginName := range config.Plugins.PreSort.Enabled {
+		pi.preSortPlugins = append(pi.preSortPlugins, plugins[pluginName].(frameworktypes.PreSortPlugin))
+		lessFuncs = append(lessFuncs, plugins[pluginName].(frameworktypes.PreSortPlugin).PreLess)
+	}
+
+	for _, pluginName := range config.Plugins.Sort.Enabled {
+		pi.sortPlugins = append(pi.sortPlugins, plugins[pluginName].(frameworktypes.SortPlugin))
+		lessFuncs = append(lessFuncs, plugins[pluginName].(frameworktypes.SortPlugin).Less)
 	}
 
-	// Later, when a default list of plugins and their extension points is established,
-	// compute the list of enabled extension points as (DefaultEnabled + Enabled - Disabled)
 	for _, pluginName := range config.Plugins.Deschedule.Enabled {
 		pi.deschedulePlugins = append(pi.deschedulePlugins, plugins[pluginName].(frameworktypes.DeschedulePlugin))
+		pi.descheduleEvictors = append(pi.descheduleEvictors, evictors[pluginName])
 	}
 
 	for _, pluginName := range config.Plugins.Balance.Enabled {
 		pi.balancePlugins = append(pi.balancePlugins, plugins[pluginName].(frameworktypes.BalancePlugin))
+		pi.balanceEvictors = append(pi.balanceEvictors, evictors[pluginName])
 	}
 
-	filters := []podutil.FilterFunc{}
 	for _, pluginName := range config.Plugins.Filter.Enabled {
 		pi.filterPlugins = append(pi.filterPlugins, plugins[pluginName].(filterPlugin))
-		filters = append(filters, plugins[pluginName].(filterPlugin).Filter)
+		pi.filters = append(pi.filters, newFilter(plugins[pluginName].(filterPlugin)))
 	}
 
-	preEvictionFilters := []podutil.FilterFunc{}
 	for _, pluginName := range config.Plugins.PreEvictionFilter.Enabled {
 		pi.preEvictionFilterPlugins = append(pi.preEvictionFilterPlugins, plugins[pluginName].(preEvictionFilterPlugin))
-		preEvictionFilters = append(preEvictionFilters, plugins[pluginName].(preEvictionFilterPlugin).PreEvictionFilter)
+		pi.preEvictionFilters = append(pi.preEvictionFilters, newPreEvictionFilter(plugins[pluginName].(preEvictionFilterPlugin)))
+	}
+
+	for _, pluginName := range config.Plugins.PreEvict.Enabled {
+		pi.preEvictPlugins = append(pi.preEvictPlugins, plugins[pluginName].(frameworktypes.PreEvictPlugin))
+	}
+
+	for _, pluginName := range config.Plugins.PostEvict.Enabled {
+		pi.postEvictPlugins = append(pi.postEvictPlugins, plugins[pluginName].(frameworktypes.PostEvictPlugin))
 	}
 
-	handle.evictor.filter = podutil.WrapFilterFuncs(filters...)
-	handle.evictor.preEvictionFilter = podutil.WrapFilterFuncs(preEvictionFilters...)
+	sharedEvictor.preEvictPlugins = pi.preEvictPlugins
+	sharedEvictor.postEvictPlugins = pi.postEvictPlugins
+	sharedEvictor.filters = pi.filters
+	sharedEvictor.preEvictionFilters = pi.preEvictionFilters
+	if len(lessFuncs) > 0 {
+		sharedEvictor.less = podutil.WrapLessFuncs(lessFuncs...)
+	}
 
 	return pi, nil
 }
 
 func (d profileImpl) RunDeschedulePlugins(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
-	errs := []error{}
-	for _, pl := range d.deschedulePlugins {
+	statuses := []pluginStatus{}
+	for i, pl := range d.deschedulePlugins {
 		var span trace.Span
 		ctx, span = tracing.Tracer().Start(ctx, pl.Name(), trace.WithAttributes(attribute.String("plugin", pl.Name()), attribute.String("profile", d.profileName), attribute.String("operation", tracing.DescheduleOperation)))
 		defer span.End()
-		evicted := d.podEvictor.TotalEvicted()
-		// TODO: strategyName should be accessible from within the strategy using a framework
-		// handle or function which the Evictor has access to. For migration/in-progress framework
-		// work, we are currently passing this via context. To be removed
-		// (See discussion thread https://github.com/kubernetes-sigs/descheduler/pull/885#discussion_r919962292)
+		evictor := d.descheduleEvictors[i]
+		counts := evictor.getCounts()
 		strategyStart := time.Now()
-		childCtx := context.WithValue(ctx, "strategyName", pl.Name())
-		status := pl.Deschedule(childCtx, nodes)
+		status := pl.Deschedule(withExtensionPoint(ctx, frameworktypes.DescheduleExtensionPoint), nodes)
 		metrics.DeschedulerStrategyDuration.With(map[string]string{"strategy": pl.Name(), "profile": d.profileName}).Observe(time.Since(strategyStart).Seconds())
+		evictor.flushRejections(span, frameworktypes.DescheduleExtensionPoint)
 
-		if status != nil && status.Err != nil {
-			span.AddEvent("Plugin Execution Failed", trace.WithAttributes(attribute.String("err", status.Err.Error())))
-			errs = append(errs, fmt.Errorf("plugin %q finished with error: %v", pl.Name(), status.Err))
-		}
-		klog.V(1).InfoS("Total number of pods evicted", "extension point", "Deschedule", "evictedPods", d.podEvictor.TotalEvicted()-evicted)
+		status = completeStatus(status, counts, evictor.getCounts())
+		d.recordStatus(span, pl.Name(), frameworktypes.DescheduleExtensionPoint, status)
+		statuses = append(statuses, pluginStatus{pluginName: pl.Name(), status: status})
 	}
 
-	aggrErr := errors.NewAggregate(errs)
-	if aggrErr == nil {
-		return &frameworktypes.Status{}
-	}
-
-	return &frameworktypes.Status{
-		Err: fmt.Errorf("%v", aggrErr.Error()),
-	}
+	return mergeStatuses(statuses)
 }
 
 func (d profileImpl) RunBalancePlugins(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
-	errs := []error{}
-	for _, pl := range d.balancePlugins {
+	statuses := []pluginStatus{}
+	for i, pl := range d.balancePlugins {
 		var span trace.Span
 		ctx, span = tracing.Tracer().Start(ctx, pl.Name(), trace.WithAttributes(attribute.String("plugin", pl.Name()), attribute.String("profile", d.profileName), attribute.String("operation", tracing.BalanceOperation)))
 		defer span.End()
-		evicted := d.podEvictor.TotalEvicted()
-		// TODO: strategyName should be accessible from within the strategy using a framework
-		// handle or function which the Evictor has access to. For migration/in-progress framework
-		// work, we are currently passing this via context. To be removed
-		// (See discussion thread https://github.com/kubernetes-sigs/descheduler/pull/885#discussion_r919962292)
+		evictor := d.balanceEvictors[i]
+		counts := evictor.getCounts()
 		strategyStart := time.Now()
-		childCtx := context.WithValue(ctx, "strategyName", pl.Name())
-		status := pl.Balance(childCtx, nodes)
+		status := pl.Balance(withExtensionPoint(ctx, frameworktypes.BalanceExtensionPoint), nodes)
 		metrics.DeschedulerStrategyDuration.With(map[string]string{"strategy": pl.Name(), "profile": d.profileName}).Observe(time.Since(strategyStart).Seconds())
+		evictor.flushRejections(span, frameworktypes.BalanceExtensionPoint)
 
-		if status != nil && status.Err != nil {
-			span.AddEvent("Plugin Execution Failed", trace.WithAttributes(attribute.String("err", status.Err.Error())))
-			errs = append(errs, fmt.Errorf("plugin %q finished with error: %v", pl.Name(), status.Err))
+		status = completeStatus(status, counts, evictor.getCounts())
+		d.recordStatus(span, pl.Name(), frameworktypes.BalanceExtensionPoint, status)
+		statuses = append(statuses, pluginStatus{pluginName: pl.Name(), status: status})
+	}
+
+	return mergeStatuses(statuses)
+}
+
+// EvictPlanned evicts a pod a Deschedule or Balance plugin of the profile planned to evict earlier.
+// The pod may have changed since, so it goes through the Filter and PreEvictionFilter plugins again
+// before the PreEvict plugins, the eviction and the PostEvict plugins, as any eviction of the plugin does.
+// A pod rejected by a plugin, or planned by a plugin no longer enabled in the profile, is not evicted
+// and the returned status tells why. The returned error tells why the eviction failed.
+func (d profileImpl) EvictPlanned(ctx context.Context, pod *v1.Pod, opts evictions.EvictOptions) (*frameworktypes.Status, error) {
+	extensionPoint := frameworktypes.ExtensionPoint(opts.ExtensionPoint)
+	evictors := d.descheduleEvictors
+	if extensionPoint == frameworktypes.BalanceExtensionPoint {
+		evictors = d.balanceEvictors
+	}
+	var evictor *evictorImpl
+	for _, ei := range evictors {
+		if ei.pluginName == opts.PluginName {
+			evictor = ei
+			break
+		}
+	}
+	if evictor == nil {
+		return frameworktypes.NewStatus(frameworktypes.Skip, fmt.Sprintf("the plugin %q is no longer enabled at the %v extension point of the profile", opts.PluginName, extensionPoint)), nil
+	}
+
+	ctx = withExtensionPoint(ctx, extensionPoint)
+	defer evictor.flushRejections(trace.SpanFromContext(ctx), extensionPoint)
+	if status := evictor.filter(pod); !status.IsSuccess() {
+		return status, nil
+	}
+	if status := evictor.preEvictionFilter(pod); !status.IsSuccess() {
+		return status, nil
+	}
+	return evictor.evict(ctx, pod, opts)
+}
+
+// VerdictResult is the outcome of the verdict of a plugin about a pod
+type VerdictResult string
+
+const (
+	// Accepted means the Filter or PreEvictionFilter plugin accepts the pod
+	Accepted VerdictResult = "Accepted"
+	// Rejected means the Filter or PreEvictionFilter plugin rejects the pod
+	Rejected VerdictResult = "Rejected"
+	// Selected means the Deschedule or Balance plugin selects the pod for eviction
+	Selected VerdictResult = "Selected"
+	// NotSelected means the Deschedule or Balance plugin does not select the pod for eviction
+	NotSelected VerdictResult = "NotSelected"
+	// Unexplained means the Deschedule or Balance plugin can not tell about a single pod,
+	// e.g. as it weighs all the pods of a node against each other
+	Unexplained VerdictResult = "Unexplained"
+)
+
+// Verdict is the verdict of a plugin about a pod at an extension point
+type Verdict struct {
+	Plugin         string                        `json:"plugin"`
+	ExtensionPoint frameworktypes.ExtensionPoint `json:"extensionPoint"`
+	Result         VerdictResult                 `json:"result"`
+	// Reason explains the result, when the plugin tells
+	Reason string `json:"reason,omitempty"`
+}
+
+// Explain returns the verdicts of the Filter and PreEvictionFilter plugins of the profile about a pod,
+// followed by those of the Deschedule and Balance plugins processing the nodes. Unlike when running
+// the extension points, every plugin gives its verdict regardless of the verdicts of the previous ones.
+func (d profileImpl) Explain(pod *v1.Pod, nodes []*v1.Node) []Verdict {
+	var verdicts []Verdict
+	filterVerdict := func(f reasonsFilter, extensionPoint frameworktypes.ExtensionPoint) Verdict {
+		verdict := Verdict{Plugin: f.pluginName, ExtensionPoint: extensionPoint, Result: Accepted}
+		if reasons := f.reasons(pod); len(reasons) > 0 {
+			verdict.Result = Rejected
+			verdict.Reason = rejectionReason(reasons)
+		}
+		return verdict
+	}
+	for _, f := range d.filters {
+		verdicts = append(verdicts, filterVerdict(f, frameworktypes.FilterExtensionPoint))
+	}
+	for _, f := range d.preEvictionFilters {
+		verdicts = append(verdicts, filterVerdict(f, frameworktypes.PreEvictionFilterExtensionPoint))
+	}
+
+	pluginVerdict := func(pl frameworktypes.Plugin, extensionPoint frameworktypes.ExtensionPoint) Verdict {
+		verdict := Verdict{Plugin: pl.Name(), ExtensionPoint: extensionPoint, Result: Unexplained}
+		if explainer, ok := pl.(frameworktypes.ExplainPlugin); ok {
+			var selected bool
+			selected, verdict.Reason = explainer.Explain(pod, nodes)
+			verdict.Result = NotSelected
+			if selected {
+				verdict.Result = Selected
+			}
+		}
+		return verdict
+	}
+	for _, pl := range d.deschedulePlugins {
+		verdicts = append(verdicts, pluginVerdict(pl, frameworktypes.DescheduleExtensionPoint))
+	}
+	for _, pl := range d.balancePlugins {
+		verdicts = append(verdicts, pluginVerdict(pl, frameworktypes.BalanceExtensionPoint))
+	}
+	return verdicts
+}
+
+// completeStatus fills the pod counts of a plugin status from the counts of its evictor
+// accumulated since the plugin started. Plugins which do not report any specific code
+// get Skip when they did not try to evict any pod, and NoAction when no pod got evicted.
+func completeStatus(status *frameworktypes.Status, before, after evictionCounts) *frameworktypes.Status {
+	result := &frameworktypes.Status{}
+	if status != nil {
+		*result = *status
+	}
+	result.Code = result.GetCode()
+	result.PodsEvaluated = after.evaluated - before.evaluated
+	result.PodsEvicted = after.evicted - before.evicted
+	result.PodsSkipped = after.skipped - before.skipped
+	result.PodsQueued = after.queued - before.queued
+
+	if result.Code == frameworktypes.Success {
+		switch {
+		case result.PodsEvaluated == 0:
+			result.Code = frameworktypes.Skip
+			if result.Reason == "" {
+				result.Reason = "no pod to evict"
+			}
+		case result.PodsEvicted == 0 && result.PodsQueued == 0:
+			result.Code = frameworktypes.NoAction
+			if result.Reason == "" {
+				result.Reason = fmt.Sprintf("none of the %d evaluated pods could be evicted", result.PodsEvaluated)
+			}
 		}
-		klog.V(1).InfoS("Total number of pods evicted", "extension point", "Balance", "evictedPods", d.podEvictor.TotalEvicted()-evicted)
+	}
+	result.Reason = result.GetReason()
+	return result
+}
+
+// recordStatus reports the status of a plugin through logs, metrics and tracing
+func (d profileImpl) recordStatus(span trace.Span, pluginName string, extensionPoint frameworktypes.ExtensionPoint, status *frameworktypes.Status) {
+	span.SetAttributes(
+		attribute.String("code", status.Code.String()),
+		attribute.String("reason", status.Reason),
+		attribute.Int("podsEvaluated", int(status.PodsEvaluated)),
+		attribute.Int("podsEvicted", int(status.PodsEvicted)),
+		attribute.Int("podsSkipped", int(status.PodsSkipped)),
+		attribute.Int("podsQueued", int(status.PodsQueued)),
+	)
+	if status.Err != nil {
+		span.AddEvent("Plugin Execution Failed", trace.WithAttributes(attribute.String("err", status.Err.Error())))
 	}
 
-	aggrErr := errors.NewAggregate(errs)
-	if aggrErr == nil {
-		return &frameworktypes.Status{}
+	metrics.PluginStatus.With(map[string]string{"strategy": pluginName, "profile": d.profileName, "extension_point": string(extensionPoint), "code": status.Code.String()}).Inc()
+	metrics.PluginPods.With(map[string]string{"strategy": pluginName, "profile": d.profileName, "result": "evaluated"}).Add(float64(status.PodsEvaluated))
+	metrics.PluginPods.With(map[string]string{"strategy": pluginName, "profile": d.profileName, "result": "evicted"}).Add(float64(status.PodsEvicted))
+	metrics.PluginPods.With(map[string]string{"strategy": pluginName, "profile": d.profileName, "result": "skipped"}).Add(float64(status.PodsSkipped))
+	metrics.PluginPods.With(map[string]string{"strategy": pluginName, "profile": d.profileName, "result": "queued"}).Add(float64(status.PodsQueued))
+
+	klog.V(1).InfoS("Plugin finished", "plugin", pluginName, "profile", d.profileName, "extension point", extensionPoint, "code", status.Code, "reason", status.Reason, "evaluatedPods", status.PodsEvaluated, "evictedPods", status.PodsEvicted, "skippedPods", status.PodsSkipped, "queuedPods", status.PodsQueued)
+}
+
+// pluginStatus is the status of a single plugin run
+type pluginStatus struct {
+	pluginName string
+	status     *frameworktypes.Status
+}
+
+// codePrecedence orders the codes by relevance when merging statuses
+var codePrecedence = map[frameworktypes.Code]int{
+	frameworktypes.Skip:     0,
+	frameworktypes.NoAction: 1,
+	frameworktypes.Success:  2,
+	frameworktypes.Error:    3,
+}
+
+// mergeStatuses merges the statuses of all the plugins run within an extension point.
+// The merged code is the most relevant one in the following order: Error, Success, NoAction, Skip.
+func mergeStatuses(statuses []pluginStatus) *frameworktypes.Status {
+	merged := &frameworktypes.Status{Code: frameworktypes.Skip}
+	errs := []error{}
+	reasons := []string{}
+	for _, ps := range statuses {
+		merged.PodsEvaluated += ps.status.PodsEvaluated
+		merged.PodsEvicted += ps.status.PodsEvicted
+		merged.PodsSkipped += ps.status.PodsSkipped
+		merged.PodsQueued += ps.status.PodsQueued
+		if codePrecedence[ps.status.Code] > codePrecedence[merged.Code] {
+			merged.Code = ps.status.Code
+		}
+		if ps.status.Err != nil {
+			errs = append(errs, fmt.Errorf("plugin %q finished with error: %v", ps.pluginName, ps.status.Err))
+		}
+		if ps.status.Reason != "" {
+			reasons = append(reasons, fmt.Sprintf("%s: %s", ps.pluginName, ps.status.Reason))
+		}
 	}
 
-	return &frameworktypes.Status{
-		Err: fmt.Errorf("%v", aggrErr.Error()),
+	aggrErr := utilerrors.NewAggregate(errs)
+	if aggrErr != nil {
+		merged.Err = fmt.Errorf("%v", aggrErr.Error())
+		merged.Reason = merged.Err.Error()
+		return merged
 	}
+	merged.Reason = strings.Join(reasons, "; ")
+	return merged
 }
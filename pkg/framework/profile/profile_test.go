@@ -3,26 +3,35 @@ package profile
 import (
 	"context"
 	"fmt"
+	"net/http/httptest"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 
 	v1 "k8s.io/api/core/v1"
 	policy "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/informers"
 	fakeclientset "k8s.io/client-go/kubernetes/fake"
 	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/events"
+	metricstestutil "k8s.io/component-base/metrics/testutil"
 
+	"sigs.k8s.io/descheduler/metrics"
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/descheduler/report"
 	fakeplugin "sigs.k8s.io/descheduler/pkg/framework/fake/plugin"
 	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/podsorting"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/remoteplugin"
 	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
 	"sigs.k8s.io/descheduler/pkg/utils"
 	testutils "sigs.k8s.io/descheduler/test"
@@ -693,3 +702,937 @@ func TestProfileExtensionPointOrdering(t *testing.T) {
 		t.Errorf("check for balance invocation order failed. Results are not deep equal. mismatch (-want +got):\n%s", diff)
 	}
 }
+
+func TestProfileSortExtensionPoints(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	n1 := testutils.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := testutils.BuildTestNode("n2", 2000, 3000, 10, nil)
+	nodes := []*v1.Node{n1, n2}
+
+	p1 := testutils.BuildTestPod("p1", 200, 100, n1.Name, func(pod *v1.Pod) {
+		testutils.SetPodPriority(pod, 100)
+		testutils.MakeBestEffortPod(pod)
+	})
+	p2 := testutils.BuildTestPod("p2", 200, 100, n1.Name, func(pod *v1.Pod) {
+		testutils.SetPodPriority(pod, 10)
+		testutils.MakeGuaranteedPod(pod)
+	})
+	p3 := testutils.BuildTestPod("p3", 200, 100, n1.Name, func(pod *v1.Pod) {
+		testutils.SetPodPriority(pod, 10)
+		testutils.MakeBestEffortPod(pod)
+	})
+	p4 := testutils.BuildTestPod("p4", 200, 100, n1.Name, func(pod *v1.Pod) {
+		testutils.SetPodPriority(pod, 100)
+		testutils.MakeBurstablePod(pod)
+	})
+
+	tests := []struct {
+		name     string
+		plugins  api.Plugins
+		expected []string
+	}{
+		{
+			name:     "no sort plugins keep the original order",
+			plugins:  api.Plugins{},
+			expected: []string{"p1", "p2", "p3", "p4"},
+		},
+		{
+			name: "sort by priority, ties broken by QoS",
+			plugins: api.Plugins{
+				Sort: api.PluginSet{Enabled: []string{podsorting.PrioritySortPluginName, podsorting.QoSSortPluginName}},
+			},
+			expected: []string{"p3", "p2", "p1", "p4"},
+		},
+		{
+			name: "presort takes precedence over sort",
+			plugins: api.Plugins{
+				PreSort: api.PluginSet{Enabled: []string{podsorting.QoSSortPluginName}},
+				Sort:    api.PluginSet{Enabled: []string{podsorting.PrioritySortPluginName}},
+			},
+			expected: []string{"p3", "p1", "p4", "p2"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var sorted []string
+			fakePlugin := fakeplugin.FakePlugin{}
+			fakePlugin.AddReactor(string(frameworktypes.DescheduleExtensionPoint), func(action fakeplugin.Action) (handled, filter bool, err error) {
+				pods := []*v1.Pod{p1, p2, p3, p4}
+				action.Handle().Evictor().Sort(pods)
+				for _, pod := range pods {
+					sorted = append(sorted, pod.Name)
+				}
+				return true, false, nil
+			})
+
+			pluginregistry.PluginRegistry = pluginregistry.NewRegistry()
+			pluginregistry.Register(
+				"FakePlugin",
+				fakeplugin.NewPluginFncFromFake(&fakePlugin),
+				&fakeplugin.FakePlugin{},
+				&fakeplugin.FakePluginArgs{},
+				fakeplugin.ValidateFakePluginArgs,
+				fakeplugin.SetDefaults_FakePluginArgs,
+				pluginregistry.PluginRegistry,
+			)
+			pluginregistry.Register(podsorting.PrioritySortPluginName, podsorting.NewPrioritySort, &podsorting.PrioritySort{}, &podsorting.PrioritySortArgs{}, podsorting.ValidatePrioritySortArgs, podsorting.SetDefaults_PrioritySortArgs, pluginregistry.PluginRegistry)
+			pluginregistry.Register(podsorting.QoSSortPluginName, podsorting.NewQoSSort, &podsorting.QoSSort{}, &podsorting.QoSSortArgs{}, podsorting.ValidateQoSSortArgs, podsorting.SetDefaults_QoSSortArgs, pluginregistry.PluginRegistry)
+
+			client := fakeclientset.NewSimpleClientset(n1, n2)
+			sharedInformerFactory := informers.NewSharedInformerFactory(client, 0)
+			podInformer := sharedInformerFactory.Core().V1().Pods().Informer()
+			getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(podInformer)
+			if err != nil {
+				t.Fatalf("build get pods assigned to node function error: %v", err)
+			}
+
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			eventBroadcaster, eventRecorder := utils.GetRecorderAndBroadcaster(ctx, client)
+			defer eventBroadcaster.Shutdown()
+
+			podEvictor := evictions.NewPodEvictor(client, "policy/v1", false, nil, nil, nodes, true, eventRecorder)
+
+			plugins := test.plugins
+			plugins.Deschedule = api.PluginSet{Enabled: []string{"FakePlugin"}}
+			prfl, err := NewProfile(
+				api.DeschedulerProfile{
+					Name: "strategy-test-profile",
+					PluginConfigs: []api.PluginConfig{
+						{
+							Name: "FakePlugin",
+							Args: &fakeplugin.FakePluginArgs{},
+						},
+						{
+							Name: podsorting.PrioritySortPluginName,
+							Args: &podsorting.PrioritySortArgs{},
+						},
+						{
+							Name: podsorting.QoSSortPluginName,
+							Args: &podsorting.QoSSortArgs{},
+						},
+					},
+					Plugins: plugins,
+				},
+				pluginregistry.PluginRegistry,
+				WithClientSet(client),
+				WithSharedInformerFactory(sharedInformerFactory),
+				WithPodEvictor(podEvictor),
+				WithGetPodsAssignedToNodeFnc(getPodsAssignedToNode),
+			)
+			if err != nil {
+				t.Fatalf("unable to create profile: %v", err)
+			}
+
+			prfl.RunDeschedulePlugins(ctx, nodes)
+
+			if diff := cmp.Diff(test.expected, sorted); diff != "" {
+				t.Errorf("unexpected pod order (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProfileStatusCodes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	n1 := testutils.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := testutils.BuildTestNode("n2", 2000, 3000, 10, nil)
+	nodes := []*v1.Node{n1, n2}
+
+	p1 := testutils.BuildTestPod("p1", 200, 0, n1.Name, testutils.SetRSOwnerRef)
+
+	tests := []struct {
+		name                  string
+		evict                 bool
+		err                   error
+		maxPodsToEvictPerNode *uint
+		expected              *frameworktypes.Status
+	}{
+		{
+			name:     "nothing to do",
+			expected: &frameworktypes.Status{Code: frameworktypes.Skip, Reason: "FakePlugin: no pod to evict"},
+		},
+		{
+			name:     "pod evicted",
+			evict:    true,
+			expected: &frameworktypes.Status{Code: frameworktypes.Success, PodsEvaluated: 1, PodsEvicted: 1},
+		},
+		{
+			name:                  "eviction blocked by the node limit",
+			evict:                 true,
+			maxPodsToEvictPerNode: func(i uint) *uint { return &i }(0),
+			expected:              &frameworktypes.Status{Code: frameworktypes.NoAction, Reason: "FakePlugin: none of the 1 evaluated pods could be evicted", PodsEvaluated: 1, PodsSkipped: 1},
+		},
+		{
+			name:     "plugin error",
+			err:      fmt.Errorf("failure"),
+			expected: &frameworktypes.Status{Code: frameworktypes.Error, Err: fmt.Errorf("plugin \"FakePlugin\" finished with error: failure"), Reason: "plugin \"FakePlugin\" finished with error: failure"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fakePlugin := fakeplugin.FakePlugin{PluginName: "FakePlugin"}
+			fakePlugin.AddReactor(string(frameworktypes.DescheduleExtensionPoint), func(action fakeplugin.Action) (handled, filter bool, err error) {
+				if test.evict {
+					action.Handle().Evictor().Evict(action.(fakeplugin.DescheduleAction).Context(), p1, evictions.EvictOptions{})
+				}
+				return true, false, test.err
+			})
+
+			pluginregistry.PluginRegistry = pluginregistry.NewRegistry()
+			pluginregistry.Register(
+				"FakePlugin",
+				fakeplugin.NewPluginFncFromFake(&fakePlugin),
+				&fakeplugin.FakePlugin{},
+				&fakeplugin.FakePluginArgs{},
+				fakeplugin.ValidateFakePluginArgs,
+				fakeplugin.SetDefaults_FakePluginArgs,
+				pluginregistry.PluginRegistry,
+			)
+
+			client := fakeclientset.NewSimpleClientset(n1, n2, p1)
+			sharedInformerFactory := informers.NewSharedInformerFactory(client, 0)
+			podInformer := sharedInformerFactory.Core().V1().Pods().Informer()
+			getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(podInformer)
+			if err != nil {
+				t.Fatalf("build get pods assigned to node function error: %v", err)
+			}
+
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			eventBroadcaster, eventRecorder := utils.GetRecorderAndBroadcaster(ctx, client)
+			defer eventBroadcaster.Shutdown()
+
+			podEvictor := evictions.NewPodEvictor(client, "policy/v1", false, test.maxPodsToEvictPerNode, nil, nodes, true, eventRecorder)
+
+			prfl, err := NewProfile(
+				api.DeschedulerProfile{
+					Name: "strategy-test-profile",
+					PluginConfigs: []api.PluginConfig{
+						{
+							Name: "FakePlugin",
+							Args: &fakeplugin.FakePluginArgs{},
+						},
+					},
+					Plugins: api.Plugins{
+						Deschedule: api.PluginSet{Enabled: []string{"FakePlugin"}},
+					},
+				},
+				pluginregistry.PluginRegistry,
+				WithClientSet(client),
+				WithSharedInformerFactory(sharedInformerFactory),
+				WithPodEvictor(podEvictor),
+				WithGetPodsAssignedToNodeFnc(getPodsAssignedToNode),
+			)
+			if err != nil {
+				t.Fatalf("unable to create profile: %v", err)
+			}
+
+			status := prfl.RunDeschedulePlugins(ctx, nodes)
+			if diff := cmp.Diff(test.expected, status, cmp.Comparer(func(a, b error) bool {
+				return a == nil && b == nil || a != nil && b != nil && a.Error() == b.Error()
+			})); diff != "" {
+				t.Errorf("unexpected status (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProfileEvictionIdentity(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	n1 := testutils.BuildTestNode("n1", 2000, 3000, 10, nil)
+	nodes := []*v1.Node{n1}
+
+	p1 := testutils.BuildTestPod("p1", 200, 0, n1.Name, testutils.SetRSOwnerRef)
+	p2 := testutils.BuildTestPod("p2", 200, 0, n1.Name, testutils.SetRSOwnerRef)
+
+	fakePlugin := fakeplugin.FakePlugin{PluginName: "FakePlugin"}
+	fakePlugin.AddReactor(string(frameworktypes.DescheduleExtensionPoint), func(action fakeplugin.Action) (handled, filter bool, err error) {
+		action.Handle().Evictor().Evict(action.(fakeplugin.DescheduleAction).Context(), p1, evictions.EvictOptions{})
+		return true, false, nil
+	})
+	fakePlugin.AddReactor(string(frameworktypes.BalanceExtensionPoint), func(action fakeplugin.Action) (handled, filter bool, err error) {
+		action.Handle().Evictor().Evict(action.(fakeplugin.BalanceAction).Context(), p2, evictions.EvictOptions{Reason: "Unbalanced"})
+		return true, false, nil
+	})
+
+	pluginregistry.PluginRegistry = pluginregistry.NewRegistry()
+	pluginregistry.Register(
+		"FakePlugin",
+		fakeplugin.NewPluginFncFromFake(&fakePlugin),
+		&fakeplugin.FakePlugin{},
+		&fakeplugin.FakePluginArgs{},
+		fakeplugin.ValidateFakePluginArgs,
+		fakeplugin.SetDefaults_FakePluginArgs,
+		pluginregistry.PluginRegistry,
+	)
+
+	client := fakeclientset.NewSimpleClientset(n1, p1, p2)
+	sharedInformerFactory := informers.NewSharedInformerFactory(client, 0)
+	podInformer := sharedInformerFactory.Core().V1().Pods().Informer()
+	getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(podInformer)
+	if err != nil {
+		t.Fatalf("build get pods assigned to node function error: %v", err)
+	}
+
+	sharedInformerFactory.Start(ctx.Done())
+	sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+	eventRecorder := events.NewFakeRecorder(10)
+	podEvictor := evictions.NewPodEvictor(client, "policy/v1", false, nil, nil, nodes, false, eventRecorder)
+
+	prfl, err := NewProfile(
+		api.DeschedulerProfile{
+			Name: "identity-profile",
+			PluginConfigs: []api.PluginConfig{
+				{
+					Name: "FakePlugin",
+					Args: &fakeplugin.FakePluginArgs{},
+				},
+			},
+			Plugins: api.Plugins{
+				Deschedule: api.PluginSet{Enabled: []string{"FakePlugin"}},
+				Balance:    api.PluginSet{Enabled: []string{"FakePlugin"}},
+			},
+		},
+		pluginregistry.PluginRegistry,
+		WithClientSet(client),
+		WithSharedInformerFactory(sharedInformerFactory),
+		WithPodEvictor(podEvictor),
+		WithGetPodsAssignedToNodeFnc(getPodsAssignedToNode),
+	)
+	if err != nil {
+		t.Fatalf("unable to create profile: %v", err)
+	}
+
+	prfl.RunDeschedulePlugins(ctx, nodes)
+	prfl.RunBalancePlugins(ctx, nodes)
+	close(eventRecorder.Events)
+
+	got := []string{}
+	for event := range eventRecorder.Events {
+		got = append(got, event)
+	}
+	expected := []string{
+		"Normal FakePlugin pod evicted from n1 node by sigs.k8s.io/descheduler (profile identity-profile, plugin FakePlugin, extension point Deschedule)",
+		"Normal Unbalanced pod evicted from n1 node by sigs.k8s.io/descheduler (profile identity-profile, plugin FakePlugin, extension point Balance)",
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("unexpected events (-want +got):\n%s", diff)
+	}
+}
+
+// evictHooksPlugin is a PreEvict and PostEvict plugin calling the configured functions
+type evictHooksPlugin struct {
+	preEvict  func(pod *v1.Pod, opts *evictions.EvictOptions) *frameworktypes.Status
+	postEvict func(pod *v1.Pod, opts evictions.EvictOptions, evicted bool)
+}
+
+func (p *evictHooksPlugin) Name() string {
+	return "EvictHooks"
+}
+
+func (p *evictHooksPlugin) PreEvict(ctx context.Context, pod *v1.Pod, opts *evictions.EvictOptions) *frameworktypes.Status {
+	return p.preEvict(pod, opts)
+}
+
+func (p *evictHooksPlugin) PostEvict(ctx context.Context, pod *v1.Pod, opts evictions.EvictOptions, evicted bool) {
+	p.postEvict(pod, opts, evicted)
+}
+
+func TestProfilePreEvictPostEvictExtensionPoints(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	n1 := testutils.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := testutils.BuildTestNode("n2", 2000, 3000, 10, nil)
+	nodes := []*v1.Node{n1, n2}
+
+	p1 := testutils.BuildTestPod("p1", 200, 0, n1.Name, testutils.SetRSOwnerRef)
+	gracePeriod := int64(5)
+	pluginGracePeriod := int64(10)
+
+	tests := []struct {
+		name              string
+		pluginGracePeriod *int64
+		preEvict          func(pod *v1.Pod, opts *evictions.EvictOptions) *frameworktypes.Status
+		evictionErr       error
+		retryDeadline     time.Duration
+		expectedEviction  *policy.Eviction
+		expectedPostEvict []string
+		expectedCode      frameworktypes.Code
+		expectedQueued    uint
+	}{
+		{
+			name: "PreEvict mutates the eviction",
+			preEvict: func(pod *v1.Pod, opts *evictions.EvictOptions) *frameworktypes.Status {
+				opts.GracePeriodSeconds = &gracePeriod
+				opts.Annotations = map[string]string{"evicted-by": opts.PluginName}
+				return nil
+			},
+			expectedEviction: &policy.Eviction{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        p1.Name,
+					Namespace:   p1.Namespace,
+					Annotations: map[string]string{"evicted-by": "FakePlugin"},
+				},
+				DeleteOptions: &metav1.DeleteOptions{GracePeriodSeconds: &gracePeriod},
+			},
+			expectedPostEvict: []string{"p1 evicted from profile test-profile by FakePlugin: true"},
+			expectedCode:      frameworktypes.Success,
+		},
+		{
+			name:              "eviction with the grace period of the plugin",
+			pluginGracePeriod: &pluginGracePeriod,
+			preEvict: func(pod *v1.Pod, opts *evictions.EvictOptions) *frameworktypes.Status {
+				return nil
+			},
+			expectedEviction: &policy.Eviction{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      p1.Name,
+					Namespace: p1.Namespace,
+				},
+				DeleteOptions: &metav1.DeleteOptions{GracePeriodSeconds: &pluginGracePeriod},
+			},
+			expectedPostEvict: []string{"p1 evicted from profile test-profile by FakePlugin: true"},
+			expectedCode:      frameworktypes.Success,
+		},
+		{
+			name:              "PreEvict overrides the grace period of the plugin",
+			pluginGracePeriod: &pluginGracePeriod,
+			preEvict: func(pod *v1.Pod, opts *evictions.EvictOptions) *frameworktypes.Status {
+				opts.GracePeriodSeconds = &gracePeriod
+				return nil
+			},
+			expectedEviction: &policy.Eviction{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      p1.Name,
+					Namespace: p1.Namespace,
+				},
+				DeleteOptions: &metav1.DeleteOptions{GracePeriodSeconds: &gracePeriod},
+			},
+			expectedPostEvict: []string{"p1 evicted from profile test-profile by FakePlugin: true"},
+			expectedCode:      frameworktypes.Success,
+		},
+		{
+			name: "PreEvict vetoes the eviction",
+			preEvict: func(pod *v1.Pod, opts *evictions.EvictOptions) *frameworktypes.Status {
+				return frameworktypes.NewStatus(frameworktypes.Skip, "vetoed")
+			},
+			expectedPostEvict: []string{},
+			expectedCode:      frameworktypes.NoAction,
+		},
+		{
+			name: "PostEvict is informed about failed evictions",
+			preEvict: func(pod *v1.Pod, opts *evictions.EvictOptions) *frameworktypes.Status {
+				return nil
+			},
+			evictionErr: fmt.Errorf("eviction failed"),
+			expectedEviction: &policy.Eviction{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      p1.Name,
+					Namespace: p1.Namespace,
+				},
+				DeleteOptions: &metav1.DeleteOptions{},
+			},
+			expectedPostEvict: []string{"p1 evicted from profile test-profile by FakePlugin: false"},
+			expectedCode:      frameworktypes.NoAction,
+		},
+		{
+			name: "PostEvict is informed about queued evictions once retried",
+			preEvict: func(pod *v1.Pod, opts *evictions.EvictOptions) *frameworktypes.Status {
+				return nil
+			},
+			evictionErr:   apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0),
+			retryDeadline: 10 * time.Millisecond,
+			expectedEviction: &policy.Eviction{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      p1.Name,
+					Namespace: p1.Namespace,
+				},
+				DeleteOptions: &metav1.DeleteOptions{},
+			},
+			expectedPostEvict: []string{"p1 evicted from profile test-profile by FakePlugin: false"},
+			expectedCode:      frameworktypes.Success,
+			expectedQueued:    1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fakePlugin := fakeplugin.FakePlugin{PluginName: "FakePlugin"}
+			fakePlugin.AddReactor(string(frameworktypes.DescheduleExtensionPoint), func(action fakeplugin.Action) (handled, filter bool, err error) {
+				action.Handle().Evictor().Evict(action.(fakeplugin.DescheduleAction).Context(), p1, evictions.EvictOptions{})
+				return true, false, nil
+			})
+
+			postEvict := []string{}
+			hooksPlugin := &evictHooksPlugin{
+				preEvict: test.preEvict,
+				postEvict: func(pod *v1.Pod, opts evictions.EvictOptions, evicted bool) {
+					postEvict = append(postEvict, fmt.Sprintf("%v evicted from profile %v by %v: %v", pod.Name, opts.ProfileName, opts.PluginName, evicted))
+				},
+			}
+
+			pluginregistry.PluginRegistry = pluginregistry.NewRegistry()
+			pluginregistry.Register(
+				"FakePlugin",
+				fakeplugin.NewPluginFncFromFake(&fakePlugin),
+				&fakeplugin.FakePlugin{},
+				&fakeplugin.FakePluginArgs{},
+				fakeplugin.ValidateFakePluginArgs,
+				fakeplugin.SetDefaults_FakePluginArgs,
+				pluginregistry.PluginRegistry,
+			)
+			pluginregistry.Register(
+				"EvictHooks",
+				func(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+					return hooksPlugin, nil
+				},
+				&evictHooksPlugin{},
+				&fakeplugin.FakePluginArgs{},
+				fakeplugin.ValidateFakePluginArgs,
+				fakeplugin.SetDefaults_FakePluginArgs,
+				pluginregistry.PluginRegistry,
+			)
+
+			client := fakeclientset.NewSimpleClientset(n1, n2, p1)
+			var eviction *policy.Eviction
+			client.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() == "eviction" {
+					eviction = action.(core.CreateAction).GetObject().(*policy.Eviction)
+					eviction.TypeMeta = metav1.TypeMeta{}
+					return true, nil, test.evictionErr
+				}
+				return false, nil, nil
+			})
+			sharedInformerFactory := informers.NewSharedInformerFactory(client, 0)
+			podInformer := sharedInformerFactory.Core().V1().Pods().Informer()
+			getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(podInformer)
+			if err != nil {
+				t.Fatalf("build get pods assigned to node function error: %v", err)
+			}
+
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			podEvictor := evictions.NewPodEvictor(client, "policy/v1", false, nil, nil, nodes, false, &events.FakeRecorder{})
+			podEvictor.SetRetry(time.Millisecond, test.retryDeadline)
+
+			prfl, err := NewProfile(
+				api.DeschedulerProfile{
+					Name: "test-profile",
+					PluginConfigs: []api.PluginConfig{
+						{
+							Name:               "FakePlugin",
+							Args:               &fakeplugin.FakePluginArgs{},
+							GracePeriodSeconds: test.pluginGracePeriod,
+						},
+						{
+							Name: "EvictHooks",
+							Args: &fakeplugin.FakePluginArgs{},
+						},
+					},
+					Plugins: api.Plugins{
+						Deschedule: api.PluginSet{Enabled: []string{"FakePlugin"}},
+						PreEvict:   api.PluginSet{Enabled: []string{"EvictHooks"}},
+						PostEvict:  api.PluginSet{Enabled: []string{"EvictHooks"}},
+					},
+				},
+				pluginregistry.PluginRegistry,
+				WithClientSet(client),
+				WithSharedInformerFactory(sharedInformerFactory),
+				WithPodEvictor(podEvictor),
+				WithGetPodsAssignedToNodeFnc(getPodsAssignedToNode),
+			)
+			if err != nil {
+				t.Fatalf("unable to create profile: %v", err)
+			}
+
+			status := prfl.RunDeschedulePlugins(ctx, nodes)
+			if status.GetCode() != test.expectedCode {
+				t.Errorf("expected %v status code, got %v", test.expectedCode, status.GetCode())
+			}
+			if status.PodsQueued != test.expectedQueued {
+				t.Errorf("expected %v queued pods, got %v", test.expectedQueued, status.PodsQueued)
+			}
+			if diff := cmp.Diff(test.expectedEviction, eviction); diff != "" {
+				t.Errorf("unexpected eviction (-want +got):\n%s", diff)
+			}
+			if test.expectedQueued > 0 {
+				if len(postEvict) != 0 {
+					t.Errorf("expected PostEvict to wait for the retries, got %v", postEvict)
+				}
+				podEvictor.RetryQueued(ctx)
+			}
+			if diff := cmp.Diff(test.expectedPostEvict, postEvict); diff != "" {
+				t.Errorf("unexpected PostEvict calls (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProfileExplain(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	n1 := testutils.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := testutils.BuildTestNode("n2", 2000, 3000, 10, nil)
+	nodes := []*v1.Node{n1, n2}
+
+	owned := testutils.BuildTestPod("owned", 200, 0, n1.Name, testutils.SetRSOwnerRef)
+	bare := testutils.BuildTestPod("bare", 200, 0, n1.Name, nil)
+
+	pluginregistry.PluginRegistry = pluginregistry.NewRegistry()
+	fakePlugin := &fakeplugin.FakePlugin{PluginName: "FakePlugin"}
+	pluginregistry.Register(
+		"FakePlugin",
+		fakeplugin.NewPluginFncFromFake(fakePlugin),
+		&fakeplugin.FakePlugin{},
+		&fakeplugin.FakePluginArgs{},
+		fakeplugin.ValidateFakePluginArgs,
+		fakeplugin.SetDefaults_FakePluginArgs,
+		pluginregistry.PluginRegistry,
+	)
+	pluginregistry.Register(
+		defaultevictor.PluginName,
+		defaultevictor.New,
+		&defaultevictor.DefaultEvictor{},
+		&defaultevictor.DefaultEvictorArgs{},
+		defaultevictor.ValidateDefaultEvictorArgs,
+		defaultevictor.SetDefaults_DefaultEvictorArgs,
+		pluginregistry.PluginRegistry,
+	)
+
+	client := fakeclientset.NewSimpleClientset(n1, n2, owned, bare)
+	sharedInformerFactory := informers.NewSharedInformerFactory(client, 0)
+	podInformer := sharedInformerFactory.Core().V1().Pods().Informer()
+	getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(podInformer)
+	if err != nil {
+		t.Fatalf("build get pods assigned to node function error: %v", err)
+	}
+
+	sharedInformerFactory.Start(ctx.Done())
+	sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+	podEvictor := evictions.NewPodEvictor(client, "policy/v1", true, nil, nil, nodes, false, &events.FakeRecorder{})
+
+	prfl, err := NewProfile(
+		api.DeschedulerProfile{
+			Name: "strategy-test-profile",
+			PluginConfigs: []api.PluginConfig{
+				{
+					Name: defaultevictor.PluginName,
+					Args: &defaultevictor.DefaultEvictorArgs{},
+				},
+				{
+					Name: "FakePlugin",
+					Args: &fakeplugin.FakePluginArgs{},
+				},
+			},
+			Plugins: api.Plugins{
+				Deschedule:        api.PluginSet{Enabled: []string{"FakePlugin"}},
+				Filter:            api.PluginSet{Enabled: []string{defaultevictor.PluginName}},
+				PreEvictionFilter: api.PluginSet{Enabled: []string{defaultevictor.PluginName}},
+			},
+		},
+		pluginregistry.PluginRegistry,
+		WithClientSet(client),
+		WithSharedInformerFactory(sharedInformerFactory),
+		WithPodEvictor(podEvictor),
+		WithGetPodsAssignedToNodeFnc(getPodsAssignedToNode),
+	)
+	if err != nil {
+		t.Fatalf("unable to create profile: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		pod      *v1.Pod
+		expected []Verdict
+	}{
+		{
+			name: "pod accepted by the filters",
+			pod:  owned,
+			expected: []Verdict{
+				{Plugin: defaultevictor.PluginName, ExtensionPoint: frameworktypes.FilterExtensionPoint, Result: Accepted},
+				{Plugin: defaultevictor.PluginName, ExtensionPoint: frameworktypes.PreEvictionFilterExtensionPoint, Result: Accepted},
+				{Plugin: "FakePlugin", ExtensionPoint: frameworktypes.DescheduleExtensionPoint, Result: Unexplained},
+			},
+		},
+		{
+			name: "pod rejected by the filter",
+			pod:  bare,
+			expected: []Verdict{
+				{Plugin: defaultevictor.PluginName, ExtensionPoint: frameworktypes.FilterExtensionPoint, Result: Rejected, Reason: "pod does not have any ownerRefs"},
+				{Plugin: defaultevictor.PluginName, ExtensionPoint: frameworktypes.PreEvictionFilterExtensionPoint, Result: Accepted},
+				{Plugin: "FakePlugin", ExtensionPoint: frameworktypes.DescheduleExtensionPoint, Result: Unexplained},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if diff := cmp.Diff(test.expected, prfl.Explain(test.pod, nodes)); diff != "" {
+				t.Errorf("unexpected verdicts (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// boolFilterPlugin is a Filter plugin not telling why it rejects a pod
+type boolFilterPlugin struct {
+	rejected string
+}
+
+func (p *boolFilterPlugin) Name() string {
+	return "BoolFilter"
+}
+
+func (p *boolFilterPlugin) Filter(pod *v1.Pod) bool {
+	return pod.Name != p.rejected
+}
+
+func (p *boolFilterPlugin) PreEvictionFilter(pod *v1.Pod) bool {
+	return true
+}
+
+func TestProfileFilterRejectionReasons(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	metrics.Register()
+
+	n1 := testutils.BuildTestNode("n1", 2000, 3000, 10, nil)
+	nodes := []*v1.Node{n1}
+
+	owned := testutils.BuildTestPod("owned", 200, 0, n1.Name, testutils.SetRSOwnerRef)
+	bare := testutils.BuildTestPod("bare", 200, 0, n1.Name, nil)
+	vetoed := testutils.BuildTestPod("vetoed", 200, 0, n1.Name, testutils.SetRSOwnerRef)
+
+	fakePlugin := fakeplugin.FakePlugin{PluginName: "FakePlugin"}
+	fakePlugin.AddReactor(string(frameworktypes.DescheduleExtensionPoint), func(action fakeplugin.Action) (handled, filter bool, err error) {
+		// Checking the pods twice still reports every rejection once
+		for i := 0; i < 2; i++ {
+			for _, pod := range []*v1.Pod{owned, bare, vetoed} {
+				action.Handle().Evictor().Filter(pod)
+			}
+		}
+		return true, false, nil
+	})
+
+	pluginregistry.PluginRegistry = pluginregistry.NewRegistry()
+	pluginregistry.Register(
+		"FakePlugin",
+		fakeplugin.NewPluginFncFromFake(&fakePlugin),
+		&fakeplugin.FakePlugin{},
+		&fakeplugin.FakePluginArgs{},
+		fakeplugin.ValidateFakePluginArgs,
+		fakeplugin.SetDefaults_FakePluginArgs,
+		pluginregistry.PluginRegistry,
+	)
+	pluginregistry.Register(
+		defaultevictor.PluginName,
+		defaultevictor.New,
+		&defaultevictor.DefaultEvictor{},
+		&defaultevictor.DefaultEvictorArgs{},
+		defaultevictor.ValidateDefaultEvictorArgs,
+		defaultevictor.SetDefaults_DefaultEvictorArgs,
+		pluginregistry.PluginRegistry,
+	)
+	pluginregistry.Register(
+		"BoolFilter",
+		func(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+			return &boolFilterPlugin{rejected: vetoed.Name}, nil
+		},
+		&boolFilterPlugin{},
+		&fakeplugin.FakePluginArgs{},
+		fakeplugin.ValidateFakePluginArgs,
+		fakeplugin.SetDefaults_FakePluginArgs,
+		pluginregistry.PluginRegistry,
+	)
+
+	client := fakeclientset.NewSimpleClientset(n1, owned, bare, vetoed)
+	sharedInformerFactory := informers.NewSharedInformerFactory(client, 0)
+	podInformer := sharedInformerFactory.Core().V1().Pods().Informer()
+	getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(podInformer)
+	if err != nil {
+		t.Fatalf("build get pods assigned to node function error: %v", err)
+	}
+
+	sharedInformerFactory.Start(ctx.Done())
+	sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+	podEvictor := evictions.NewPodEvictor(client, "policy/v1", true, nil, nil, nodes, false, &events.FakeRecorder{})
+	recorder := report.NewRecorder()
+
+	prfl, err := NewProfile(
+		api.DeschedulerProfile{
+			Name: "reasons-profile",
+			PluginConfigs: []api.PluginConfig{
+				{
+					Name: defaultevictor.PluginName,
+					Args: &defaultevictor.DefaultEvictorArgs{},
+				},
+				{
+					Name: "FakePlugin",
+					Args: &fakeplugin.FakePluginArgs{},
+				},
+				{
+					Name: "BoolFilter",
+					Args: &fakeplugin.FakePluginArgs{},
+				},
+			},
+			Plugins: api.Plugins{
+				Deschedule: api.PluginSet{Enabled: []string{"FakePlugin"}},
+				Filter:     api.PluginSet{Enabled: []string{defaultevictor.PluginName, "BoolFilter"}},
+			},
+		},
+		pluginregistry.PluginRegistry,
+		WithClientSet(client),
+		WithSharedInformerFactory(sharedInformerFactory),
+		WithPodEvictor(podEvictor),
+		WithGetPodsAssignedToNodeFnc(getPodsAssignedToNode),
+		WithReportRecorder(recorder),
+	)
+	if err != nil {
+		t.Fatalf("unable to create profile: %v", err)
+	}
+
+	prfl.RunDeschedulePlugins(ctx, nodes)
+
+	expected := []report.Decision{
+		{
+			Pod:            "default/bare",
+			Node:           n1.Name,
+			Profile:        "reasons-profile",
+			Plugin:         "FakePlugin",
+			ExtensionPoint: string(frameworktypes.DescheduleExtensionPoint),
+			Result:         report.Rejected,
+			RejectedBy:     defaultevictor.PluginName,
+			RejectedAt:     string(frameworktypes.FilterExtensionPoint),
+			Reason:         "pod does not have any ownerRefs",
+		},
+		{
+			Pod:            "default/vetoed",
+			Node:           n1.Name,
+			Profile:        "reasons-profile",
+			Plugin:         "FakePlugin",
+			ExtensionPoint: string(frameworktypes.DescheduleExtensionPoint),
+			Result:         report.Rejected,
+			RejectedBy:     "BoolFilter",
+			RejectedAt:     string(frameworktypes.FilterExtensionPoint),
+		},
+	}
+	if diff := cmp.Diff(expected, recorder.Decisions()); diff != "" {
+		t.Errorf("unexpected decisions (-want +got):\n%s", diff)
+	}
+
+	for _, labels := range []map[string]string{
+		{"reason": "pod does not have any ownerRefs", "plugin": defaultevictor.PluginName, "extension_point": string(frameworktypes.FilterExtensionPoint), "strategy": "FakePlugin", "profile": "reasons-profile"},
+		{"reason": "unknown", "plugin": "BoolFilter", "extension_point": string(frameworktypes.FilterExtensionPoint), "strategy": "FakePlugin", "profile": "reasons-profile"},
+	} {
+		value, err := metricstestutil.GetCounterMetricValue(metrics.PodsFiltered.With(labels))
+		if err != nil {
+			t.Fatalf("unable to read the pods_filtered metric: %v", err)
+		}
+		if value != 1 {
+			t.Errorf("expected the pods_filtered metric with labels %v to be 1, got %v", labels, value)
+		}
+	}
+}
+
+func TestProfileNamedPluginInstances(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	n1 := testutils.BuildTestNode("n1", 2000, 3000, 10, nil)
+	nodes := []*v1.Node{n1}
+	p1 := testutils.BuildTestPod("p1", 200, 0, n1.Name, testutils.SetRSOwnerRef)
+	p2 := testutils.BuildTestPod("p2", 200, 0, n1.Name, testutils.SetRSOwnerRef)
+
+	remotePlugin := func(candidate string) *httptest.Server {
+		return httptest.NewServer(&remoteplugin.Server{
+			Deschedule: func(ctx context.Context, request *remoteplugin.EvictionRequest) (*remoteplugin.EvictionResponse, error) {
+				return &remoteplugin.EvictionResponse{Candidates: []remoteplugin.EvictionCandidate{{Namespace: "default", Name: candidate}}}, nil
+			},
+		})
+	}
+	teamA, teamB := remotePlugin(p1.Name), remotePlugin(p2.Name)
+	defer teamA.Close()
+	defer teamB.Close()
+
+	pluginregistry.PluginRegistry = pluginregistry.NewRegistry()
+	pluginregistry.Register(
+		remoteplugin.PluginName,
+		remoteplugin.New,
+		&remoteplugin.RemotePlugin{},
+		&remoteplugin.RemotePluginArgs{},
+		remoteplugin.ValidateRemotePluginArgs,
+		remoteplugin.SetDefaults_RemotePluginArgs,
+		pluginregistry.PluginRegistry,
+	)
+
+	client := fakeclientset.NewSimpleClientset(n1, p1, p2)
+	sharedInformerFactory := informers.NewSharedInformerFactory(client, 0)
+	podInformer := sharedInformerFactory.Core().V1().Pods().Informer()
+	getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(podInformer)
+	if err != nil {
+		t.Fatalf("build get pods assigned to node function error: %v", err)
+	}
+
+	sharedInformerFactory.Start(ctx.Done())
+	sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+	podEvictor := evictions.NewPodEvictor(client, "policy/v1", true, nil, nil, nodes, false, &events.FakeRecorder{})
+
+	prfl, err := NewProfile(
+		api.DeschedulerProfile{
+			Name: "teams-profile",
+			PluginConfigs: []api.PluginConfig{
+				{
+					Name: remoteplugin.PluginName,
+					Args: &remoteplugin.RemotePluginArgs{Name: "team-a", Endpoint: teamA.URL},
+				},
+				{
+					Name: remoteplugin.PluginName,
+					Args: &remoteplugin.RemotePluginArgs{Name: "team-b", Endpoint: teamB.URL},
+				},
+			},
+			Plugins: api.Plugins{
+				Deschedule: api.PluginSet{Enabled: []string{"team-a", "team-b"}},
+			},
+		},
+		pluginregistry.PluginRegistry,
+		WithClientSet(client),
+		WithSharedInformerFactory(sharedInformerFactory),
+		WithPodEvictor(podEvictor),
+		WithGetPodsAssignedToNodeFnc(getPodsAssignedToNode),
+		WithContext(ctx),
+	)
+	if err != nil {
+		t.Fatalf("unable to create profile: %v", err)
+	}
+
+	var pluginNames []string
+	for _, pl := range prfl.deschedulePlugins {
+		pluginNames = append(pluginNames, pl.Name())
+	}
+	if diff := cmp.Diff([]string{"team-a", "team-b"}, pluginNames); diff != "" {
+		t.Errorf("unexpected plugin instances (-want +got):\n%s", diff)
+	}
+
+	prfl.RunDeschedulePlugins(ctx, nodes)
+	if evicted := podEvictor.TotalEvicted(); evicted != 2 {
+		t.Errorf("expected the candidates of both instances to be evicted, got %d evictions", evicted)
+	}
+}
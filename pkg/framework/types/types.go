@@ -18,6 +18,7 @@ package types
 
 import (
 	"context"
+	"fmt"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/informers"
@@ -25,6 +26,7 @@ import (
 
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/framework/parallelize"
 )
 
 // Handle provides handles used by plugins to retrieve a kubernetes client set,
@@ -38,6 +40,31 @@ type Handle interface {
 	SharedInformerFactory() informers.SharedInformerFactory
 }
 
+// ParallelizerHandle is implemented by the handles able to run the per-node work of plugins concurrently.
+type ParallelizerHandle interface {
+	Handle
+	// Parallelizer returns a parallelizer for running per-node work concurrently.
+	Parallelizer() parallelize.Parallelizer
+}
+
+// GetParallelizer returns the parallelizer of a handle implementing ParallelizerHandle,
+// or one processing the work sequentially otherwise
+func GetParallelizer(handle Handle) parallelize.Parallelizer {
+	if parallelizerHandle, ok := handle.(ParallelizerHandle); ok {
+		return parallelizerHandle.Parallelizer()
+	}
+	return parallelize.NewParallelizer(parallelize.DefaultParallelism)
+}
+
+// ContextHandle is implemented by the handles of the profiles run within a descheduling loop.
+// Plugins doing work outside of the extension points given a context, e.g. calling a remote
+// service from Filter, bind it to the loop through the context it provides.
+type ContextHandle interface {
+	Handle
+	// Context returns the context of the descheduling loop the profile runs within
+	Context() context.Context
+}
+
 // Evictor defines an interface for filtering and evicting pods
 // while abstracting away the specific pod evictor/evictor filter.
 type Evictor interface {
@@ -49,11 +76,108 @@ type Evictor interface {
 	Evict(context.Context, *v1.Pod, evictions.EvictOptions) bool
 	// NodeLimitExceeded checks if the number of evictions for a node was exceeded
 	NodeLimitExceeded(node *v1.Node) bool
+	// Sort sorts pods in place so the pods to be evicted first come first.
+	// The order is given by the PreSort and Sort plugins. Pods the plugins
+	// consider equal keep their original order.
+	Sort(pods []*v1.Pod)
+	// SortReversed sorts pods in place so the pods to be evicted first come last,
+	// for the callers evicting from the back of the list. Pods the plugins
+	// consider equal keep their original order.
+	SortReversed(pods []*v1.Pod)
+}
+
+// Code is the status code of an extension point invocation
+type Code int
+
+// These are predefined codes used in a Status.
+const (
+	// Success means that the plugin ran correctly and evicted pods.
+	Success Code = iota
+	// Error is used for internal plugin errors, unexpected input, etc.
+	Error
+	// Skip means the plugin had nothing to do, e.g. no pod was found to be evicted.
+	Skip
+	// NoAction means the plugin ran and found pods to evict, but none of them
+	// could be evicted, e.g. because every eviction was blocked by a filter or a limit.
+	NoAction
+	// Rejected means a Filter or PreEvictionFilter plugin rejected the pod.
+	Rejected
+)
+
+var codes = []string{"Success", "Error", "Skip", "NoAction", "Rejected"}
+
+func (c Code) String() string {
+	if int(c) >= 0 && int(c) < len(codes) {
+		return codes[c]
+	}
+	return fmt.Sprintf("Code(%d)", int(c))
 }
 
 // Status describes result of an extension point invocation
 type Status struct {
-	Err error
+	// Code of the invocation. A non-nil Err always means Error.
+	Code Code
+	Err  error
+	// Reason is a human readable message explaining the code
+	Reason string
+
+	// PodsEvaluated is the number of pods the plugin tried to evict,
+	// including the pods rejected by the PreEvictionFilter extension point.
+	PodsEvaluated uint
+	// PodsEvicted is the number of evaluated pods which got evicted.
+	PodsEvicted uint
+	// PodsSkipped is the number of evaluated pods which did not get evicted.
+	PodsSkipped uint
+	// PodsQueued is the number of evaluated pods whose eviction got blocked by a pod disruption budget
+	// and queued for a retry at the end of the descheduling loop, which reports their final outcome.
+	PodsQueued uint
+}
+
+// NewStatus makes a Status out of the given code and reason
+func NewStatus(code Code, reason string) *Status {
+	return &Status{
+		Code:   code,
+		Reason: reason,
+	}
+}
+
+// AsStatus wraps an error in a Status
+func AsStatus(err error) *Status {
+	if err == nil {
+		return nil
+	}
+	return &Status{
+		Code:   Error,
+		Err:    err,
+		Reason: err.Error(),
+	}
+}
+
+// GetCode returns the code of the Status. A nil Status means Success.
+func (s *Status) GetCode() Code {
+	if s == nil {
+		return Success
+	}
+	if s.Err != nil {
+		return Error
+	}
+	return s.Code
+}
+
+// GetReason returns the reason of the Status
+func (s *Status) GetReason() string {
+	if s == nil {
+		return ""
+	}
+	if s.Reason == "" && s.Err != nil {
+		return s.Err.Error()
+	}
+	return s.Reason
+}
+
+// IsSuccess returns true if and only if the Status is nil or its code is Success
+func (s *Status) IsSuccess() bool {
+	return s.GetCode() == Success
 }
 
 // Plugin is the parent type for all the descheduling framework plugins.
@@ -83,11 +207,80 @@ type EvictorPlugin interface {
 	PreEvictionFilter(pod *v1.Pod) bool
 }
 
+// FilterReasonsPlugin is implemented by the Filter plugins telling why they reject a pod.
+// The evictor calls FilterReasons instead of Filter for them. The plugins only implementing
+// Filter keep working, their rejections are reported with the unknown reason.
+type FilterReasonsPlugin interface {
+	Plugin
+	// FilterReasons checks if a pod can be evicted and returns a reason per failed check, none accepting the pod.
+	// Every reason labels the pods_filtered metric, so it should not vary from pod to pod.
+	FilterReasons(pod *v1.Pod) []string
+}
+
+// PreEvictionFilterReasonsPlugin is implemented by the PreEvictionFilter plugins telling why they reject a pod.
+// The evictor calls PreEvictionFilterReasons instead of PreEvictionFilter for them.
+type PreEvictionFilterReasonsPlugin interface {
+	Plugin
+	// PreEvictionFilterReasons checks if a pod can be evicted right before eviction and returns a reason
+	// per failed check, none accepting the pod. Every reason labels the pods_filtered metric.
+	PreEvictionFilterReasons(pod *v1.Pod) []string
+}
+
+// ExplainPlugin is implemented by the Deschedule and Balance plugins deciding about every pod
+// on its own, so they can tell whether and why they select a given pod for eviction
+type ExplainPlugin interface {
+	Plugin
+	// Explain tells whether the plugin selects the pod for eviction when processing the nodes,
+	// regardless of the evictor and of the eviction limits, and why
+	Explain(pod *v1.Pod, nodes []*v1.Node) (selected bool, reason string)
+}
+
+// PreSortPlugin defines an extension point for ordering pods before the Sort
+// extension point is consulted. Order established by PreSort plugins takes
+// precedence over order established by Sort plugins.
+type PreSortPlugin interface {
+	Plugin
+	// PreLess reports whether pod1 should be evicted before pod2
+	PreLess(pod1, pod2 *v1.Pod) bool
+}
+
+// SortPlugin defines an extension point for ordering pods for eviction
+type SortPlugin interface {
+	Plugin
+	// Less reports whether pod1 should be evicted before pod2
+	Less(pod1, pod2 *v1.Pod) bool
+}
+
+// PreEvictPlugin defines an extension point called right before a pod gets evicted.
+// PreEvict plugins are called in their configured order once the pod passed the
+// PreEvictionFilter extension point.
+type PreEvictPlugin interface {
+	Plugin
+	// PreEvict can mutate the eviction through its options, e.g. to annotate
+	// the eviction or to set its grace period. Any status other than Success
+	// vetoes the eviction. The pod must not be modified.
+	PreEvict(ctx context.Context, pod *v1.Pod, opts *evictions.EvictOptions) *Status
+}
+
+// PostEvictPlugin defines an extension point called after every eviction attempt
+// which was not vetoed by a PreEvict plugin, whether the pod got evicted or not.
+// For an eviction blocked by a pod disruption budget and queued for a retry, it is
+// called with the final outcome once the descheduling loop retried the eviction.
+type PostEvictPlugin interface {
+	Plugin
+	// PostEvict is informed about the outcome of the eviction of the pod
+	PostEvict(ctx context.Context, pod *v1.Pod, opts evictions.EvictOptions, evicted bool)
+}
+
 type ExtensionPoint string
 
 const (
+	PreSortExtensionPoint           ExtensionPoint = "PreSort"
+	SortExtensionPoint              ExtensionPoint = "Sort"
 	DescheduleExtensionPoint        ExtensionPoint = "Deschedule"
 	BalanceExtensionPoint           ExtensionPoint = "Balance"
 	FilterExtensionPoint            ExtensionPoint = "Filter"
 	PreEvictionFilterExtensionPoint ExtensionPoint = "PreEvictionFilter"
+	PreEvictExtensionPoint          ExtensionPoint = "PreEvict"
+	PostEvictExtensionPoint         ExtensionPoint = "PostEvict"
 )